@@ -3,11 +3,14 @@ package asn1
 import (
 	"encoding/asn1"
 	"errors"
+	"fmt"
 	"math/big"
 )
 
 // AuthorityKeyIdentifier represents an X509 authority key identifier
-// extension as defined in RFC 5280 section 4.2.1.1.
+// extension as defined in RFC 5280 section 4.2.1.1. Issuer and SerialNumber
+// must either both be present or both be absent. ID is absent if it is nil,
+// so that an empty key identifier is retained when parsed and re-encoded.
 //
 // id-ce-authorityKeyIdentifier OBJECT IDENTIFIER ::=  { id-ce 35 }
 //
@@ -18,28 +21,132 @@ import (
 //
 //  KeyIdentifier ::= OCTET STRING
 type AuthorityKeyIdentifier struct {
-	ID           []byte        `asn1:"optional,tag:0"`
-	Issuer       asn1.RawValue `asn1:"optional,tag:1"`
-	SerialNumber *big.Int      `asn1:"optional,tag:2"`
+	ID           []byte
+	Issuer       GeneralNames
+	SerialNumber *big.Int
 }
 
+// Tag numbers for AuthorityKeyIdentifier structures.
+const (
+	akiTagKeyIdentifier = 0
+	akiTagIssuer        = 1
+	akiTagSerialNumber  = 2
+)
+
+// errAKIIssuerAndSerial is returned when only one of the issuer and serial
+// number of an authority key identifier is present.
+var errAKIIssuerAndSerial = errors.New("issuer and serial number must both be present or both be absent")
+
 // Marshal returns the ASN.1 DER-encoding of a value.
 func (e AuthorityKeyIdentifier) Marshal() ([]byte, error) {
-	return asn1.Marshal(e)
+
+	// The authorityCertIssuer and authorityCertSerialNumber fields MUST
+	// both be present or both be absent. See RFC 5280 section 4.2.1.1.
+	if e.Issuer.IsEmpty() != (e.SerialNumber == nil) {
+		return nil, errAKIIssuerAndSerial
+	}
+
+	var vals = []asn1.RawValue{}
+
+	if e.ID != nil {
+		vals = append(vals, asn1.RawValue{
+			Class: asn1.ClassContextSpecific,
+			Tag:   akiTagKeyIdentifier,
+			Bytes: e.ID,
+		})
+	}
+
+	if !e.Issuer.IsEmpty() {
+		der, err := e.Issuer.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		val, err := implicitTag(der, akiTagIssuer)
+		if err != nil {
+			return nil, err
+		}
+
+		der, err = asn1.Marshal(e.SerialNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		serial, err := implicitTag(der, akiTagSerialNumber)
+		if err != nil {
+			return nil, err
+		}
+
+		vals = append(vals, val, serial)
+	}
+
+	return asn1.Marshal(vals)
 }
 
 // Unmarshal parses an DER-encoded ASN.1 data structure and stores the result
 // in the object.
 func (e *AuthorityKeyIdentifier) Unmarshal(b []byte) error {
-	var tmp AuthorityKeyIdentifier
+	var vals []asn1.RawValue
 
-	rest, err := asn1.Unmarshal(b, &tmp)
+	rest, err := asn1.Unmarshal(b, &vals)
 	if err != nil {
 		return err
 	} else if len(rest) != 0 {
 		return errors.New("trailing bytes")
 	}
 
+	var tmp AuthorityKeyIdentifier
+	var last = -1
+
+	for _, val := range vals {
+		if val.Class != asn1.ClassContextSpecific || val.Tag <= last {
+			return errors.New("unexpected element in authority key identifier")
+		}
+		last = val.Tag
+
+		switch val.Tag {
+		case akiTagKeyIdentifier:
+			if val.IsCompound {
+				return errors.New("constructed key identifier")
+			}
+
+			tmp.ID = append([]byte{}, val.Bytes...)
+
+		case akiTagIssuer:
+			der, err := universalTag(val, asn1.TagSequence)
+			if err != nil {
+				return err
+			}
+
+			if err := tmp.Issuer.Unmarshal(der); err != nil {
+				return err
+			}
+
+			// GeneralNames must contain at least one name, and an empty
+			// issuer would not be re-encoded.
+			if tmp.Issuer.IsEmpty() {
+				return errors.New("empty issuer in authority key identifier")
+			}
+
+		case akiTagSerialNumber:
+			der, err := universalTag(val, asn1.TagInteger)
+			if err != nil {
+				return err
+			}
+
+			if _, err := asn1.Unmarshal(der, &tmp.SerialNumber); err != nil {
+				return err
+			}
+
+		default:
+			return fmt.Errorf("unexpected tag in authority key identifier: %d", val.Tag)
+		}
+	}
+
+	if tmp.Issuer.IsEmpty() != (tmp.SerialNumber == nil) {
+		return errAKIIssuerAndSerial
+	}
+
 	*e = tmp
 
 	return nil
@@ -0,0 +1,224 @@
+package asn1_test
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"github.com/paulgriffiths/pki/asn1"
+)
+
+// testAKIDER is the DER-encoding of testAKI. OpenSSL generates the same
+// encoding with "keyid:always,issuer:always", except that it encodes the
+// common name as a UTF8String rather than a PrintableString.
+const testAKIDER = "302e8014c75cf4b859f9bfd322c27e81b5a8614cf7bb9a55" +
+	"a113a411300f310d300b060355040313045465737482012a"
+
+var testAKI = asn1.AuthorityKeyIdentifier{
+	ID: []byte{
+		0xc7, 0x5c, 0xf4, 0xb8, 0x59, 0xf9, 0xbf, 0xd3, 0x22, 0xc2,
+		0x7e, 0x81, 0xb5, 0xa8, 0x61, 0x4c, 0xf7, 0xbb, 0x9a, 0x55,
+	},
+	Issuer: asn1.GeneralNames{
+		{DirectoryName: pkix.RDNSequence{{{Type: asn1.OIDCommonName, Value: "Test"}}}},
+	},
+	SerialNumber: big.NewInt(42),
+}
+
+func TestAuthorityKeyIdentifierMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		obj  asn1.AuthorityKeyIdentifier
+		want []byte
+		err  error
+	}{
+		{
+			name: "All",
+			obj:  testAKI,
+			want: mustDecodeHex(t, testAKIDER),
+		},
+		{
+			name: "IDOnly",
+			obj:  asn1.AuthorityKeyIdentifier{ID: []byte{1, 2, 3, 4}},
+			want: mustDecodeHex(t, "3006800401020304"),
+		},
+		{
+			name: "IssuerAndSerialNumber",
+			obj: asn1.AuthorityKeyIdentifier{
+				Issuer:       asn1.GeneralNames{{DNSName: "ca"}},
+				SerialNumber: big.NewInt(42),
+			},
+			want: mustDecodeHex(t, "3009a1048202636182012a"),
+		},
+		{
+			name: "Empty",
+			obj:  asn1.AuthorityKeyIdentifier{},
+			want: mustDecodeHex(t, "3000"),
+		},
+		{
+			name: "SerialNumberWithoutIssuer",
+			obj:  asn1.AuthorityKeyIdentifier{SerialNumber: big.NewInt(42)},
+			err:  errors.New("serial number without issuer"),
+		},
+		{
+			name: "IssuerWithoutSerialNumber",
+			obj:  asn1.AuthorityKeyIdentifier{Issuer: asn1.GeneralNames{{DNSName: "ca"}}},
+			err:  errors.New("issuer without serial number"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.obj.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !bytes.Equal(got, tc.want) {
+				t.Errorf("got %x, want %x", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAuthorityKeyIdentifierUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		der  []byte
+		want asn1.AuthorityKeyIdentifier
+		err  error
+	}{
+		{
+			name: "All",
+			der:  mustDecodeHex(t, testAKIDER),
+			want: testAKI,
+		},
+		{
+			name: "All/UTF8String",
+			der: mustDecodeHex(t, "302e8014c75cf4b859f9bfd322c27e81b5a8614cf7bb9a55"+
+				"a113a411300f310d300b06035504030c045465737482012a"),
+			want: testAKI,
+		},
+		{
+			name: "IDOnly",
+			der:  mustDecodeHex(t, "3006800401020304"),
+			want: asn1.AuthorityKeyIdentifier{ID: []byte{1, 2, 3, 4}},
+		},
+		{
+			name: "Empty",
+			der:  mustDecodeHex(t, "3000"),
+			want: asn1.AuthorityKeyIdentifier{},
+		},
+		{
+			name: "SerialNumberWithoutIssuer",
+			der:  mustDecodeHex(t, "300382012a"),
+			err:  errors.New("serial number without issuer"),
+		},
+		{
+			name: "IssuerWithoutSerialNumber",
+			der:  mustDecodeHex(t, "3006a10482026361"),
+			err:  errors.New("issuer without serial number"),
+		},
+		{
+			name: "OutOfOrder",
+			der:  mustDecodeHex(t, "300982012a800401020304"),
+			err:  errors.New("out of order"),
+		},
+		{
+			name: "ConstructedKeyIdentifier",
+			der:  mustDecodeHex(t, "3006a00401020304"),
+			err:  errors.New("constructed key identifier"),
+		},
+		{
+			name: "UnknownTag",
+			der:  mustDecodeHex(t, "3003830100"),
+			err:  errors.New("unknown tag"),
+		},
+		{
+			name: "EmptyKeyIdentifier",
+			der:  mustDecodeHex(t, "30028000"),
+			want: asn1.AuthorityKeyIdentifier{ID: []byte{}},
+		},
+		{
+			name: "EmptyIssuer",
+			der:  mustDecodeHex(t, "3007a10082012a"),
+			err:  errors.New("empty issuer"),
+		},
+		{
+			name: "TrailingBytes",
+			der:  mustDecodeHex(t, "300680040102030400"),
+			err:  errors.New("trailing bytes"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got asn1.AuthorityKeyIdentifier
+			err := got.Unmarshal(tc.der)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAuthorityKeyIdentifierRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		der  string
+	}{
+		{
+			name: "EmptyKeyIdentifier",
+			der:  "30028000",
+		},
+		{
+			name: "OtherNameIssuer",
+			der:  "3015a110a00e06032a0304a0070c05416c69636582012a",
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var der = mustDecodeHex(t, tc.der)
+
+			var aki asn1.AuthorityKeyIdentifier
+			if err := aki.Unmarshal(der); err != nil {
+				t.Fatalf("couldn't unmarshal authority key identifier: %v", err)
+			}
+
+			got, err := aki.Marshal()
+			if err != nil {
+				t.Fatalf("couldn't marshal authority key identifier: %v", err)
+			}
+
+			if !bytes.Equal(got, der) {
+				t.Errorf("got %x, want %x", got, der)
+			}
+		})
+	}
+}
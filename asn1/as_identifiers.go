@@ -0,0 +1,426 @@
+package asn1
+
+import (
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// ASIdentifiers represents an autonomous system identifier delegation
+// extension as defined in RFC 3779 section 3.2.3. At least one of ASNum and
+// RDI should be present.
+//
+// id-pe-autonomousSysIds OBJECT IDENTIFIER ::= { id-pe 8 }
+//
+// ASIdentifiers ::= SEQUENCE {
+//      asnum               [0] EXPLICIT ASIdentifierChoice OPTIONAL,
+//      rdi                 [1] EXPLICIT ASIdentifierChoice OPTIONAL }
+type ASIdentifiers struct {
+	ASNum ASIdentifierChoice
+	RDI   ASIdentifierChoice
+}
+
+// ASIdentifierChoice represents a set of AS identifier resources as defined
+// in RFC 3779 section 3.2.3.2, and is absent if it is the zero value. At most
+// one of Inherit and IDs should be set. IDs must be listed in ascending
+// order, and may be neither overlapping nor adjacent.
+//
+// ASIdentifierChoice ::= CHOICE {
+//      inherit              NULL,
+//      asIdsOrRanges        SEQUENCE OF ASIdOrRange }
+type ASIdentifierChoice struct {
+	Inherit bool
+	IDs     []ASIDOrRange
+}
+
+// ASIDOrRange represents an AS identifier or an inclusive range of AS
+// identifiers as defined in RFC 3779 section 3.2.3.3. A single AS identifier
+// has equal Min and Max values, and is encoded as an id rather than a range.
+//
+// ASIdOrRange ::= CHOICE {
+//      id                   ASId,
+//      range                ASRange }
+//
+// ASRange ::= SEQUENCE {
+//      min                  ASId,
+//      max                  ASId }
+//
+// ASId ::= INTEGER
+type ASIDOrRange struct {
+	Min int64
+	Max int64
+}
+
+// AS identifiers tag values.
+const (
+	asTagASNum = iota
+	asTagRDI
+)
+
+// maxASID is the largest AS identifier.
+const maxASID = 1<<32 - 1
+
+// asRange is the intermediate representation of an AS identifier range.
+type asRange struct {
+	Min int64
+	Max int64
+}
+
+// Marshal returns the ASN.1 DER-encoding of a value.
+func (e ASIdentifiers) Marshal() ([]byte, error) {
+	if e.ASNum.IsEmpty() && e.RDI.IsEmpty() {
+		return nil, errors.New("no AS identifiers specified")
+	}
+
+	var vals []asn1.RawValue
+
+	for _, c := range []struct {
+		choice ASIdentifierChoice
+		tag    int
+	}{
+		{e.ASNum, asTagASNum},
+		{e.RDI, asTagRDI},
+	} {
+		if c.choice.IsEmpty() {
+			continue
+		}
+
+		val, err := c.choice.raw()
+		if err != nil {
+			return nil, err
+		}
+
+		der, err := asn1.Marshal(val)
+		if err != nil {
+			return nil, err
+		}
+
+		vals = append(vals, explicitTag(der, c.tag))
+	}
+
+	return asn1.Marshal(vals)
+}
+
+// Unmarshal parses an DER-encoded ASN.1 data structure and stores the result
+// in the object.
+func (e *ASIdentifiers) Unmarshal(b []byte) error {
+	var vals []asn1.RawValue
+
+	rest, err := asn1.Unmarshal(b, &vals)
+	if err != nil {
+		return err
+	} else if len(rest) != 0 {
+		return errors.New("trailing bytes")
+	}
+
+	if len(vals) == 0 {
+		return errors.New("no AS identifiers specified")
+	}
+
+	var tmp ASIdentifiers
+	var last = -1
+
+	for _, val := range vals {
+		if val.Class != asn1.ClassContextSpecific || !val.IsCompound || val.Tag <= last {
+			return errors.New("unexpected element in AS identifiers")
+		}
+		last = val.Tag
+
+		var choice ASIdentifierChoice
+
+		switch val.Tag {
+		case asTagASNum:
+			err = choice.unmarshalExplicit(val)
+			tmp.ASNum = choice
+
+		case asTagRDI:
+			err = choice.unmarshalExplicit(val)
+			tmp.RDI = choice
+
+		default:
+			err = fmt.Errorf("unexpected tag in AS identifiers: %d", val.Tag)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	*e = tmp
+
+	return nil
+}
+
+// Contains returns true if every AS identifier resource in child is also
+// among the resources in e, as RFC 3779 section 3.3 requires of a
+// certificate with respect to its issuer. Resources which child inherits are
+// contained if e includes resources of the same type. Since the AS
+// identifiers which e inherits are not known, inherited resources in e
+// contain only inherited resources, and Resolve should be used to replace
+// inherited resources before calling Contains. Invalid AS identifiers are
+// never contained.
+func (e ASIdentifiers) Contains(child ASIdentifiers) bool {
+	return e.ASNum.contains(child.ASNum) && e.RDI.contains(child.RDI)
+}
+
+// Resolve returns a copy of e in which any inherited resources are replaced
+// with the resources of the same type from issuer, which should itself
+// already be resolved. An error is returned if issuer does not include
+// resources which e inherits.
+func (e ASIdentifiers) Resolve(issuer ASIdentifiers) (ASIdentifiers, error) {
+	var tmp = e
+
+	if e.ASNum.Inherit {
+		if issuer.ASNum.IsEmpty() {
+			return ASIdentifiers{}, errors.New("issuer has no AS number resources")
+		}
+
+		tmp.ASNum = issuer.ASNum
+	}
+
+	if e.RDI.Inherit {
+		if issuer.RDI.IsEmpty() {
+			return ASIdentifiers{}, errors.New("issuer has no routing domain identifier resources")
+		}
+
+		tmp.RDI = issuer.RDI
+	}
+
+	return tmp, nil
+}
+
+// IsEmpty returns true if the AS identifier choice is absent.
+func (e ASIdentifierChoice) IsEmpty() bool {
+	return !e.Inherit && len(e.IDs) == 0
+}
+
+// raw converts an ASIdentifierChoice to a raw value.
+func (e ASIdentifierChoice) raw() (asn1.RawValue, error) {
+	if e.Inherit && len(e.IDs) != 0 {
+		return asn1.RawValue{}, errors.New("AS identifier choice both inherits and lists identifiers")
+	}
+
+	var vals []asn1.RawValue
+	var last int64 = -2
+
+	for _, id := range e.IDs {
+		if err := id.check(last); err != nil {
+			return asn1.RawValue{}, err
+		}
+		last = id.Max
+
+		var der []byte
+		var err error
+
+		if id.Min == id.Max {
+			der, err = asn1.Marshal(id.Min)
+		} else {
+			der, err = asn1.Marshal(asRange{Min: id.Min, Max: id.Max})
+		}
+
+		if err != nil {
+			return asn1.RawValue{}, err
+		}
+
+		vals = append(vals, asn1.RawValue{FullBytes: der})
+	}
+
+	return inheritChoiceRaw(e.Inherit, vals)
+}
+
+// unmarshalExplicit parses an EXPLICIT tagged AS identifier choice.
+func (e *ASIdentifierChoice) unmarshalExplicit(val asn1.RawValue) error {
+	var choice asn1.RawValue
+
+	rest, err := asn1.Unmarshal(val.Bytes, &choice)
+	if err != nil {
+		return err
+	} else if len(rest) != 0 {
+		return errors.New("trailing bytes")
+	}
+
+	inherit, vals, err := inheritChoiceFromRaw(choice)
+	if err != nil {
+		return err
+	}
+
+	var tmp = ASIdentifierChoice{Inherit: inherit}
+	var last int64 = -2
+
+	for _, v := range vals {
+		id, err := asIDOrRangeFromRaw(v)
+		if err != nil {
+			return err
+		}
+
+		if err := id.check(last); err != nil {
+			return err
+		}
+		last = id.Max
+
+		tmp.IDs = append(tmp.IDs, id)
+	}
+
+	*e = tmp
+
+	return nil
+}
+
+// contains returns true if every AS identifier in child lies within an AS
+// identifier range in e.
+func (e ASIdentifierChoice) contains(child ASIdentifierChoice) bool {
+	switch {
+	case child.IsEmpty():
+		return true
+
+	case e.IsEmpty():
+		return false
+
+	case child.Inherit:
+		return true
+
+	case e.Inherit:
+		return false
+	}
+
+	for _, cid := range child.IDs {
+		if cid.check(-2) != nil {
+			return false
+		}
+
+		var found bool
+
+		for _, id := range e.IDs {
+			if id.Min <= cid.Min && cid.Max <= id.Max {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// check returns an error if an AS identifier range is invalid, or does not
+// follow the AS identifier range ending at last without overlapping or
+// being adjacent to it.
+func (e ASIDOrRange) check(last int64) error {
+	if e.Min < 0 || e.Max > maxASID || e.Min > e.Max {
+		return fmt.Errorf("invalid AS identifier range: %d-%d", e.Min, e.Max)
+	}
+
+	if e.Min <= last+1 {
+		return errors.New("AS identifiers out of order, overlapping or adjacent")
+	}
+
+	return nil
+}
+
+// asIDOrRangeFromRaw parses an AS identifier or range of AS identifiers.
+func asIDOrRangeFromRaw(val asn1.RawValue) (ASIDOrRange, error) {
+	if val.Class != asn1.ClassUniversal {
+		return ASIDOrRange{}, errors.New("invalid AS identifier entry class")
+	}
+
+	switch val.Tag {
+	case asn1.TagInteger:
+		var id int64
+		if rest, err := asn1.Unmarshal(val.FullBytes, &id); err != nil {
+			return ASIDOrRange{}, err
+		} else if len(rest) != 0 {
+			return ASIDOrRange{}, errors.New("trailing bytes")
+		}
+
+		return ASIDOrRange{Min: id, Max: id}, nil
+
+	case asn1.TagSequence:
+		var r asRange
+		if rest, err := asn1.Unmarshal(val.FullBytes, &r); err != nil {
+			return ASIDOrRange{}, err
+		} else if len(rest) != 0 {
+			return ASIDOrRange{}, errors.New("trailing bytes")
+		}
+
+		if r.Min == r.Max {
+			return ASIDOrRange{}, errors.New("single AS identifier encoded as a range")
+		}
+
+		return ASIDOrRange{Min: r.Min, Max: r.Max}, nil
+	}
+
+	return ASIDOrRange{}, fmt.Errorf("unexpected tag in AS identifier entry: %d", val.Tag)
+}
+
+// asIdentifierChoiceJSON is the JSON representation of an
+// ASIdentifierChoice.
+type asIdentifierChoiceJSON struct {
+	Inherit bool          `json:"inherit,omitempty"`
+	IDs     []ASIDOrRange `json:"ids,omitempty"`
+}
+
+// MarshalJSON returns the JSON encoding of a value. The choice is encoded as
+// an object with either an "inherit" boolean or an "ids" list. See
+// ASIDOrRange.MarshalJSON.
+func (e ASIdentifierChoice) MarshalJSON() ([]byte, error) {
+	return json.Marshal(asIdentifierChoiceJSON(e))
+}
+
+// UnmarshalJSON parses a JSON-encoded value and stores the result in the
+// object.
+func (e *ASIdentifierChoice) UnmarshalJSON(b []byte) error {
+	var tmp asIdentifierChoiceJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = ASIdentifierChoice(tmp)
+
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of a value. A single AS identifier
+// is encoded as a decimal string, such as "64496", and a range as a string
+// containing the lowest and highest identifiers separated by a hyphen, such
+// as "64500-64510".
+func (e ASIDOrRange) MarshalJSON() ([]byte, error) {
+	if e.Min == e.Max {
+		return json.Marshal(strconv.FormatInt(e.Min, 10))
+	}
+
+	return json.Marshal(fmt.Sprintf("%d-%d", e.Min, e.Max))
+}
+
+// UnmarshalJSON parses a JSON-encoded value and stores the result in the
+// object.
+func (e *ASIDOrRange) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	var min, max = s, s
+	if i := strings.IndexByte(s, '-'); i != -1 {
+		min, max = s[:i], s[i+1:]
+	}
+
+	lo, err := strconv.ParseInt(min, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid AS identifier range: %q", s)
+	}
+
+	hi, err := strconv.ParseInt(max, 10, 64)
+	if err != nil {
+		return fmt.Errorf("invalid AS identifier range: %q", s)
+	}
+
+	*e = ASIDOrRange{Min: lo, Max: hi}
+
+	return nil
+}
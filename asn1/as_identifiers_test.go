@@ -0,0 +1,351 @@
+package asn1_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+const testASIdentifiers = "3019a0133011020300fbf0300a020300fbf4020300fbfea1020500"
+
+func TestASIdentifiersMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		obj  pgasn1.ASIdentifiers
+		want string
+		err  error
+	}{
+		{
+			name: "OK",
+			obj: pgasn1.ASIdentifiers{
+				ASNum: pgasn1.ASIdentifierChoice{
+					IDs: []pgasn1.ASIDOrRange{
+						{Min: 64496, Max: 64496},
+						{Min: 64500, Max: 64510},
+					},
+				},
+				RDI: pgasn1.ASIdentifierChoice{Inherit: true},
+			},
+			want: testASIdentifiers,
+		},
+		{
+			name: "RDIOnly",
+			obj: pgasn1.ASIdentifiers{
+				RDI: pgasn1.ASIdentifierChoice{
+					IDs: []pgasn1.ASIDOrRange{{Min: 1<<32 - 1, Max: 1<<32 - 1}},
+				},
+			},
+			want: "300ba10930070205" + "00ffffffff",
+		},
+		{
+			name: "Empty",
+			obj:  pgasn1.ASIdentifiers{},
+			err:  errors.New("empty"),
+		},
+		{
+			name: "InheritAndIDs",
+			obj: pgasn1.ASIdentifiers{
+				ASNum: pgasn1.ASIdentifierChoice{
+					Inherit: true,
+					IDs:     []pgasn1.ASIDOrRange{{Min: 1, Max: 1}},
+				},
+			},
+			err: errors.New("inherit and IDs"),
+		},
+		{
+			name: "Negative",
+			obj: pgasn1.ASIdentifiers{
+				ASNum: pgasn1.ASIdentifierChoice{
+					IDs: []pgasn1.ASIDOrRange{{Min: -1, Max: 1}},
+				},
+			},
+			err: errors.New("negative"),
+		},
+		{
+			name: "TooLarge",
+			obj: pgasn1.ASIdentifiers{
+				ASNum: pgasn1.ASIdentifierChoice{
+					IDs: []pgasn1.ASIDOrRange{{Min: 1, Max: 1 << 32}},
+				},
+			},
+			err: errors.New("too large"),
+		},
+		{
+			name: "MinAfterMax",
+			obj: pgasn1.ASIdentifiers{
+				ASNum: pgasn1.ASIdentifierChoice{
+					IDs: []pgasn1.ASIDOrRange{{Min: 2, Max: 1}},
+				},
+			},
+			err: errors.New("min after max"),
+		},
+		{
+			name: "Adjacent",
+			obj: pgasn1.ASIdentifiers{
+				ASNum: pgasn1.ASIdentifierChoice{
+					IDs: []pgasn1.ASIDOrRange{
+						{Min: 1, Max: 2},
+						{Min: 3, Max: 3},
+					},
+				},
+			},
+			err: errors.New("adjacent"),
+		},
+		{
+			name: "OutOfOrder",
+			obj: pgasn1.ASIdentifiers{
+				ASNum: pgasn1.ASIdentifierChoice{
+					IDs: []pgasn1.ASIDOrRange{
+						{Min: 10, Max: 10},
+						{Min: 1, Max: 1},
+					},
+				},
+			},
+			err: errors.New("out of order"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.obj.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if want := mustDecodeHex(t, tc.want); !bytes.Equal(got, want) {
+				t.Errorf("got %x, want %x", got, want)
+			}
+		})
+	}
+}
+
+func TestASIdentifiersUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		der  string
+		want pgasn1.ASIdentifiers
+		err  error
+	}{
+		{
+			name: "OK",
+			der:  testASIdentifiers,
+			want: pgasn1.ASIdentifiers{
+				ASNum: pgasn1.ASIdentifierChoice{
+					IDs: []pgasn1.ASIDOrRange{
+						{Min: 64496, Max: 64496},
+						{Min: 64500, Max: 64510},
+					},
+				},
+				RDI: pgasn1.ASIdentifierChoice{Inherit: true},
+			},
+		},
+		{
+			name: "Empty",
+			der:  "3000",
+			err:  errors.New("empty"),
+		},
+		{
+			name: "EmptyChoice",
+			der:  "3004a0023000",
+			err:  errors.New("empty choice"),
+		},
+		{
+			name: "OutOfOrderTags",
+			der:  "3008a1020500a0020500",
+			err:  errors.New("out of order tags"),
+		},
+		{
+			name: "BadTag",
+			der:  "3004a2020500",
+			err:  errors.New("bad tag"),
+		},
+		{
+			name: "ImplicitTag",
+			der:  "3002" + "8000",
+			err:  errors.New("implicit tag"),
+		},
+		{
+			name: "SingleIDAsRange",
+			der:  "300ca00a3008" + "3006020101020101",
+			err:  errors.New("single ID as range"),
+		},
+		{
+			name: "Negative",
+			der:  "3007a005300302" + "01ff",
+			err:  errors.New("negative"),
+		},
+		{
+			name: "Overlapping",
+			der:  "300fa00d300b" + "3006020101020103" + "020102",
+			err:  errors.New("overlapping"),
+		},
+		{
+			name: "TrailingBytes",
+			der:  "3004a0020500" + "00",
+			err:  errors.New("trailing bytes"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got pgasn1.ASIdentifiers
+			err := got.Unmarshal(mustDecodeHex(t, tc.der))
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestASIdentifiersContains(t *testing.T) {
+	t.Parallel()
+
+	var issuer = pgasn1.ASIdentifiers{
+		ASNum: pgasn1.ASIdentifierChoice{
+			IDs: []pgasn1.ASIDOrRange{
+				{Min: 64496, Max: 64496},
+				{Min: 64500, Max: 64510},
+			},
+		},
+		RDI: pgasn1.ASIdentifierChoice{Inherit: true},
+	}
+
+	var testcases = []struct {
+		name  string
+		child pgasn1.ASIdentifiers
+		want  bool
+	}{
+		{
+			name: "Contained",
+			child: pgasn1.ASIdentifiers{
+				ASNum: pgasn1.ASIdentifierChoice{
+					IDs: []pgasn1.ASIDOrRange{
+						{Min: 64496, Max: 64496},
+						{Min: 64502, Max: 64505},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Inherited",
+			child: pgasn1.ASIdentifiers{
+				ASNum: pgasn1.ASIdentifierChoice{Inherit: true},
+				RDI:   pgasn1.ASIdentifierChoice{Inherit: true},
+			},
+			want: true,
+		},
+		{
+			name:  "Empty",
+			child: pgasn1.ASIdentifiers{},
+			want:  true,
+		},
+		{
+			name: "OutsideRange",
+			child: pgasn1.ASIdentifiers{
+				ASNum: pgasn1.ASIdentifierChoice{
+					IDs: []pgasn1.ASIDOrRange{{Min: 64505, Max: 64511}},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "SpanningRanges",
+			child: pgasn1.ASIdentifiers{
+				ASNum: pgasn1.ASIdentifierChoice{
+					IDs: []pgasn1.ASIDOrRange{{Min: 64496, Max: 64500}},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "IssuerInherits",
+			child: pgasn1.ASIdentifiers{
+				RDI: pgasn1.ASIdentifierChoice{
+					IDs: []pgasn1.ASIDOrRange{{Min: 1, Max: 1}},
+				},
+			},
+			want: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := issuer.Contains(tc.child); got != tc.want {
+				t.Errorf("got %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestASIdentifiersResolve(t *testing.T) {
+	t.Parallel()
+
+	var issuer = pgasn1.ASIdentifiers{
+		ASNum: pgasn1.ASIdentifierChoice{
+			IDs: []pgasn1.ASIDOrRange{{Min: 64496, Max: 64511}},
+		},
+	}
+
+	var testcases = []struct {
+		name  string
+		child pgasn1.ASIdentifiers
+		want  pgasn1.ASIdentifiers
+		err   error
+	}{
+		{
+			name: "OK",
+			child: pgasn1.ASIdentifiers{
+				ASNum: pgasn1.ASIdentifierChoice{Inherit: true},
+			},
+			want: issuer,
+		},
+		{
+			name: "MissingRDI",
+			child: pgasn1.ASIdentifiers{
+				RDI: pgasn1.ASIdentifierChoice{Inherit: true},
+			},
+			err: errors.New("missing RDI"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.child.Resolve(issuer)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
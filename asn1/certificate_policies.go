@@ -0,0 +1,731 @@
+package asn1
+
+import (
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode/utf16"
+	"unicode/utf8"
+)
+
+// CertificatePolicies represents an X509 certificate policies extension as
+// defined in RFC 5280 section 4.2.1.4.
+//
+// id-ce-certificatePolicies OBJECT IDENTIFIER ::=  { id-ce 32 }
+//
+// certificatePolicies ::= SEQUENCE SIZE (1..MAX) OF PolicyInformation
+type CertificatePolicies []PolicyInformation
+
+// PolicyInformation represents a policy information term as defined in RFC
+// 5280 section 4.2.1.4.
+//
+// PolicyInformation ::= SEQUENCE {
+//      policyIdentifier   CertPolicyId,
+//      policyQualifiers   SEQUENCE SIZE (1..MAX) OF
+//                              PolicyQualifierInfo OPTIONAL }
+//
+// CertPolicyId ::= OBJECT IDENTIFIER
+type PolicyInformation struct {
+	Policy     asn1.ObjectIdentifier
+	Qualifiers []PolicyQualifierInfo
+}
+
+// PolicyQualifierInfo represents a policy qualifier as defined in RFC 5280
+// section 4.2.1.4. CPSURI is used when ID is OIDPolicyQualifierCPS,
+// UserNotice is used when ID is OIDPolicyQualifierUserNotice, and Raw
+// contains the encoded qualifier for any other qualifier ID.
+//
+// Some non-conforming CAs issue user notices with display text which is
+// longer than 200 characters, empty, or contains control characters in a
+// VisibleString. RFC 5280 requires certificate users to handle such text
+// gracefully, so when parsing, a user notice which could not be encoded
+// again is stored in Raw instead of UserNotice, and Raw is used in place of
+// UserNotice when it is present.
+//
+// PolicyQualifierInfo ::= SEQUENCE {
+//      policyQualifierId  PolicyQualifierId,
+//      qualifier          ANY DEFINED BY policyQualifierId }
+//
+// CPSuri ::= IA5String
+type PolicyQualifierInfo struct {
+	ID         asn1.ObjectIdentifier
+	CPSURI     string
+	UserNotice UserNotice
+	Raw        asn1.RawValue
+}
+
+// UserNotice represents a user notice policy qualifier as defined in RFC 5280
+// section 4.2.1.4. NoticeRef is absent if its organization is zero, and
+// ExplicitText is absent if it is zero. See DisplayText.IsZero.
+//
+// UserNotice ::= SEQUENCE {
+//      noticeRef        NoticeReference OPTIONAL,
+//      explicitText     DisplayText OPTIONAL }
+type UserNotice struct {
+	NoticeRef    NoticeReference
+	ExplicitText DisplayText
+}
+
+// NoticeReference represents a notice reference as defined in RFC 5280
+// section 4.2.1.4.
+//
+// NoticeReference ::= SEQUENCE {
+//      organization     DisplayText,
+//      noticeNumbers    SEQUENCE OF INTEGER }
+type NoticeReference struct {
+	Organization  DisplayText
+	NoticeNumbers []int
+}
+
+// DisplayText represents a display text string as defined in RFC 5280 section
+// 4.2.1.4. Type is the ASN.1 tag of the selected string type, and if zero
+// defaults to UTF8String as recommended by RFC 6818 section 3. Text must
+// contain between 1 and 200 characters. See PolicyQualifierInfo for how
+// non-conforming display text is handled when parsing.
+//
+// DisplayText ::= CHOICE {
+//      ia5String        IA5String      (SIZE (1..200)),
+//      visibleString    VisibleString  (SIZE (1..200)),
+//      bmpString        BMPString      (SIZE (1..200)),
+//      utf8String       UTF8String     (SIZE (1..200)) }
+type DisplayText struct {
+	Type int
+	Text string
+}
+
+// ASN.1 universal tags which are not defined in encoding/asn1.
+const (
+	TagVisibleString = 26
+	TagBMPString     = 30
+)
+
+// maxDisplayTextLength is the maximum number of characters in a DisplayText.
+const maxDisplayTextLength = 200
+
+// policyInformation is the intermediate representation of a
+// PolicyInformation.
+type policyInformation struct {
+	Policy     asn1.ObjectIdentifier
+	Qualifiers []policyQualifierInfo `asn1:"optional"`
+}
+
+// policyQualifierInfo is the intermediate representation of a
+// PolicyQualifierInfo.
+type policyQualifierInfo struct {
+	ID        asn1.ObjectIdentifier
+	Qualifier asn1.RawValue
+}
+
+// noticeReference is the intermediate representation of a NoticeReference.
+type noticeReference struct {
+	Organization  asn1.RawValue
+	NoticeNumbers []int
+}
+
+// Marshal returns the ASN.1 DER-encoding of a value.
+func (e CertificatePolicies) Marshal() ([]byte, error) {
+	if len(e) == 0 {
+		return nil, errors.New("no policies specified")
+	}
+
+	var tmp []policyInformation
+
+	for i, info := range e {
+		if len(info.Policy) == 0 {
+			return nil, errors.New("no policy identifier specified")
+		}
+
+		// A certificate policy OID MUST NOT appear more than once. See RFC
+		// 5280 section 4.2.1.4.
+		for _, prev := range e[:i] {
+			if prev.Policy.Equal(info.Policy) {
+				return nil, fmt.Errorf("duplicate policy identifier: %v", info.Policy)
+			}
+		}
+
+		var raw = policyInformation{Policy: info.Policy}
+
+		for _, q := range info.Qualifiers {
+			val, err := q.raw()
+			if err != nil {
+				return nil, err
+			}
+
+			raw.Qualifiers = append(raw.Qualifiers, policyQualifierInfo{
+				ID:        q.ID,
+				Qualifier: val,
+			})
+		}
+
+		tmp = append(tmp, raw)
+	}
+
+	return asn1.Marshal(tmp)
+}
+
+// Unmarshal parses an DER-encoded ASN.1 data structure and stores the result
+// in the object.
+func (e *CertificatePolicies) Unmarshal(b []byte) error {
+	var raw []policyInformation
+
+	rest, err := asn1.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	} else if len(rest) != 0 {
+		return errors.New("trailing bytes")
+	}
+
+	var tmp CertificatePolicies
+
+	for _, r := range raw {
+		for _, prev := range tmp {
+			if prev.Policy.Equal(r.Policy) {
+				return fmt.Errorf("duplicate policy identifier: %v", r.Policy)
+			}
+		}
+
+		var info = PolicyInformation{Policy: r.Policy}
+
+		for _, rq := range r.Qualifiers {
+			q, err := qualifierFromRaw(rq)
+			if err != nil {
+				return err
+			}
+
+			info.Qualifiers = append(info.Qualifiers, q)
+		}
+
+		tmp = append(tmp, info)
+	}
+
+	*e = tmp
+
+	return nil
+}
+
+// raw returns the encoded qualifier.
+func (e PolicyQualifierInfo) raw() (asn1.RawValue, error) {
+	switch {
+	case e.ID.Equal(OIDPolicyQualifierCPS):
+		if err := isIA5String(e.CPSURI); err != nil {
+			return asn1.RawValue{}, err
+		}
+
+		return asn1.RawValue{
+			Class: asn1.ClassUniversal,
+			Tag:   asn1.TagIA5String,
+			Bytes: []byte(e.CPSURI),
+		}, nil
+
+	case e.ID.Equal(OIDPolicyQualifierUserNotice):
+		if len(e.Raw.FullBytes) != 0 {
+			return e.Raw, nil
+		}
+
+		return e.UserNotice.raw()
+
+	case len(e.ID) == 0:
+		return asn1.RawValue{}, errors.New("no policy qualifier identifier specified")
+	}
+
+	if len(e.Raw.FullBytes) == 0 && len(e.Raw.Bytes) == 0 && e.Raw.Tag == 0 {
+		return asn1.RawValue{}, fmt.Errorf("no value for policy qualifier %v", e.ID)
+	}
+
+	return e.Raw, nil
+}
+
+// qualifierFromRaw converts the intermediate representation of a
+// PolicyQualifierInfo.
+func qualifierFromRaw(raw policyQualifierInfo) (PolicyQualifierInfo, error) {
+	var q = PolicyQualifierInfo{ID: raw.ID}
+
+	switch {
+	case raw.ID.Equal(OIDPolicyQualifierCPS):
+		if raw.Qualifier.Class != asn1.ClassUniversal || raw.Qualifier.Tag != asn1.TagIA5String {
+			return PolicyQualifierInfo{}, errors.New("CPS URI is not an IA5String")
+		}
+
+		q.CPSURI = string(raw.Qualifier.Bytes)
+		if err := isIA5String(q.CPSURI); err != nil {
+			return PolicyQualifierInfo{}, err
+		}
+
+	case raw.ID.Equal(OIDPolicyQualifierUserNotice):
+		if err := q.UserNotice.unmarshalRaw(raw.Qualifier); err != nil {
+			return PolicyQualifierInfo{}, err
+		}
+
+		if _, err := q.UserNotice.raw(); err != nil {
+			q.UserNotice = UserNotice{}
+			q.Raw = raw.Qualifier
+		}
+
+	default:
+		q.Raw = raw.Qualifier
+	}
+
+	return q, nil
+}
+
+// raw returns the encoded user notice.
+func (e UserNotice) raw() (asn1.RawValue, error) {
+	var vals []asn1.RawValue
+
+	if !e.NoticeRef.Organization.IsZero() {
+		org, err := e.NoticeRef.Organization.raw()
+		if err != nil {
+			return asn1.RawValue{}, err
+		}
+
+		der, err := asn1.Marshal(noticeReference{
+			Organization:  org,
+			NoticeNumbers: e.NoticeRef.NoticeNumbers,
+		})
+		if err != nil {
+			return asn1.RawValue{}, err
+		}
+
+		vals = append(vals, asn1.RawValue{FullBytes: der})
+	}
+
+	if !e.ExplicitText.IsZero() {
+		text, err := e.ExplicitText.raw()
+		if err != nil {
+			return asn1.RawValue{}, err
+		}
+
+		vals = append(vals, text)
+	}
+
+	der, err := asn1.Marshal(vals)
+	if err != nil {
+		return asn1.RawValue{}, err
+	}
+
+	return asn1.RawValue{FullBytes: der}, nil
+}
+
+// unmarshalRaw parses a user notice from a raw value and stores the result
+// in the object.
+func (e *UserNotice) unmarshalRaw(val asn1.RawValue) error {
+	var vals []asn1.RawValue
+
+	rest, err := asn1.Unmarshal(val.FullBytes, &vals)
+	if err != nil {
+		return err
+	} else if len(rest) != 0 {
+		return errors.New("trailing bytes")
+	}
+
+	var tmp UserNotice
+
+	if len(vals) > 0 && vals[0].Class == asn1.ClassUniversal && vals[0].Tag == asn1.TagSequence {
+		var ref noticeReference
+		if rest, err := asn1.Unmarshal(vals[0].FullBytes, &ref); err != nil {
+			return err
+		} else if len(rest) != 0 {
+			return errors.New("trailing bytes")
+		}
+
+		if err := tmp.NoticeRef.Organization.unmarshalRaw(ref.Organization); err != nil {
+			return err
+		}
+		tmp.NoticeRef.NoticeNumbers = ref.NoticeNumbers
+
+		vals = vals[1:]
+	}
+
+	if len(vals) > 0 {
+		if err := tmp.ExplicitText.unmarshalRaw(vals[0]); err != nil {
+			return err
+		}
+
+		vals = vals[1:]
+	}
+
+	if len(vals) != 0 {
+		return errors.New("unexpected elements in user notice")
+	}
+
+	*e = tmp
+
+	return nil
+}
+
+// IsZero returns true if the display text is absent, which is the case if
+// both Type and Text are zero.
+func (e DisplayText) IsZero() bool {
+	return e.Type == 0 && e.Text == ""
+}
+
+// raw returns the encoded display text.
+func (e DisplayText) raw() (asn1.RawValue, error) {
+	if n := utf8.RuneCountInString(e.Text); n == 0 || n > maxDisplayTextLength {
+		return asn1.RawValue{}, fmt.Errorf("invalid display text length: %d", n)
+	}
+
+	var val = asn1.RawValue{
+		Class: asn1.ClassUniversal,
+		Tag:   e.Type,
+		Bytes: []byte(e.Text),
+	}
+
+	switch e.Type {
+	case asn1.TagIA5String:
+		if err := isIA5String(e.Text); err != nil {
+			return asn1.RawValue{}, err
+		}
+
+	case TagVisibleString:
+		for _, r := range e.Text {
+			if r < 0x20 || r > 0x7e {
+				return asn1.RawValue{}, fmt.Errorf("%q cannot be encoded as a VisibleString", e.Text)
+			}
+		}
+
+	case TagBMPString:
+		b, err := encodeBMPString(e.Text)
+		if err != nil {
+			return asn1.RawValue{}, err
+		}
+		val.Bytes = b
+
+	case asn1.TagUTF8String, 0:
+		if !utf8.ValidString(e.Text) {
+			return asn1.RawValue{}, fmt.Errorf("%q is not valid UTF-8", e.Text)
+		}
+		val.Tag = asn1.TagUTF8String
+
+	default:
+		return asn1.RawValue{}, fmt.Errorf("unsupported display text type: %d", e.Type)
+	}
+
+	return val, nil
+}
+
+// unmarshalRaw parses display text from a raw value and stores the result in
+// the object.
+func (e *DisplayText) unmarshalRaw(val asn1.RawValue) error {
+	if val.Class != asn1.ClassUniversal || val.IsCompound {
+		return errors.New("invalid display text")
+	}
+
+	var tmp = DisplayText{Type: val.Tag}
+
+	switch val.Tag {
+	case asn1.TagIA5String, TagVisibleString:
+		tmp.Text = string(val.Bytes)
+		if err := isIA5String(tmp.Text); err != nil {
+			return err
+		}
+
+	case asn1.TagUTF8String:
+		if !utf8.Valid(val.Bytes) {
+			return errors.New("display text is not valid UTF-8")
+		}
+		tmp.Text = string(val.Bytes)
+
+	case TagBMPString:
+		s, err := DecodeBMPString(val.Bytes)
+		if err != nil {
+			return err
+		}
+		tmp.Text = s
+
+	default:
+		return fmt.Errorf("unsupported display text type: %d", val.Tag)
+	}
+
+	*e = tmp
+
+	return nil
+}
+
+// encodeBMPString returns the contents octets of an ASN.1 BMPString, which is
+// big-endian UCS-2.
+func encodeBMPString(s string) ([]byte, error) {
+	var b []byte
+
+	for _, r := range s {
+		if r > 0xffff || utf16.IsSurrogate(r) {
+			return nil, fmt.Errorf("%q cannot be encoded as a BMPString", s)
+		}
+
+		b = append(b, byte(r>>8), byte(r))
+	}
+
+	return b, nil
+}
+
+// DecodeBMPString decodes the contents octets of an ASN.1 BMPString. It
+// returns an error if the length is odd or if the string contains a UTF-16
+// surrogate, since a BMPString can only contain characters in the Basic
+// Multilingual Plane.
+func DecodeBMPString(b []byte) (string, error) {
+	if len(b)%2 != 0 {
+		return "", errors.New("invalid BMPString length")
+	}
+
+	var runes = make([]rune, 0, len(b)/2)
+
+	for i := 0; i < len(b); i += 2 {
+		r := rune(b[i])<<8 | rune(b[i+1])
+		if utf16.IsSurrogate(r) {
+			return "", errors.New("invalid character in BMPString")
+		}
+
+		runes = append(runes, r)
+	}
+
+	return string(runes), nil
+}
+
+// displayTextTypeNames contains the names of the DisplayText string types.
+var displayTextTypeNames = map[int]string{
+	asn1.TagIA5String:  "ia5String",
+	TagVisibleString:   "visibleString",
+	TagBMPString:       "bmpString",
+	asn1.TagUTF8String: "utf8String",
+}
+
+// policyInformationJSON is the JSON representation of a PolicyInformation.
+type policyInformationJSON struct {
+	Policy     string                `json:"policy"`
+	Qualifiers []PolicyQualifierInfo `json:"qualifiers,omitempty"`
+}
+
+// policyQualifierInfoJSON is the JSON representation of a
+// PolicyQualifierInfo.
+type policyQualifierInfoJSON struct {
+	ID         string      `json:"id"`
+	CPSURI     string      `json:"cpsURI,omitempty"`
+	UserNotice *UserNotice `json:"userNotice,omitempty"`
+	Raw        string      `json:"raw,omitempty"`
+}
+
+// userNoticeJSON is the JSON representation of a UserNotice.
+type userNoticeJSON struct {
+	NoticeRef    *NoticeReference `json:"noticeRef,omitempty"`
+	ExplicitText *DisplayText     `json:"explicitText,omitempty"`
+}
+
+// noticeReferenceJSON is the JSON representation of a NoticeReference.
+type noticeReferenceJSON struct {
+	Organization  DisplayText `json:"organization"`
+	NoticeNumbers []int       `json:"noticeNumbers"`
+}
+
+// displayTextJSON is the JSON representation of a DisplayText.
+type displayTextJSON struct {
+	Type string `json:"type,omitempty"`
+	Text string `json:"text"`
+}
+
+// MarshalJSON returns the JSON encoding of a value. The policy information
+// is encoded as an object with a "policy" member containing the named or
+// dotted policy OID, and an optional "qualifiers" list. See
+// PolicyQualifierInfo.MarshalJSON.
+func (e PolicyInformation) MarshalJSON() ([]byte, error) {
+	return json.Marshal(policyInformationJSON{
+		Policy:     OIDName(e.Policy),
+		Qualifiers: e.Qualifiers,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded value and stores the result in the
+// object.
+func (e *PolicyInformation) UnmarshalJSON(b []byte) error {
+	var tmp policyInformationJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	oid, err := ParseOIDName(tmp.Policy)
+	if err != nil {
+		return err
+	}
+
+	*e = PolicyInformation{
+		Policy:     oid,
+		Qualifiers: tmp.Qualifiers,
+	}
+
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of a value. The qualifier is encoded
+// as an object with an "id" member containing the named or dotted qualifier
+// OID, and a "cpsURI" string, a "userNotice" object, or for any other
+// qualifier or a non-conforming user notice a "raw" hex string containing
+// the DER-encoded qualifier. See UserNotice.MarshalJSON.
+func (e PolicyQualifierInfo) MarshalJSON() ([]byte, error) {
+	var tmp = policyQualifierInfoJSON{ID: OIDName(e.ID)}
+
+	switch {
+	case e.ID.Equal(OIDPolicyQualifierCPS):
+		tmp.CPSURI = e.CPSURI
+
+	case e.ID.Equal(OIDPolicyQualifierUserNotice) && len(e.Raw.FullBytes) == 0:
+		tmp.UserNotice = &e.UserNotice
+
+	default:
+		raw, err := rawToHex(e.Raw)
+		if err != nil {
+			return nil, err
+		}
+		tmp.Raw = raw
+	}
+
+	return json.Marshal(tmp)
+}
+
+// UnmarshalJSON parses a JSON-encoded value and stores the result in the
+// object.
+func (e *PolicyQualifierInfo) UnmarshalJSON(b []byte) error {
+	var tmp policyQualifierInfoJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	oid, err := ParseOIDName(tmp.ID)
+	if err != nil {
+		return err
+	}
+
+	raw, err := rawFromHex(tmp.Raw)
+	if err != nil {
+		return err
+	}
+
+	var q = PolicyQualifierInfo{
+		ID:     oid,
+		CPSURI: tmp.CPSURI,
+		Raw:    raw,
+	}
+
+	if tmp.UserNotice != nil {
+		q.UserNotice = *tmp.UserNotice
+	}
+
+	*e = q
+
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of a value. The user notice is
+// encoded as an object with optional "noticeRef" and "explicitText"
+// members. A notice reference is an object with an "organization" member
+// and a "noticeNumbers" list of integers. See DisplayText.MarshalJSON.
+func (e UserNotice) MarshalJSON() ([]byte, error) {
+	var tmp userNoticeJSON
+
+	if !e.NoticeRef.Organization.IsZero() {
+		tmp.NoticeRef = &e.NoticeRef
+	}
+
+	if !e.ExplicitText.IsZero() {
+		tmp.ExplicitText = &e.ExplicitText
+	}
+
+	return json.Marshal(tmp)
+}
+
+// UnmarshalJSON parses a JSON-encoded value and stores the result in the
+// object.
+func (e *UserNotice) UnmarshalJSON(b []byte) error {
+	var tmp userNoticeJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	var notice UserNotice
+
+	if tmp.NoticeRef != nil {
+		notice.NoticeRef = *tmp.NoticeRef
+	}
+
+	if tmp.ExplicitText != nil {
+		notice.ExplicitText = *tmp.ExplicitText
+	}
+
+	*e = notice
+
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of a value.
+func (e NoticeReference) MarshalJSON() ([]byte, error) {
+	var tmp = noticeReferenceJSON{
+		Organization:  e.Organization,
+		NoticeNumbers: e.NoticeNumbers,
+	}
+
+	if tmp.NoticeNumbers == nil {
+		tmp.NoticeNumbers = []int{}
+	}
+
+	return json.Marshal(tmp)
+}
+
+// UnmarshalJSON parses a JSON-encoded value and stores the result in the
+// object.
+func (e *NoticeReference) UnmarshalJSON(b []byte) error {
+	var tmp noticeReferenceJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = NoticeReference{
+		Organization:  tmp.Organization,
+		NoticeNumbers: tmp.NoticeNumbers,
+	}
+
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of a value. The display text is
+// encoded as an object with a "text" member and a "type" member which is
+// one of "ia5String", "visibleString", "bmpString" and "utf8String", and
+// which is omitted if Type is zero.
+func (e DisplayText) MarshalJSON() ([]byte, error) {
+	var tmp = displayTextJSON{Text: e.Text}
+
+	if e.Type != 0 {
+		name, ok := displayTextTypeNames[e.Type]
+		if !ok {
+			return nil, fmt.Errorf("unsupported display text type: %d", e.Type)
+		}
+		tmp.Type = name
+	}
+
+	return json.Marshal(tmp)
+}
+
+// UnmarshalJSON parses a JSON-encoded value and stores the result in the
+// object.
+func (e *DisplayText) UnmarshalJSON(b []byte) error {
+	var tmp displayTextJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	var text = DisplayText{Text: tmp.Text}
+
+	if tmp.Type != "" {
+		for t, name := range displayTextTypeNames {
+			if name == tmp.Type {
+				text.Type = t
+			}
+		}
+
+		if text.Type == 0 {
+			return fmt.Errorf("unsupported display text type: %q", tmp.Type)
+		}
+	}
+
+	*e = text
+
+	return nil
+}
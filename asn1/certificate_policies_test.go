@@ -0,0 +1,434 @@
+package asn1_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+func TestCertificatePoliciesMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		obj  pgasn1.CertificatePolicies
+		want []byte
+		err  error
+	}{
+		{
+			name: "PolicyOnly",
+			obj: pgasn1.CertificatePolicies{
+				{Policy: asn1.ObjectIdentifier{1, 2, 3, 4}},
+			},
+			want: []byte{asn1.TagSequence | bit6, 7,
+				asn1.TagSequence | bit6, 5,
+				asn1.TagOID, 3, 0x2a, 0x03, 0x04,
+			},
+		},
+		{
+			name: "CPSAndUserNotice",
+			obj: pgasn1.CertificatePolicies{
+				{
+					Policy: asn1.ObjectIdentifier{1, 2, 3, 4},
+					Qualifiers: []pgasn1.PolicyQualifierInfo{
+						{
+							ID:     pgasn1.OIDPolicyQualifierCPS,
+							CPSURI: "http://c/",
+						},
+						{
+							ID: pgasn1.OIDPolicyQualifierUserNotice,
+							UserNotice: pgasn1.UserNotice{
+								ExplicitText: pgasn1.DisplayText{
+									Type: pgasn1.TagBMPString,
+									Text: "Hi",
+								},
+							},
+						},
+					},
+				},
+			},
+			want: []byte{asn1.TagSequence | bit6, 52,
+				asn1.TagSequence | bit6, 50,
+				asn1.TagOID, 3, 0x2a, 0x03, 0x04,
+				asn1.TagSequence | bit6, 43,
+				asn1.TagSequence | bit6, 21,
+				asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x02, 0x01,
+				asn1.TagIA5String, 9, 'h', 't', 't', 'p', ':', '/', '/', 'c', '/',
+				asn1.TagSequence | bit6, 18,
+				asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x02, 0x02,
+				asn1.TagSequence | bit6, 6,
+				pgasn1.TagBMPString, 4, 0, 'H', 0, 'i',
+			},
+		},
+		{
+			name: "Empty",
+			obj:  pgasn1.CertificatePolicies{},
+			err:  errors.New("no policies"),
+		},
+		{
+			name: "DuplicatePolicy",
+			obj: pgasn1.CertificatePolicies{
+				{Policy: asn1.ObjectIdentifier{1, 2, 3, 4}},
+				{Policy: asn1.ObjectIdentifier{1, 2, 3, 4}},
+			},
+			err: errors.New("duplicate policy"),
+		},
+		{
+			name: "NotIA5String",
+			obj: pgasn1.CertificatePolicies{
+				{
+					Policy: asn1.ObjectIdentifier{1, 2, 3, 4},
+					Qualifiers: []pgasn1.PolicyQualifierInfo{
+						{ID: pgasn1.OIDPolicyQualifierCPS, CPSURI: "\xff"},
+					},
+				},
+			},
+			err: errors.New("not IA5String"),
+		},
+		{
+			name: "NotVisibleString",
+			obj: pgasn1.CertificatePolicies{
+				{
+					Policy: asn1.ObjectIdentifier{1, 2, 3, 4},
+					Qualifiers: []pgasn1.PolicyQualifierInfo{
+						{
+							ID: pgasn1.OIDPolicyQualifierUserNotice,
+							UserNotice: pgasn1.UserNotice{
+								ExplicitText: pgasn1.DisplayText{
+									Type: pgasn1.TagVisibleString,
+									Text: "Hé",
+								},
+							},
+						},
+					},
+				},
+			},
+			err: errors.New("not VisibleString"),
+		},
+		{
+			name: "VisibleStringControlCharacter",
+			obj: pgasn1.CertificatePolicies{
+				{
+					Policy: asn1.ObjectIdentifier{1, 2, 3, 4},
+					Qualifiers: []pgasn1.PolicyQualifierInfo{
+						{
+							ID: pgasn1.OIDPolicyQualifierUserNotice,
+							UserNotice: pgasn1.UserNotice{
+								ExplicitText: pgasn1.DisplayText{Type: pgasn1.TagVisibleString, Text: "a\nb"},
+							},
+						},
+					},
+				},
+			},
+			err: errors.New("not VisibleString"),
+		},
+		{
+			name: "ExplicitTextTooLong",
+			obj: pgasn1.CertificatePolicies{
+				{
+					Policy: asn1.ObjectIdentifier{1, 2, 3, 4},
+					Qualifiers: []pgasn1.PolicyQualifierInfo{
+						{
+							ID: pgasn1.OIDPolicyQualifierUserNotice,
+							UserNotice: pgasn1.UserNotice{
+								ExplicitText: pgasn1.DisplayText{Text: strings.Repeat("x", 201)},
+							},
+						},
+					},
+				},
+			},
+			err: errors.New("too long"),
+		},
+		{
+			name: "EmptyExplicitText",
+			obj: pgasn1.CertificatePolicies{
+				{
+					Policy: asn1.ObjectIdentifier{1, 2, 3, 4},
+					Qualifiers: []pgasn1.PolicyQualifierInfo{
+						{
+							ID: pgasn1.OIDPolicyQualifierUserNotice,
+							UserNotice: pgasn1.UserNotice{
+								ExplicitText: pgasn1.DisplayText{Type: asn1.TagUTF8String},
+							},
+						},
+					},
+				},
+			},
+			err: errors.New("empty"),
+		},
+		{
+			name: "UnknownQualifierWithoutValue",
+			obj: pgasn1.CertificatePolicies{
+				{
+					Policy: asn1.ObjectIdentifier{1, 2, 3, 4},
+					Qualifiers: []pgasn1.PolicyQualifierInfo{
+						{ID: asn1.ObjectIdentifier{1, 2, 3}},
+					},
+				},
+			},
+			err: errors.New("no value"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.obj.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !bytes.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCertificatePoliciesUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		der  []byte
+		want pgasn1.CertificatePolicies
+		err  error
+	}{
+		{
+			name: "UserNotice",
+			der: []byte{asn1.TagSequence | bit6, 43,
+				asn1.TagSequence | bit6, 41,
+				asn1.TagOID, 3, 0x2a, 0x03, 0x04,
+				asn1.TagSequence | bit6, 34,
+				asn1.TagSequence | bit6, 32,
+				asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x02, 0x02,
+				asn1.TagSequence | bit6, 20,
+				asn1.TagSequence | bit6, 13,
+				asn1.TagUTF8String, 3, 'O', 'r', 'g',
+				asn1.TagSequence | bit6, 6,
+				asn1.TagInteger, 1, 1,
+				asn1.TagInteger, 1, 2,
+				asn1.TagIA5String, 3, 'F', 'o', 'o',
+			},
+			want: pgasn1.CertificatePolicies{
+				{
+					Policy: asn1.ObjectIdentifier{1, 2, 3, 4},
+					Qualifiers: []pgasn1.PolicyQualifierInfo{
+						{
+							ID: pgasn1.OIDPolicyQualifierUserNotice,
+							UserNotice: pgasn1.UserNotice{
+								NoticeRef: pgasn1.NoticeReference{
+									Organization: pgasn1.DisplayText{
+										Type: asn1.TagUTF8String,
+										Text: "Org",
+									},
+									NoticeNumbers: []int{1, 2},
+								},
+								ExplicitText: pgasn1.DisplayText{
+									Type: asn1.TagIA5String,
+									Text: "Foo",
+								},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "UnknownQualifier",
+			der: []byte{asn1.TagSequence | bit6, 17,
+				asn1.TagSequence | bit6, 15,
+				asn1.TagOID, 3, 0x2a, 0x03, 0x04,
+				asn1.TagSequence | bit6, 8,
+				asn1.TagSequence | bit6, 6,
+				asn1.TagOID, 2, 0x2a, 0x03,
+				asn1.TagNull, 0,
+			},
+			want: pgasn1.CertificatePolicies{
+				{
+					Policy: asn1.ObjectIdentifier{1, 2, 3, 4},
+					Qualifiers: []pgasn1.PolicyQualifierInfo{
+						{
+							ID: asn1.ObjectIdentifier{1, 2, 3},
+							Raw: asn1.RawValue{
+								Tag:       asn1.TagNull,
+								Bytes:     []byte{},
+								FullBytes: []byte{asn1.TagNull, 0},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "NonConformingUserNotice",
+			der: []byte{asn1.TagSequence | bit6, 25,
+				asn1.TagSequence | bit6, 23,
+				asn1.TagOID, 3, 0x2a, 0x03, 0x04,
+				asn1.TagSequence | bit6, 16,
+				asn1.TagSequence | bit6, 14,
+				asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x02, 0x02,
+				asn1.TagSequence | bit6, 2,
+				asn1.TagUTF8String, 0,
+			},
+			want: pgasn1.CertificatePolicies{
+				{
+					Policy: asn1.ObjectIdentifier{1, 2, 3, 4},
+					Qualifiers: []pgasn1.PolicyQualifierInfo{
+						{
+							ID: pgasn1.OIDPolicyQualifierUserNotice,
+							Raw: asn1.RawValue{
+								Tag:        asn1.TagSequence,
+								IsCompound: true,
+								Bytes:      []byte{asn1.TagUTF8String, 0},
+								FullBytes:  []byte{asn1.TagSequence | bit6, 2, asn1.TagUTF8String, 0},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "CPSNotIA5String",
+			der: []byte{asn1.TagSequence | bit6, 25,
+				asn1.TagSequence | bit6, 23,
+				asn1.TagOID, 3, 0x2a, 0x03, 0x04,
+				asn1.TagSequence | bit6, 16,
+				asn1.TagSequence | bit6, 14,
+				asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x02, 0x01,
+				asn1.TagUTF8String, 2, 'h', 'i',
+			},
+			err: errors.New("CPS URI not IA5String"),
+		},
+		{
+			name: "DuplicatePolicy",
+			der: []byte{asn1.TagSequence | bit6, 14,
+				asn1.TagSequence | bit6, 5,
+				asn1.TagOID, 3, 0x2a, 0x03, 0x04,
+				asn1.TagSequence | bit6, 5,
+				asn1.TagOID, 3, 0x2a, 0x03, 0x04,
+			},
+			err: errors.New("duplicate policy"),
+		},
+		{
+			name: "OddLengthBMPString",
+			der: []byte{asn1.TagSequence | bit6, 28,
+				asn1.TagSequence | bit6, 26,
+				asn1.TagOID, 3, 0x2a, 0x03, 0x04,
+				asn1.TagSequence | bit6, 19,
+				asn1.TagSequence | bit6, 17,
+				asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x02, 0x02,
+				asn1.TagSequence | bit6, 5,
+				pgasn1.TagBMPString, 3, 0, 'H', 0,
+			},
+			err: errors.New("odd length BMPString"),
+		},
+		{
+			name: "TrailingBytes",
+			der: []byte{asn1.TagSequence | bit6, 7,
+				asn1.TagSequence | bit6, 5,
+				asn1.TagOID, 3, 0x2a, 0x03, 0x04,
+				0xff,
+			},
+			err: errors.New("trailing bytes"),
+		},
+		{
+			name: "BadASN1",
+			der:  []byte{0xff},
+			err:  errors.New("bad ASN.1"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got pgasn1.CertificatePolicies
+
+			err := got.Unmarshal(tc.der)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCertificatePoliciesRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		der  string
+	}{
+		{
+			name: "OpenSSL",
+			der: "304e300506032a0304304506062b0601040163303b301706082b06010505" +
+				"070201160b687474703a2f2f6370732f302006082b06010505070202301430" +
+				"0d1a034f726730060201010201020c0348c3a9",
+		},
+		{
+			name: "UnknownQualifier",
+			der:  "301e301c06032a03043015300706022a030c0158300a06032a03053003020101",
+		},
+		{
+			name: "LongExplicitText",
+			der: "3081e73081e406032a03043081dc3081d906082b06010505070202" +
+				"3081cc0c81c9" + strings.Repeat("78", 201),
+		},
+		{
+			name: "EmptyExplicitText",
+			der:  "3019301706032a03043010300e06082b0601050507020230020c00",
+		},
+		{
+			name: "EmptyOrganization",
+			der:  "3020301e06032a03043017301506082b060105050702023009300716003003020101",
+		},
+		{
+			name: "VisibleStringControlCharacter",
+			der:  "301c301a06032a03043013301106082b0601050507020230051a03610a62",
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			der, err := hex.DecodeString(tc.der)
+			if err != nil {
+				t.Fatalf("couldn't decode hex: %v", err)
+			}
+
+			var policies pgasn1.CertificatePolicies
+			if err := policies.Unmarshal(der); err != nil {
+				t.Fatalf("couldn't unmarshal certificate policies: %v", err)
+			}
+
+			got, err := policies.Marshal()
+			if err != nil {
+				t.Fatalf("couldn't marshal certificate policies: %v", err)
+			}
+
+			if !bytes.Equal(got, der) {
+				t.Errorf("got %x, want %x", got, der)
+			}
+		})
+	}
+}
@@ -0,0 +1,550 @@
+package asn1
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+	"fmt"
+)
+
+// CRLDistributionPoints represents an X509 CRL distribution points extension
+// as defined in RFC 5280 section 4.2.1.13.
+//
+// id-ce-cRLDistributionPoints OBJECT IDENTIFIER ::=  { id-ce 31 }
+//
+// CRLDistributionPoints ::= SEQUENCE SIZE (1..MAX) OF DistributionPoint
+type CRLDistributionPoints []DistributionPoint
+
+// DistributionPoint represents a distribution point as defined in RFC 5280
+// section 4.2.1.13.
+//
+// DistributionPoint ::= SEQUENCE {
+//      distributionPoint       [0]     DistributionPointName OPTIONAL,
+//      reasons                 [1]     ReasonFlags OPTIONAL,
+//      cRLIssuer               [2]     GeneralNames OPTIONAL }
+type DistributionPoint struct {
+	Name      DistributionPointName
+	Reasons   ReasonFlags
+	CRLIssuer GeneralNames
+}
+
+// DistributionPointName represents a distribution point name as defined in
+// RFC 5280 section 4.2.1.13. At most one of the fields should be set.
+//
+// DistributionPointName ::= CHOICE {
+//      fullName                [0]     GeneralNames,
+//      nameRelativeToCRLIssuer [1]     RelativeDistinguishedName }
+type DistributionPointName struct {
+	FullName     GeneralNames
+	RelativeName pkix.RelativeDistinguishedNameSET
+}
+
+// ReasonFlags represents a set of revocation reasons as defined in RFC 5280
+// section 4.2.1.13. Each flag corresponds to the bit of the same number in
+// the ASN.1 BIT STRING.
+//
+// ReasonFlags ::= BIT STRING {
+//      unused                  (0),
+//      keyCompromise           (1),
+//      cACompromise            (2),
+//      affiliationChanged      (3),
+//      superseded              (4),
+//      cessationOfOperation    (5),
+//      certificateHold         (6),
+//      privilegeWithdrawn      (7),
+//      aACompromise            (8) }
+type ReasonFlags int
+
+// Revocation reason flags.
+const (
+	ReasonFlagUnused ReasonFlags = 1 << iota
+	ReasonFlagKeyCompromise
+	ReasonFlagCACompromise
+	ReasonFlagAffiliationChanged
+	ReasonFlagSuperseded
+	ReasonFlagCessationOfOperation
+	ReasonFlagCertificateHold
+	ReasonFlagPrivilegeWithdrawn
+	ReasonFlagAACompromise
+)
+
+// maxReasonFlagBit is the number of the highest defined bit in ReasonFlags.
+const maxReasonFlagBit = 8
+
+// Tag numbers for DistributionPoint and DistributionPointName structures.
+const (
+	dpTagDistributionPoint = 0
+	dpTagReasons           = 1
+	dpTagCRLIssuer         = 2
+
+	dpNameTagFullName     = 0
+	dpNameTagRelativeName = 1
+)
+
+// Marshal returns the ASN.1 DER-encoding of a value.
+func (e CRLDistributionPoints) Marshal() ([]byte, error) {
+	if len(e) == 0 {
+		return nil, errors.New("no distribution points specified")
+	}
+
+	var vals []asn1.RawValue
+
+	for _, dp := range e {
+		der, err := dp.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		vals = append(vals, asn1.RawValue{FullBytes: der})
+	}
+
+	return asn1.Marshal(vals)
+}
+
+// Unmarshal parses an DER-encoded ASN.1 data structure and stores the result
+// in the object.
+func (e *CRLDistributionPoints) Unmarshal(b []byte) error {
+	var vals []asn1.RawValue
+
+	rest, err := asn1.Unmarshal(b, &vals)
+	if err != nil {
+		return err
+	} else if len(rest) != 0 {
+		return errors.New("trailing bytes")
+	}
+
+	var tmp CRLDistributionPoints
+
+	for _, val := range vals {
+		var dp DistributionPoint
+		if err := dp.Unmarshal(val.FullBytes); err != nil {
+			return err
+		}
+
+		tmp = append(tmp, dp)
+	}
+
+	*e = tmp
+
+	return nil
+}
+
+// Marshal returns the ASN.1 DER-encoding of a value.
+func (e DistributionPoint) Marshal() ([]byte, error) {
+
+	// Either distributionPoint or cRLIssuer MUST be present. See RFC 5280
+	// section 4.2.1.13.
+	if e.Name.IsEmpty() && e.CRLIssuer.IsEmpty() {
+		return nil, errors.New("distribution point has neither name nor CRL issuer")
+	}
+
+	var vals []asn1.RawValue
+
+	if !e.Name.IsEmpty() {
+		val, err := e.Name.raw()
+		if err != nil {
+			return nil, err
+		}
+
+		der, err := asn1.Marshal(val)
+		if err != nil {
+			return nil, err
+		}
+
+		vals = append(vals, explicitTag(der, dpTagDistributionPoint))
+	}
+
+	if e.Reasons != 0 {
+		val, err := e.Reasons.raw(dpTagReasons)
+		if err != nil {
+			return nil, err
+		}
+
+		vals = append(vals, val)
+	}
+
+	if !e.CRLIssuer.IsEmpty() {
+		der, err := e.CRLIssuer.Marshal()
+		if err != nil {
+			return nil, err
+		}
+
+		val, err := implicitTag(der, dpTagCRLIssuer)
+		if err != nil {
+			return nil, err
+		}
+
+		vals = append(vals, val)
+	}
+
+	return asn1.Marshal(vals)
+}
+
+// Unmarshal parses an DER-encoded ASN.1 data structure and stores the result
+// in the object.
+func (e *DistributionPoint) Unmarshal(b []byte) error {
+	var vals []asn1.RawValue
+
+	rest, err := asn1.Unmarshal(b, &vals)
+	if err != nil {
+		return err
+	} else if len(rest) != 0 {
+		return errors.New("trailing bytes")
+	}
+
+	var tmp DistributionPoint
+	var last = -1
+
+	for _, val := range vals {
+		if val.Class != asn1.ClassContextSpecific || val.Tag <= last {
+			return errors.New("unexpected element in distribution point")
+		}
+		last = val.Tag
+
+		switch val.Tag {
+		case dpTagDistributionPoint:
+			if err := tmp.Name.unmarshalExplicit(val); err != nil {
+				return err
+			}
+
+		case dpTagReasons:
+			if tmp.Reasons, err = reasonFlagsFromRaw(val); err != nil {
+				return err
+			}
+
+		case dpTagCRLIssuer:
+			der, err := universalTag(val, asn1.TagSequence)
+			if err != nil {
+				return err
+			}
+
+			if err := tmp.CRLIssuer.Unmarshal(der); err != nil {
+				return err
+			}
+
+		default:
+			return fmt.Errorf("unexpected tag in distribution point: %d", val.Tag)
+		}
+	}
+
+	if tmp.Name.IsEmpty() && tmp.CRLIssuer.IsEmpty() {
+		return errors.New("distribution point has neither name nor CRL issuer")
+	}
+
+	*e = tmp
+
+	return nil
+}
+
+// IsEmpty returns true if neither name form is set.
+func (e DistributionPointName) IsEmpty() bool {
+	return e.FullName.IsEmpty() && len(e.RelativeName) == 0
+}
+
+// raw returns the distribution point name as a raw value, with the tag of
+// the selected CHOICE alternative.
+func (e DistributionPointName) raw() (asn1.RawValue, error) {
+	switch {
+	case !e.FullName.IsEmpty() && len(e.RelativeName) != 0:
+		return asn1.RawValue{}, errors.New("distribution point name has both full and relative names")
+
+	case !e.FullName.IsEmpty():
+		der, err := e.FullName.Marshal()
+		if err != nil {
+			return asn1.RawValue{}, err
+		}
+
+		return implicitTag(der, dpNameTagFullName)
+
+	case len(e.RelativeName) != 0:
+		der, err := asn1.Marshal(e.RelativeName)
+		if err != nil {
+			return asn1.RawValue{}, err
+		}
+
+		return implicitTag(der, dpNameTagRelativeName)
+	}
+
+	return asn1.RawValue{}, errors.New("empty distribution point name")
+}
+
+// unmarshalExplicit parses a distribution point name from an EXPLICIT tagged
+// raw value and stores the result in the object.
+func (e *DistributionPointName) unmarshalExplicit(val asn1.RawValue) error {
+	var inner asn1.RawValue
+
+	rest, err := asn1.Unmarshal(val.Bytes, &inner)
+	if err != nil {
+		return err
+	} else if len(rest) != 0 {
+		return errors.New("trailing bytes")
+	}
+
+	return e.unmarshalRaw(inner)
+}
+
+// unmarshalRaw parses a distribution point name from a raw value with the tag
+// of the selected CHOICE alternative and stores the result in the object.
+func (e *DistributionPointName) unmarshalRaw(val asn1.RawValue) error {
+	if val.Class != asn1.ClassContextSpecific {
+		return errors.New("invalid distribution point name class")
+	}
+
+	var tmp DistributionPointName
+
+	switch val.Tag {
+	case dpNameTagFullName:
+		der, err := universalTag(val, asn1.TagSequence)
+		if err != nil {
+			return err
+		}
+
+		if err := tmp.FullName.Unmarshal(der); err != nil {
+			return err
+		}
+
+	case dpNameTagRelativeName:
+		der, err := universalTag(val, asn1.TagSet)
+		if err != nil {
+			return err
+		}
+
+		if rest, err := asn1.Unmarshal(der, &tmp.RelativeName); err != nil {
+			return err
+		} else if len(rest) != 0 {
+			return errors.New("trailing bytes")
+		}
+
+	default:
+		return fmt.Errorf("unexpected distribution point name tag: %d", val.Tag)
+	}
+
+	*e = tmp
+
+	return nil
+}
+
+// raw returns the reason flags as an IMPLICIT tagged raw value, using the
+// minimal number of bits as required by DER for named bit lists.
+func (e ReasonFlags) raw(tag int) (asn1.RawValue, error) {
+	if e < 0 || e >= 1<<(maxReasonFlagBit+1) {
+		return asn1.RawValue{}, fmt.Errorf("invalid reason flags: %#x", int(e))
+	}
+
+	var bs asn1.BitString
+
+	for i := 0; i <= maxReasonFlagBit; i++ {
+		if e&(1<<i) != 0 {
+			bs.BitLength = i + 1
+		}
+	}
+
+	bs.Bytes = make([]byte, (bs.BitLength+7)/8)
+	for i := 0; i < bs.BitLength; i++ {
+		if e&(1<<i) != 0 {
+			bs.Bytes[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+
+	der, err := asn1.Marshal(bs)
+	if err != nil {
+		return asn1.RawValue{}, err
+	}
+
+	return implicitTag(der, tag)
+}
+
+// reasonFlagsFromRaw parses reason flags from an IMPLICIT tagged raw value.
+func reasonFlagsFromRaw(val asn1.RawValue) (ReasonFlags, error) {
+	der, err := universalTag(val, asn1.TagBitString)
+	if err != nil {
+		return 0, err
+	}
+
+	var bs asn1.BitString
+	if rest, err := asn1.Unmarshal(der, &bs); err != nil {
+		return 0, err
+	} else if len(rest) != 0 {
+		return 0, errors.New("trailing bytes")
+	}
+
+	var flags ReasonFlags
+
+	for i := 0; i < bs.BitLength; i++ {
+		if bs.At(i) == 0 {
+			continue
+		}
+
+		if i > maxReasonFlagBit {
+			return 0, fmt.Errorf("unknown reason flag bit: %d", i)
+		}
+
+		flags |= 1 << i
+	}
+
+	return flags, nil
+}
+
+// reasonFlagNames contains the names of the reason flags, indexed by bit
+// number.
+var reasonFlagNames = [maxReasonFlagBit + 1]string{
+	"unused",
+	"keyCompromise",
+	"cACompromise",
+	"affiliationChanged",
+	"superseded",
+	"cessationOfOperation",
+	"certificateHold",
+	"privilegeWithdrawn",
+	"aACompromise",
+}
+
+// distributionPointNameJSON is the JSON representation of a
+// DistributionPointName.
+type distributionPointNameJSON struct {
+	FullName     *GeneralNames   `json:"fullName,omitempty"`
+	RelativeName []attributeJSON `json:"relativeName,omitempty"`
+}
+
+// distributionPointJSON is the JSON representation of a DistributionPoint.
+type distributionPointJSON struct {
+	Name      *DistributionPointName `json:"name,omitempty"`
+	Reasons   ReasonFlags            `json:"reasons,omitempty"`
+	CRLIssuer *GeneralNames          `json:"crlIssuer,omitempty"`
+}
+
+// MarshalJSON returns the JSON encoding of a value. The distribution point
+// is encoded as an object with optional "name", "reasons" and "crlIssuer"
+// members. See DistributionPointName.MarshalJSON, ReasonFlags.MarshalJSON
+// and GeneralNames.MarshalJSON.
+func (e DistributionPoint) MarshalJSON() ([]byte, error) {
+	var tmp = distributionPointJSON{Reasons: e.Reasons}
+
+	if !e.Name.IsEmpty() {
+		tmp.Name = &e.Name
+	}
+
+	if !e.CRLIssuer.IsEmpty() {
+		tmp.CRLIssuer = &e.CRLIssuer
+	}
+
+	return json.Marshal(tmp)
+}
+
+// UnmarshalJSON parses a JSON-encoded value and stores the result in the
+// object.
+func (e *DistributionPoint) UnmarshalJSON(b []byte) error {
+	var tmp distributionPointJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	var dp = DistributionPoint{Reasons: tmp.Reasons}
+
+	if tmp.Name != nil {
+		dp.Name = *tmp.Name
+	}
+
+	if tmp.CRLIssuer != nil {
+		dp.CRLIssuer = *tmp.CRLIssuer
+	}
+
+	*e = dp
+
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of a value. The name is encoded as
+// an object with at most one of a "fullName" member, which is encoded as
+// GeneralNames, and a "relativeName" member, which is a list of {"type",
+// "value"} objects. See GeneralNames.MarshalJSON.
+func (e DistributionPointName) MarshalJSON() ([]byte, error) {
+	var tmp distributionPointNameJSON
+
+	if !e.FullName.IsEmpty() {
+		tmp.FullName = &e.FullName
+	}
+
+	rdn, err := rdnToJSON(e.RelativeName)
+	if err != nil {
+		return nil, err
+	}
+	tmp.RelativeName = rdn
+
+	return json.Marshal(tmp)
+}
+
+// UnmarshalJSON parses a JSON-encoded value and stores the result in the
+// object.
+func (e *DistributionPointName) UnmarshalJSON(b []byte) error {
+	var tmp distributionPointNameJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	var name DistributionPointName
+
+	if tmp.FullName != nil {
+		name.FullName = *tmp.FullName
+	}
+
+	rdn, err := rdnFromJSON(tmp.RelativeName)
+	if err != nil {
+		return err
+	}
+	name.RelativeName = rdn
+
+	*e = name
+
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of a value. The flags are encoded as
+// a list of the ASN.1 names of the set bits, such as "keyCompromise".
+func (e ReasonFlags) MarshalJSON() ([]byte, error) {
+	if e < 0 || e >= 1<<(maxReasonFlagBit+1) {
+		return nil, fmt.Errorf("invalid reason flags: %#x", int(e))
+	}
+
+	var names = []string{}
+
+	for i, name := range reasonFlagNames {
+		if e&(1<<i) != 0 {
+			names = append(names, name)
+		}
+	}
+
+	return json.Marshal(names)
+}
+
+// UnmarshalJSON parses a JSON-encoded value and stores the result in the
+// object.
+func (e *ReasonFlags) UnmarshalJSON(b []byte) error {
+	var names []string
+	if err := json.Unmarshal(b, &names); err != nil {
+		return err
+	}
+
+	var flags ReasonFlags
+
+	for _, name := range names {
+		var found bool
+
+		for i, n := range reasonFlagNames {
+			if n == name {
+				flags |= 1 << i
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("unknown reason flag: %q", name)
+		}
+	}
+
+	*e = flags
+
+	return nil
+}
@@ -0,0 +1,244 @@
+package asn1_test
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+func TestCRLDistributionPointsMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		obj  pgasn1.CRLDistributionPoints
+		want []byte
+		err  error
+	}{
+		{
+			name: "FullNameAndReasons",
+			obj: pgasn1.CRLDistributionPoints{
+				{
+					Name: pgasn1.DistributionPointName{
+						FullName: pgasn1.GeneralNames{
+							{URI: mustParseURI(t, "http://c/x")},
+						},
+					},
+					Reasons: pgasn1.ReasonFlagKeyCompromise | pgasn1.ReasonFlagCACompromise,
+				},
+			},
+			want: []byte{asn1.TagSequence | bit6, 22,
+				asn1.TagSequence | bit6, 20,
+				asn1.ClassContextSpecific<<6 | bit6 | 0, 14,
+				asn1.ClassContextSpecific<<6 | bit6 | 0, 12,
+				nameTagURI | asn1.ClassContextSpecific<<6, 10,
+				'h', 't', 't', 'p', ':', '/', '/', 'c', '/', 'x',
+				asn1.ClassContextSpecific<<6 | 1, 2, 5, 0x60,
+			},
+		},
+		{
+			name: "RelativeNameAndCRLIssuer",
+			obj: pgasn1.CRLDistributionPoints{
+				{
+					Name: pgasn1.DistributionPointName{
+						RelativeName: pkix.RelativeDistinguishedNameSET{
+							{Type: asn1.ObjectIdentifier{2, 5, 4, 3}, Value: "C"},
+						},
+					},
+					CRLIssuer: pgasn1.GeneralNames{{DNSName: "a.b"}},
+				},
+			},
+			want: []byte{asn1.TagSequence | bit6, 23,
+				asn1.TagSequence | bit6, 21,
+				asn1.ClassContextSpecific<<6 | bit6 | 0, 12,
+				asn1.ClassContextSpecific<<6 | bit6 | 1, 10,
+				asn1.TagSequence | bit6, 8,
+				asn1.TagOID, 3, 0x55, 0x04, 0x03,
+				asn1.TagPrintableString, 1, 'C',
+				asn1.ClassContextSpecific<<6 | bit6 | 2, 5,
+				nameTagDNSName | asn1.ClassContextSpecific<<6, 3, 'a', '.', 'b',
+			},
+		},
+		{
+			name: "Empty",
+			obj:  pgasn1.CRLDistributionPoints{},
+			err:  errors.New("no distribution points"),
+		},
+		{
+			name: "NoNameOrCRLIssuer",
+			obj: pgasn1.CRLDistributionPoints{
+				{Reasons: pgasn1.ReasonFlagKeyCompromise},
+			},
+			err: errors.New("no name or CRL issuer"),
+		},
+		{
+			name: "FullAndRelativeNames",
+			obj: pgasn1.CRLDistributionPoints{
+				{
+					Name: pgasn1.DistributionPointName{
+						FullName: pgasn1.GeneralNames{{DNSName: "a.b"}},
+						RelativeName: pkix.RelativeDistinguishedNameSET{
+							{Type: asn1.ObjectIdentifier{2, 5, 4, 3}, Value: "C"},
+						},
+					},
+				},
+			},
+			err: errors.New("full and relative names"),
+		},
+		{
+			name: "BadReasonFlags",
+			obj: pgasn1.CRLDistributionPoints{
+				{
+					Name: pgasn1.DistributionPointName{
+						FullName: pgasn1.GeneralNames{{DNSName: "a.b"}},
+					},
+					Reasons: 1 << 9,
+				},
+			},
+			err: errors.New("bad reason flags"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.obj.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !bytes.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCRLDistributionPointsUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		der  []byte
+		want pgasn1.CRLDistributionPoints
+		err  error
+	}{
+		{
+			name: "FullNameAndReasons",
+			der: []byte{asn1.TagSequence | bit6, 22,
+				asn1.TagSequence | bit6, 20,
+				asn1.ClassContextSpecific<<6 | bit6 | 0, 14,
+				asn1.ClassContextSpecific<<6 | bit6 | 0, 12,
+				nameTagURI | asn1.ClassContextSpecific<<6, 10,
+				'h', 't', 't', 'p', ':', '/', '/', 'c', '/', 'x',
+				asn1.ClassContextSpecific<<6 | 1, 2, 5, 0x60,
+			},
+			want: pgasn1.CRLDistributionPoints{
+				{
+					Name: pgasn1.DistributionPointName{
+						FullName: pgasn1.GeneralNames{
+							{URI: mustParseURI(t, "http://c/x")},
+						},
+					},
+					Reasons: pgasn1.ReasonFlagKeyCompromise | pgasn1.ReasonFlagCACompromise,
+				},
+			},
+		},
+		{
+			name: "RelativeNameAndCRLIssuer",
+			der: []byte{asn1.TagSequence | bit6, 23,
+				asn1.TagSequence | bit6, 21,
+				asn1.ClassContextSpecific<<6 | bit6 | 0, 12,
+				asn1.ClassContextSpecific<<6 | bit6 | 1, 10,
+				asn1.TagSequence | bit6, 8,
+				asn1.TagOID, 3, 0x55, 0x04, 0x03,
+				asn1.TagPrintableString, 1, 'C',
+				asn1.ClassContextSpecific<<6 | bit6 | 2, 5,
+				nameTagDNSName | asn1.ClassContextSpecific<<6, 3, 'a', '.', 'b',
+			},
+			want: pgasn1.CRLDistributionPoints{
+				{
+					Name: pgasn1.DistributionPointName{
+						RelativeName: pkix.RelativeDistinguishedNameSET{
+							{Type: asn1.ObjectIdentifier{2, 5, 4, 3}, Value: "C"},
+						},
+					},
+					CRLIssuer: pgasn1.GeneralNames{{DNSName: "a.b"}},
+				},
+			},
+		},
+		{
+			name: "NoNameOrCRLIssuer",
+			der: []byte{asn1.TagSequence | bit6, 6,
+				asn1.TagSequence | bit6, 4,
+				asn1.ClassContextSpecific<<6 | 1, 2, 6, 0x40,
+			},
+			err: errors.New("no name or CRL issuer"),
+		},
+		{
+			name: "UnknownReasonFlag",
+			der: []byte{asn1.TagSequence | bit6, 14,
+				asn1.TagSequence | bit6, 12,
+				asn1.ClassContextSpecific<<6 | bit6 | 0, 5,
+				asn1.ClassContextSpecific<<6 | bit6 | 0, 3,
+				nameTagDNSName | asn1.ClassContextSpecific<<6, 1, 'a',
+				asn1.ClassContextSpecific<<6 | 1, 3, 6, 0x00, 0x40,
+			},
+			err: errors.New("unknown reason flag"),
+		},
+		{
+			name: "OutOfOrder",
+			der: []byte{asn1.TagSequence | bit6, 13,
+				asn1.TagSequence | bit6, 11,
+				asn1.ClassContextSpecific<<6 | 1, 2, 6, 0x40,
+				asn1.ClassContextSpecific<<6 | bit6 | 0, 5,
+				asn1.ClassContextSpecific<<6 | bit6 | 0, 3,
+				nameTagDNSName | asn1.ClassContextSpecific<<6, 1, 'a',
+			},
+			err: errors.New("out of order"),
+		},
+		{
+			name: "TrailingBytes",
+			der: []byte{asn1.TagSequence | bit6, 9,
+				asn1.TagSequence | bit6, 7,
+				asn1.ClassContextSpecific<<6 | bit6 | 2, 5,
+				nameTagDNSName | asn1.ClassContextSpecific<<6, 3, 'a', '.', 'b',
+				0xff,
+			},
+			err: errors.New("trailing bytes"),
+		},
+		{
+			name: "BadASN1",
+			der:  []byte{0xff},
+			err:  errors.New("bad ASN.1"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got pgasn1.CRLDistributionPoints
+
+			err := got.Unmarshal(tc.der)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -1,7 +1,9 @@
 package asn1
 
 import (
+	"crypto/x509/pkix"
 	"encoding/asn1"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net"
@@ -31,12 +33,23 @@ import (
 // EDIPartyName ::= SEQUENCE {
 //      nameAssigner            [0]     DirectoryString OPTIONAL,
 //      partyName               [1]     DirectoryString }
-type GeneralNames struct {
-	DNSNames       []string
-	DirectoryNames []string
-	EmailAddresses []string
-	IPAddresses    []net.IP
-	URIs           []*url.URL
+//
+// The names are kept in the order in which they appear in the sequence, so
+// that they are re-encoded in the same order.
+type GeneralNames []GeneralName
+
+// GeneralName represents a single General Name as defined in RFC 5280
+// section 4.2.1.6. Exactly one of the fields should be set. Raw contains a
+// name of the otherName, x400Address, ediPartyName or registeredID type,
+// with the context-specific tag of the selected CHOICE alternative, so that
+// it is retained when names are parsed and re-encoded. See GeneralNames.
+type GeneralName struct {
+	DNSName       string
+	DirectoryName pkix.RDNSequence
+	EmailAddress  string
+	IPAddress     net.IP
+	URI           *url.URL
+	Raw           asn1.RawValue
 }
 
 // Tag numbers for GeneralName structure.
@@ -56,119 +69,412 @@ const (
 func (e GeneralNames) Marshal() ([]byte, error) {
 	var vals []asn1.RawValue
 
-	for _, name := range e.DNSNames {
-		if err := isIA5String(name); err != nil {
+	for _, name := range e {
+		val, err := name.raw()
+		if err != nil {
 			return nil, err
 		}
 
-		if _, ok := domainToReverseLabels(name); !ok {
-			return nil, fmt.Errorf("couldn't parse %q as domain name", name)
-		}
-
-		vals = append(
-			vals,
-			asn1.RawValue{
-				Tag:   nameTagDNSName,
-				Class: asn1.ClassContextSpecific,
-				Bytes: []byte(name),
-			},
-		)
+		vals = append(vals, val)
 	}
 
-	for _, addr := range e.EmailAddresses {
-		if err := isIA5String(addr); err != nil {
-			return nil, err
-		}
+	return asn1.Marshal(vals)
+}
 
-		if _, ok := parseRFC2821Mailbox(addr); !ok {
-			return nil, fmt.Errorf("couldn't parse %q as email address", addr)
-		}
+// Unmarshal parses an DER-encoded ASN.1 data structure and stores the result
+// in the object.
+func (e *GeneralNames) Unmarshal(b []byte) error {
+	var tmp GeneralNames
+	var vals []asn1.RawValue
 
-		vals = append(
-			vals,
-			asn1.RawValue{
-				Tag:   nameTagRFC822Name,
-				Class: asn1.ClassContextSpecific,
-				Bytes: []byte(addr),
-			},
-		)
+	rest, err := asn1.Unmarshal(b, &vals)
+	if err != nil {
+		return err
+	} else if len(rest) != 0 {
+		return errors.New("trailing bytes")
 	}
 
-	for _, ip := range e.IPAddresses {
-		ipBytes := ip.To4()
-		if ipBytes == nil {
-			ipBytes = ip
+	for _, val := range vals {
+		name, err := generalNameFromRaw(val)
+		if err != nil {
+			return err
 		}
 
-		vals = append(
-			vals,
-			asn1.RawValue{
-				Tag:   nameTagIPAddress,
-				Class: asn1.ClassContextSpecific,
-				Bytes: ipBytes,
-			},
-		)
+		tmp = append(tmp, name)
 	}
 
-	for _, uri := range e.URIs {
-		vals = append(
-			vals,
-			asn1.RawValue{
-				Tag:   nameTagURI,
-				Class: asn1.ClassContextSpecific,
-				Bytes: []byte(uri.String()),
-			},
-		)
+	*e = tmp
+
+	return nil
+}
+
+// IsEmpty returns true if the object contains no names.
+func (e GeneralNames) IsEmpty() bool {
+	return len(e) == 0
+}
+
+// Marshal returns the ASN.1 DER-encoding of a value.
+func (e GeneralName) Marshal() ([]byte, error) {
+	val, err := e.raw()
+	if err != nil {
+		return nil, err
 	}
 
-	return asn1.Marshal(vals)
+	return asn1.Marshal(val)
 }
 
 // Unmarshal parses an DER-encoded ASN.1 data structure and stores the result
 // in the object.
-func (e *GeneralNames) Unmarshal(b []byte) error {
-	var tmp GeneralNames
-	var vals []asn1.RawValue
+func (e *GeneralName) Unmarshal(b []byte) error {
+	var val asn1.RawValue
 
-	rest, err := asn1.Unmarshal(b, &vals)
+	rest, err := asn1.Unmarshal(b, &val)
 	if err != nil {
 		return err
 	} else if len(rest) != 0 {
 		return errors.New("trailing bytes")
 	}
 
-	for _, val := range vals {
-		switch val.Tag {
-		case nameTagDNSName:
-			tmp.DNSNames = append(tmp.DNSNames, string(val.Bytes))
-
-		case nameTagRFC822Name:
-			tmp.EmailAddresses = append(tmp.EmailAddresses, string(val.Bytes))
-
-		case nameTagIPAddress:
-			switch len(val.Bytes) {
-			case net.IPv4len, net.IPv6len:
-				tmp.IPAddresses = append(tmp.IPAddresses, val.Bytes)
-
-			default:
-				return errors.New("cannot parse IP address")
-			}
-
-		case nameTagURI:
-			uri, err := url.Parse(string(val.Bytes))
-			if err != nil {
-				return fmt.Errorf("cannot parse %q as URI", string(val.Bytes))
-			}
-			if len(uri.Host) > 0 {
-				if _, ok := domainToReverseLabels(uri.Host); !ok {
-					return fmt.Errorf("cannot parse %q as URI", string(val.Bytes))
-				}
-			}
-			tmp.URIs = append(tmp.URIs, uri)
+	tmp, err := generalNameFromRaw(val)
+	if err != nil {
+		return err
+	}
+
+	*e = tmp
+
+	return nil
+}
+
+// raw returns the general name as a raw value, with the tag of the selected
+// CHOICE alternative.
+func (e GeneralName) raw() (asn1.RawValue, error) {
+	var count int
+	var val asn1.RawValue
+	var err error
+
+	if e.DNSName != "" {
+		count++
+		val, err = marshalDNSName(e.DNSName)
+	}
+
+	if e.DirectoryName != nil {
+		count++
+		val, err = marshalDirectoryName(e.DirectoryName)
+	}
+
+	if e.EmailAddress != "" {
+		count++
+		val, err = marshalEmailAddress(e.EmailAddress)
+	}
+
+	if e.IPAddress != nil {
+		count++
+		val = marshalIPAddress(e.IPAddress)
+	}
+
+	if e.URI != nil {
+		count++
+		val = marshalURI(e.URI)
+	}
+
+	if isRawPresent(e.Raw) {
+		count++
+		val, err = marshalRawName(e.Raw)
+	}
+
+	if count != 1 {
+		return asn1.RawValue{}, fmt.Errorf("general name must contain exactly one name, found %d", count)
+	} else if err != nil {
+		return asn1.RawValue{}, err
+	}
+
+	return val, nil
+}
+
+// generalNameFromRaw parses a general name from a raw value with the tag of
+// the selected CHOICE alternative.
+func generalNameFromRaw(val asn1.RawValue) (GeneralName, error) {
+	if val.Class != asn1.ClassContextSpecific {
+		return GeneralName{}, errors.New("invalid general name")
+	}
+
+	var name GeneralName
+	var err error
+
+	switch val.Tag {
+	case nameTagDNSName:
+		name.DNSName = string(val.Bytes)
+
+	case nameTagRFC822Name:
+		name.EmailAddress = string(val.Bytes)
+
+	case nameTagIPAddress:
+		name.IPAddress, err = unmarshalIPAddress(val)
+
+	case nameTagURI:
+		name.URI, err = unmarshalURI(val)
+
+	case nameTagDirectoryName:
+		name.DirectoryName, err = unmarshalDirectoryName(val)
+
+	case nameTagOtherName, nameTagX400Address, nameTagEDIPartyName, nameTagRegisteredID:
+		name.Raw = val
+
+	default:
+		return GeneralName{}, fmt.Errorf("invalid general name tag: %d", val.Tag)
+	}
+
+	if err != nil {
+		return GeneralName{}, err
+	}
+
+	return name, nil
+}
+
+// marshalDNSName returns a GeneralName raw value containing a DNS name.
+func marshalDNSName(name string) (asn1.RawValue, error) {
+	if err := isIA5String(name); err != nil {
+		return asn1.RawValue{}, err
+	}
+
+	if _, ok := domainToReverseLabels(name); !ok {
+		return asn1.RawValue{}, fmt.Errorf("couldn't parse %q as domain name", name)
+	}
+
+	return asn1.RawValue{
+		Tag:   nameTagDNSName,
+		Class: asn1.ClassContextSpecific,
+		Bytes: []byte(name),
+	}, nil
+}
+
+// marshalEmailAddress returns a GeneralName raw value containing an RFC822
+// email address.
+func marshalEmailAddress(addr string) (asn1.RawValue, error) {
+	if err := isIA5String(addr); err != nil {
+		return asn1.RawValue{}, err
+	}
+
+	if _, ok := parseRFC2821Mailbox(addr); !ok {
+		return asn1.RawValue{}, fmt.Errorf("couldn't parse %q as email address", addr)
+	}
+
+	return asn1.RawValue{
+		Tag:   nameTagRFC822Name,
+		Class: asn1.ClassContextSpecific,
+		Bytes: []byte(addr),
+	}, nil
+}
+
+// marshalIPAddress returns a GeneralName raw value containing an IP address.
+func marshalIPAddress(ip net.IP) asn1.RawValue {
+	ipBytes := ip.To4()
+	if ipBytes == nil {
+		ipBytes = ip
+	}
+
+	return asn1.RawValue{
+		Tag:   nameTagIPAddress,
+		Class: asn1.ClassContextSpecific,
+		Bytes: ipBytes,
+	}
+}
+
+// marshalURI returns a GeneralName raw value containing a URI.
+func marshalURI(uri *url.URL) asn1.RawValue {
+	return asn1.RawValue{
+		Tag:   nameTagURI,
+		Class: asn1.ClassContextSpecific,
+		Bytes: []byte(uri.String()),
+	}
+}
+
+// marshalDirectoryName returns a GeneralName raw value containing a
+// directory name. Since Name is a CHOICE, the tag is explicit.
+func marshalDirectoryName(name pkix.RDNSequence) (asn1.RawValue, error) {
+	der, err := asn1.Marshal(name)
+	if err != nil {
+		return asn1.RawValue{}, err
+	}
+
+	return asn1.RawValue{
+		Tag:        nameTagDirectoryName,
+		Class:      asn1.ClassContextSpecific,
+		IsCompound: true,
+		Bytes:      der,
+	}, nil
+}
+
+// marshalRawName returns a GeneralName raw value containing a name of a type
+// for which GeneralName has no other field.
+func marshalRawName(val asn1.RawValue) (asn1.RawValue, error) {
+	if val.Class != asn1.ClassContextSpecific {
+		return asn1.RawValue{}, errors.New("raw general name is not context-specific")
+	}
+
+	switch val.Tag {
+	case nameTagOtherName, nameTagX400Address, nameTagEDIPartyName, nameTagRegisteredID:
+		return val, nil
+	}
+
+	return asn1.RawValue{}, fmt.Errorf("invalid raw general name tag: %d", val.Tag)
+}
+
+// isRawPresent returns true if a raw value is present.
+func isRawPresent(val asn1.RawValue) bool {
+	return len(val.FullBytes) != 0 || len(val.Bytes) != 0 || val.Tag != 0 || val.Class != 0
+}
+
+// unmarshalIPAddress parses an IP address from a GeneralName raw value.
+func unmarshalIPAddress(val asn1.RawValue) (net.IP, error) {
+	switch len(val.Bytes) {
+	case net.IPv4len, net.IPv6len:
+		return val.Bytes, nil
+	}
+
+	return nil, errors.New("cannot parse IP address")
+}
+
+// unmarshalURI parses a URI from a GeneralName raw value.
+func unmarshalURI(val asn1.RawValue) (*url.URL, error) {
+	uri, err := url.Parse(string(val.Bytes))
+	if err != nil {
+		return nil, fmt.Errorf("cannot parse %q as URI", string(val.Bytes))
+	}
+
+	if len(uri.Host) > 0 {
+		if _, ok := domainToReverseLabels(uri.Host); !ok {
+			return nil, fmt.Errorf("cannot parse %q as URI", string(val.Bytes))
 		}
 	}
 
+	return uri, nil
+}
+
+// unmarshalDirectoryName parses a directory name from a GeneralName raw
+// value.
+func unmarshalDirectoryName(val asn1.RawValue) (pkix.RDNSequence, error) {
+	if !val.IsCompound {
+		return nil, errors.New("cannot parse directory name")
+	}
+
+	var name pkix.RDNSequence
+	if rest, err := asn1.Unmarshal(val.Bytes, &name); err != nil {
+		return nil, err
+	} else if len(rest) != 0 {
+		return nil, errors.New("trailing bytes")
+	}
+
+	return name, nil
+}
+
+// generalNameJSON is the JSON representation of a GeneralName.
+type generalNameJSON struct {
+	DNSName       string    `json:"dnsName,omitempty"`
+	DirectoryName *nameJSON `json:"directoryName,omitempty"`
+	EmailAddress  string    `json:"emailAddress,omitempty"`
+	IPAddress     net.IP    `json:"ipAddress,omitempty"`
+	URI           string    `json:"uri,omitempty"`
+	Raw           string    `json:"raw,omitempty"`
+}
+
+// MarshalJSON returns the JSON encoding of a value. The names are encoded
+// as a list in sequence order, and each name is an object with exactly one
+// of "dnsName", "directoryName", "emailAddress", "ipAddress", "uri" and
+// "raw" members. IP addresses and URIs are strings, and a directory name is
+// a list of relative distinguished names, each of which is a list of
+// {"type", "value"} objects with a named or dotted OID type. An attribute
+// value which is not a string has a "der" hex string in place of "value". A
+// raw name is a hex string containing its DER-encoding, including the
+// context-specific tag.
+func (e GeneralNames) MarshalJSON() ([]byte, error) {
+	return json.Marshal([]GeneralName(e))
+}
+
+// UnmarshalJSON parses a JSON-encoded value and stores the result in the
+// object.
+func (e *GeneralNames) UnmarshalJSON(b []byte) error {
+	var tmp []GeneralName
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
 	*e = tmp
 
 	return nil
 }
+
+// MarshalJSON returns the JSON encoding of a value. The name is encoded as
+// an object with exactly one of "dnsName", "directoryName", "emailAddress",
+// "ipAddress", "uri" and "raw". See GeneralNames.MarshalJSON.
+func (e GeneralName) MarshalJSON() ([]byte, error) {
+	var tmp = generalNameJSON{
+		DNSName:      e.DNSName,
+		EmailAddress: e.EmailAddress,
+		IPAddress:    e.IPAddress,
+	}
+
+	if e.DirectoryName != nil {
+		dn, err := nameToJSON(e.DirectoryName)
+		if err != nil {
+			return nil, err
+		}
+
+		tmp.DirectoryName = &dn
+	}
+
+	if e.URI != nil {
+		tmp.URI = e.URI.String()
+	}
+
+	raw, err := rawToHex(e.Raw)
+	if err != nil {
+		return nil, err
+	}
+	tmp.Raw = raw
+
+	return json.Marshal(tmp)
+}
+
+// UnmarshalJSON parses a JSON-encoded value and stores the result in the
+// object.
+func (e *GeneralName) UnmarshalJSON(b []byte) error {
+	var tmp generalNameJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	var name = GeneralName{
+		DNSName:      tmp.DNSName,
+		EmailAddress: tmp.EmailAddress,
+		IPAddress:    tmp.IPAddress,
+	}
+
+	if tmp.DirectoryName != nil {
+		dn, err := nameFromJSON(*tmp.DirectoryName)
+		if err != nil {
+			return err
+		}
+
+		name.DirectoryName = dn
+	}
+
+	if tmp.URI != "" {
+		uri, err := url.Parse(tmp.URI)
+		if err != nil {
+			return err
+		}
+
+		name.URI = uri
+	}
+
+	raw, err := rawFromHex(tmp.Raw)
+	if err != nil {
+		return err
+	}
+	name.Raw = raw
+
+	*e = name
+
+	return nil
+}
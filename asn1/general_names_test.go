@@ -2,6 +2,7 @@ package asn1_test
 
 import (
 	"bytes"
+	"crypto/x509/pkix"
 	"errors"
 	"net"
 	"net/url"
@@ -38,16 +39,14 @@ func TestGeneralNamesMarshal(t *testing.T) {
 		{
 			name: "Full",
 			obj: pgasn1.GeneralNames{
-				DNSNames:       []string{"some.domain", "foo.bar"},
-				EmailAddresses: []string{"foo@bar", "tom@jerry"},
-				IPAddresses: []net.IP{
-					net.ParseIP("10.0.0.1"),
-					net.ParseIP("::1"),
-				},
-				URIs: []*url.URL{
-					mustParseURI(t, "http://www.this"),
-					mustParseURI(t, "ftp://ftp.that"),
-				},
+				{DNSName: "some.domain"},
+				{DNSName: "foo.bar"},
+				{EmailAddress: "foo@bar"},
+				{EmailAddress: "tom@jerry"},
+				{IPAddress: net.ParseIP("10.0.0.1")},
+				{IPAddress: net.ParseIP("::1")},
+				{URI: mustParseURI(t, "http://www.this")},
+				{URI: mustParseURI(t, "ftp://ftp.that")},
 			},
 			want: []byte{asn1.TagSequence | bit6, 99,
 				nameTagDNSName | asn1.ClassContextSpecific<<6, 11, 's', 'o', 'm', 'e', '.', 'd', 'o', 'm', 'a', 'i', 'n',
@@ -62,31 +61,45 @@ func TestGeneralNamesMarshal(t *testing.T) {
 				'/', '/', 'f', 't', 'p', '.', 't', 'h', 'a', 't',
 			},
 		},
+		{
+			name: "DirectoryName",
+			obj: pgasn1.GeneralNames{
+				{DirectoryName: pkix.RDNSequence{{{Type: asn1.ObjectIdentifier{2, 5, 4, 3}, Value: "Foo"}}}},
+			},
+			want: []byte{asn1.TagSequence | bit6, 18,
+				nameTagDirectoryName | asn1.ClassContextSpecific<<6 | bit6, 16,
+				asn1.TagSequence | bit6, 14,
+				asn1.TagSet | bit6, 12,
+				asn1.TagSequence | bit6, 10,
+				asn1.TagOID, 3, 0x55, 0x04, 0x03,
+				asn1.TagPrintableString, 3, 'F', 'o', 'o',
+			},
+		},
 		{
 			name: "NotIA5String/DNSNames",
 			obj: pgasn1.GeneralNames{
-				DNSNames: []string{"\xff"},
+				{DNSName: "\xff"},
 			},
 			err: errors.New("not IA5String"),
 		},
 		{
 			name: "NotIA5String/EmailAddresses",
 			obj: pgasn1.GeneralNames{
-				EmailAddresses: []string{"\xff"},
+				{EmailAddress: "\xff"},
 			},
 			err: errors.New("not IA5String"),
 		},
 		{
 			name: "NotDomainName",
 			obj: pgasn1.GeneralNames{
-				DNSNames: []string{"..."},
+				{DNSName: "..."},
 			},
 			err: errors.New("not domain name"),
 		},
 		{
 			name: "NotRFC822Name",
 			obj: pgasn1.GeneralNames{
-				EmailAddresses: []string{"dog"},
+				{EmailAddress: "dog"},
 			},
 			err: errors.New("not RFC822 name"),
 		},
@@ -134,18 +147,79 @@ func TestGeneralNamesUnmarshal(t *testing.T) {
 				'/', '/', 'f', 't', 'p', '.', 't', 'h', 'a', 't',
 			},
 			want: pgasn1.GeneralNames{
-				DNSNames:       []string{"some.domain", "foo.bar"},
-				EmailAddresses: []string{"foo@bar", "tom@jerry"},
-				IPAddresses: []net.IP{
-					net.ParseIP("10.0.0.1").To4(),
-					net.ParseIP("::1"),
-				},
-				URIs: []*url.URL{
-					mustParseURI(t, "http://www.this"),
-					mustParseURI(t, "ftp://ftp.that"),
-				},
+				{DNSName: "some.domain"},
+				{DNSName: "foo.bar"},
+				{EmailAddress: "foo@bar"},
+				{EmailAddress: "tom@jerry"},
+				{IPAddress: net.ParseIP("10.0.0.1").To4()},
+				{IPAddress: net.ParseIP("::1")},
+				{URI: mustParseURI(t, "http://www.this")},
+				{URI: mustParseURI(t, "ftp://ftp.that")},
+			},
+		},
+		{
+			name: "DirectoryName",
+			obj: []byte{asn1.TagSequence | bit6, 18,
+				nameTagDirectoryName | asn1.ClassContextSpecific<<6 | bit6, 16,
+				asn1.TagSequence | bit6, 14,
+				asn1.TagSet | bit6, 12,
+				asn1.TagSequence | bit6, 10,
+				asn1.TagOID, 3, 0x55, 0x04, 0x03,
+				asn1.TagPrintableString, 3, 'F', 'o', 'o',
+			},
+			want: pgasn1.GeneralNames{
+				{DirectoryName: pkix.RDNSequence{{{Type: asn1.ObjectIdentifier{2, 5, 4, 3}, Value: "Foo"}}}},
+			},
+		},
+		{
+			name: "RawNames",
+			obj: []byte{asn1.TagSequence | bit6, 26,
+				nameTagOtherName | asn1.ClassContextSpecific<<6 | bit6, 12,
+				asn1.TagOID, 3, 0x2a, 0x03, 0x04,
+				0 | asn1.ClassContextSpecific<<6 | bit6, 5,
+				asn1.TagUTF8String, 3, 'F', 'o', 'o',
+				nameTagDNSName | asn1.ClassContextSpecific<<6, 3, 'f', 'o', 'o',
+				nameTagRegisteredID | asn1.ClassContextSpecific<<6, 5, 0x2a, 0x03, 0x04, 0x05, 0x06,
+			},
+			want: pgasn1.GeneralNames{
+				{Raw: asn1.RawValue{
+					Class:      asn1.ClassContextSpecific,
+					Tag:        nameTagOtherName,
+					IsCompound: true,
+					Bytes: []byte{
+						asn1.TagOID, 3, 0x2a, 0x03, 0x04,
+						0 | asn1.ClassContextSpecific<<6 | bit6, 5,
+						asn1.TagUTF8String, 3, 'F', 'o', 'o',
+					},
+					FullBytes: []byte{
+						nameTagOtherName | asn1.ClassContextSpecific<<6 | bit6, 12,
+						asn1.TagOID, 3, 0x2a, 0x03, 0x04,
+						0 | asn1.ClassContextSpecific<<6 | bit6, 5,
+						asn1.TagUTF8String, 3, 'F', 'o', 'o',
+					},
+				}},
+				{DNSName: "foo"},
+				{Raw: asn1.RawValue{
+					Class:     asn1.ClassContextSpecific,
+					Tag:       nameTagRegisteredID,
+					Bytes:     []byte{0x2a, 0x03, 0x04, 0x05, 0x06},
+					FullBytes: []byte{nameTagRegisteredID | asn1.ClassContextSpecific<<6, 5, 0x2a, 0x03, 0x04, 0x05, 0x06},
+				}},
 			},
 		},
+		{
+			name: "EmptyDNSName",
+			obj: []byte{asn1.TagSequence | bit6, 2,
+				nameTagDNSName | asn1.ClassContextSpecific<<6, 0,
+			},
+			want: pgasn1.GeneralNames{{DNSName: ""}},
+		},
+		{
+			name: "BadDirectoryName",
+			obj: []byte{asn1.TagSequence | bit6, 5,
+				nameTagDirectoryName | asn1.ClassContextSpecific<<6, 3, 'F', 'o', 'o'},
+			err: errors.New("bad directory name"),
+		},
 		{
 			name: "BadASN1",
 			obj:  []byte{0xff},
@@ -208,3 +282,227 @@ func mustParseURI(t *testing.T, s string) *url.URL {
 
 	return uri
 }
+
+func TestGeneralNameMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		obj  pgasn1.GeneralName
+		want []byte
+		err  error
+	}{
+		{
+			name: "DNSName",
+			obj:  pgasn1.GeneralName{DNSName: "foo.bar"},
+			want: []byte{nameTagDNSName | asn1.ClassContextSpecific<<6, 7,
+				'f', 'o', 'o', '.', 'b', 'a', 'r'},
+		},
+		{
+			name: "IPAddress",
+			obj:  pgasn1.GeneralName{IPAddress: net.ParseIP("10.0.0.1")},
+			want: []byte{nameTagIPAddress | asn1.ClassContextSpecific<<6, 4, 10, 0, 0, 1},
+		},
+		{
+			name: "Empty",
+			obj:  pgasn1.GeneralName{},
+			err:  errors.New("no name"),
+		},
+		{
+			name: "TwoNames",
+			obj: pgasn1.GeneralName{
+				DNSName:      "foo.bar",
+				EmailAddress: "foo@bar",
+			},
+			err: errors.New("two names"),
+		},
+		{
+			name: "NotDomainName",
+			obj:  pgasn1.GeneralName{DNSName: "..."},
+			err:  errors.New("not domain name"),
+		},
+		{
+			name: "Raw",
+			obj: pgasn1.GeneralName{
+				Raw: asn1.RawValue{
+					Class: asn1.ClassContextSpecific,
+					Tag:   nameTagRegisteredID,
+					Bytes: []byte{0x2a, 0x03, 0x04},
+				},
+			},
+			want: []byte{nameTagRegisteredID | asn1.ClassContextSpecific<<6, 3, 0x2a, 0x03, 0x04},
+		},
+		{
+			name: "RawBadTag",
+			obj: pgasn1.GeneralName{
+				Raw: asn1.RawValue{
+					Class: asn1.ClassContextSpecific,
+					Tag:   nameTagDNSName,
+					Bytes: []byte{'f', 'o', 'o'},
+				},
+			},
+			err: errors.New("bad raw tag"),
+		},
+		{
+			name: "RawNotContextSpecific",
+			obj: pgasn1.GeneralName{
+				Raw: asn1.RawValue{Tag: asn1.TagOID, Bytes: []byte{0x2a, 0x03, 0x04}},
+			},
+			err: errors.New("raw not context-specific"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.obj.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !bytes.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGeneralNameUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		obj  []byte
+		want pgasn1.GeneralName
+		err  error
+	}{
+		{
+			name: "EmailAddress",
+			obj: []byte{nameTagRFC822Name | asn1.ClassContextSpecific<<6, 7,
+				'f', 'o', 'o', '@', 'b', 'a', 'r'},
+			want: pgasn1.GeneralName{EmailAddress: "foo@bar"},
+		},
+		{
+			name: "URI",
+			obj: []byte{nameTagURI | asn1.ClassContextSpecific<<6, 15,
+				'h', 't', 't', 'p', ':', '/', '/', 'w', 'w', 'w', '.', 't', 'h', 'i', 's'},
+			want: pgasn1.GeneralName{URI: mustParseURI(t, "http://www.this")},
+		},
+		{
+			name: "RegisteredID",
+			obj:  []byte{nameTagRegisteredID | asn1.ClassContextSpecific<<6, 3, 0x2a, 0x03, 0x04},
+			want: pgasn1.GeneralName{
+				Raw: asn1.RawValue{
+					Class:     asn1.ClassContextSpecific,
+					Tag:       nameTagRegisteredID,
+					Bytes:     []byte{0x2a, 0x03, 0x04},
+					FullBytes: []byte{nameTagRegisteredID | asn1.ClassContextSpecific<<6, 3, 0x2a, 0x03, 0x04},
+				},
+			},
+		},
+		{
+			name: "BadTag",
+			obj:  []byte{9 | asn1.ClassContextSpecific<<6, 3, 0x2a, 0x03, 0x04},
+			err:  errors.New("bad tag"),
+		},
+		{
+			name: "NotContextSpecific",
+			obj:  []byte{asn1.TagOID, 3, 0x2a, 0x03, 0x04},
+			err:  errors.New("not context-specific"),
+		},
+		{
+			name: "TrailingBytes",
+			obj:  []byte{nameTagIPAddress | asn1.ClassContextSpecific<<6, 4, 10, 0, 0, 1, 0xff},
+			err:  errors.New("trailing bytes"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got pgasn1.GeneralName
+
+			err := got.Unmarshal(tc.obj)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestGeneralNamesRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		der  []byte
+	}{
+		{
+			name: "OtherName",
+			der: []byte{asn1.TagSequence | bit6, 19,
+				nameTagDNSName | asn1.ClassContextSpecific<<6, 3, 'f', 'o', 'o',
+				nameTagOtherName | asn1.ClassContextSpecific<<6 | bit6, 12,
+				asn1.TagOID, 3, 0x2a, 0x03, 0x04,
+				0 | asn1.ClassContextSpecific<<6 | bit6, 5,
+				asn1.TagUTF8String, 3, 'F', 'o', 'o',
+			},
+		},
+		{
+			name: "X400AddressAndEDIPartyName",
+			der: []byte{asn1.TagSequence | bit6, 13,
+				nameTagX400Address | asn1.ClassContextSpecific<<6 | bit6, 2,
+				asn1.TagSequence | bit6, 0,
+				nameTagEDIPartyName | asn1.ClassContextSpecific<<6 | bit6, 7,
+				1 | asn1.ClassContextSpecific<<6 | bit6, 5,
+				asn1.TagUTF8String, 3, 'F', 'o', 'o',
+			},
+		},
+		{
+			name: "MixedOrder",
+			der: []byte{asn1.TagSequence | bit6, 45,
+				nameTagIPAddress | asn1.ClassContextSpecific<<6, 4, 192, 0, 2, 1,
+				nameTagURI | asn1.ClassContextSpecific<<6, 9, 'h', 't', 't', 'p', ':', '/', '/', 'a', '/',
+				nameTagDirectoryName | asn1.ClassContextSpecific<<6 | bit6, 16,
+				asn1.TagSequence | bit6, 14,
+				asn1.TagSet | bit6, 12,
+				asn1.TagSequence | bit6, 10,
+				asn1.TagOID, 3, 0x55, 0x04, 0x03,
+				asn1.TagPrintableString, 3, 'F', 'o', 'o',
+				nameTagRFC822Name | asn1.ClassContextSpecific<<6, 3, 'a', '@', 'b',
+				nameTagDNSName | asn1.ClassContextSpecific<<6, 3, 'f', 'o', 'o',
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var names pgasn1.GeneralNames
+			if err := names.Unmarshal(tc.der); err != nil {
+				t.Fatalf("couldn't unmarshal general names: %v", err)
+			}
+
+			got, err := names.Marshal()
+			if err != nil {
+				t.Fatalf("couldn't marshal general names: %v", err)
+			}
+
+			if !bytes.Equal(got, tc.der) {
+				t.Errorf("got %x, want %x", got, tc.der)
+			}
+		})
+	}
+}
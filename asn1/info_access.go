@@ -0,0 +1,135 @@
+package asn1
+
+import (
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+)
+
+// InfoAccess represents an X509 authority or subject information access
+// extension as defined in RFC 5280 sections 4.2.2.1 and 4.2.2.2.
+//
+// id-pe-authorityInfoAccess OBJECT IDENTIFIER ::= { id-pe 1 }
+//
+// AuthorityInfoAccessSyntax  ::=
+//         SEQUENCE SIZE (1..MAX) OF AccessDescription
+//
+// id-pe-subjectInfoAccess OBJECT IDENTIFIER ::= { id-pe 11 }
+//
+// SubjectInfoAccessSyntax  ::=
+//         SEQUENCE SIZE (1..MAX) OF AccessDescription
+type InfoAccess []AccessDescription
+
+// AccessDescription represents an access description as defined in RFC 5280
+// section 4.2.2.1.
+//
+// AccessDescription  ::=  SEQUENCE {
+//         accessMethod          OBJECT IDENTIFIER,
+//         accessLocation        GeneralName  }
+type AccessDescription struct {
+	Method   asn1.ObjectIdentifier
+	Location GeneralName
+}
+
+// accessDescription is the intermediate representation of an
+// AccessDescription.
+type accessDescription struct {
+	Method   asn1.ObjectIdentifier
+	Location asn1.RawValue
+}
+
+// Marshal returns the ASN.1 DER-encoding of a value.
+func (e InfoAccess) Marshal() ([]byte, error) {
+	if len(e) == 0 {
+		return nil, errors.New("no access descriptions specified")
+	}
+
+	var tmp []accessDescription
+
+	for _, desc := range e {
+		if len(desc.Method) == 0 {
+			return nil, errors.New("no access method specified")
+		}
+
+		loc, err := desc.Location.raw()
+		if err != nil {
+			return nil, err
+		}
+
+		tmp = append(tmp, accessDescription{
+			Method:   desc.Method,
+			Location: loc,
+		})
+	}
+
+	return asn1.Marshal(tmp)
+}
+
+// Unmarshal parses an DER-encoded ASN.1 data structure and stores the result
+// in the object.
+func (e *InfoAccess) Unmarshal(b []byte) error {
+	var raw []accessDescription
+
+	rest, err := asn1.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	} else if len(rest) != 0 {
+		return errors.New("trailing bytes")
+	}
+
+	var tmp InfoAccess
+
+	for _, desc := range raw {
+		loc, err := generalNameFromRaw(desc.Location)
+		if err != nil {
+			return err
+		}
+
+		tmp = append(tmp, AccessDescription{
+			Method:   desc.Method,
+			Location: loc,
+		})
+	}
+
+	*e = tmp
+
+	return nil
+}
+
+// accessDescriptionJSON is the JSON representation of an AccessDescription.
+type accessDescriptionJSON struct {
+	Method   string      `json:"method"`
+	Location GeneralName `json:"location"`
+}
+
+// MarshalJSON returns the JSON encoding of a value. The access description
+// is encoded as an object with a "method" member containing the named or
+// dotted access method OID, such as "OCSP" or "caIssuers", and a "location"
+// member. See GeneralName.MarshalJSON.
+func (e AccessDescription) MarshalJSON() ([]byte, error) {
+	return json.Marshal(accessDescriptionJSON{
+		Method:   OIDName(e.Method),
+		Location: e.Location,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded value and stores the result in the
+// object.
+func (e *AccessDescription) UnmarshalJSON(b []byte) error {
+	var tmp accessDescriptionJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	oid, err := ParseOIDName(tmp.Method)
+	if err != nil {
+		return err
+	}
+
+	*e = AccessDescription{
+		Method:   oid,
+		Location: tmp.Location,
+	}
+
+	return nil
+}
@@ -0,0 +1,175 @@
+package asn1_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+func TestInfoAccessMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		obj  pgasn1.InfoAccess
+		want []byte
+		err  error
+	}{
+		{
+			name: "OK",
+			obj: pgasn1.InfoAccess{
+				{
+					Method:   pgasn1.OIDAccessMethodOCSP,
+					Location: pgasn1.GeneralName{URI: mustParseURI(t, "http://o/")},
+				},
+				{
+					Method:   pgasn1.OIDAccessMethodCAIssuers,
+					Location: pgasn1.GeneralName{URI: mustParseURI(t, "http://c/x")},
+				},
+			},
+			want: []byte{asn1.TagSequence | bit6, 47,
+				asn1.TagSequence | bit6, 21,
+				asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x30, 0x01,
+				nameTagURI | asn1.ClassContextSpecific<<6, 9,
+				'h', 't', 't', 'p', ':', '/', '/', 'o', '/',
+				asn1.TagSequence | bit6, 22,
+				asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x30, 0x02,
+				nameTagURI | asn1.ClassContextSpecific<<6, 10,
+				'h', 't', 't', 'p', ':', '/', '/', 'c', '/', 'x',
+			},
+		},
+		{
+			name: "Empty",
+			obj:  pgasn1.InfoAccess{},
+			err:  errors.New("no access descriptions"),
+		},
+		{
+			name: "NoMethod",
+			obj: pgasn1.InfoAccess{
+				{Location: pgasn1.GeneralName{URI: mustParseURI(t, "http://o/")}},
+			},
+			err: errors.New("no method"),
+		},
+		{
+			name: "NoLocation",
+			obj: pgasn1.InfoAccess{
+				{Method: pgasn1.OIDAccessMethodOCSP},
+			},
+			err: errors.New("no location"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.obj.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !bytes.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInfoAccessUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		der  []byte
+		want pgasn1.InfoAccess
+		err  error
+	}{
+		{
+			name: "OK",
+			der: []byte{asn1.TagSequence | bit6, 22,
+				asn1.TagSequence | bit6, 20,
+				asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x30, 0x05,
+				nameTagDNSName | asn1.ClassContextSpecific<<6, 8,
+				'r', 'e', 'p', 'o', '.', 'c', 'o', 'm',
+			},
+			want: pgasn1.InfoAccess{
+				{
+					Method:   pgasn1.OIDAccessMethodCARepository,
+					Location: pgasn1.GeneralName{DNSName: "repo.com"},
+				},
+			},
+		},
+		{
+			name: "RegisteredID",
+			der: []byte{asn1.TagSequence | bit6, 17,
+				asn1.TagSequence | bit6, 15,
+				asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x30, 0x05,
+				nameTagRegisteredID | asn1.ClassContextSpecific<<6, 3, 0x2a, 0x03, 0x04,
+			},
+			want: pgasn1.InfoAccess{
+				{
+					Method: pgasn1.OIDAccessMethodCARepository,
+					Location: pgasn1.GeneralName{
+						Raw: asn1.RawValue{
+							Class:     asn1.ClassContextSpecific,
+							Tag:       nameTagRegisteredID,
+							Bytes:     []byte{0x2a, 0x03, 0x04},
+							FullBytes: []byte{nameTagRegisteredID | asn1.ClassContextSpecific<<6, 3, 0x2a, 0x03, 0x04},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "BadNameTag",
+			der: []byte{asn1.TagSequence | bit6, 17,
+				asn1.TagSequence | bit6, 15,
+				asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x30, 0x05,
+				9 | asn1.ClassContextSpecific<<6, 3, 0x2a, 0x03, 0x04,
+			},
+			err: errors.New("bad name tag"),
+		},
+		{
+			name: "TrailingBytes",
+			der: []byte{asn1.TagSequence | bit6, 22,
+				asn1.TagSequence | bit6, 20,
+				asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x30, 0x05,
+				nameTagDNSName | asn1.ClassContextSpecific<<6, 8,
+				'r', 'e', 'p', 'o', '.', 'c', 'o', 'm',
+				0xff,
+			},
+			err: errors.New("trailing bytes"),
+		},
+		{
+			name: "BadASN1",
+			der:  []byte{0xff},
+			err:  errors.New("bad ASN.1"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got pgasn1.InfoAccess
+
+			err := got.Unmarshal(tc.der)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,750 @@
+package asn1
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/bits"
+	"net"
+	"strings"
+)
+
+// IPAddrBlocks represents an IP address delegation extension as defined in
+// RFC 3779 section 2.2.3. Address families must be listed in ascending
+// order of AFI and SAFI, and each address family may appear at most once.
+//
+// id-pe-ipAddrBlocks OBJECT IDENTIFIER ::= { id-pe 7 }
+//
+// IPAddrBlocks ::= SEQUENCE OF IPAddressFamily
+type IPAddrBlocks []IPAddressFamily
+
+// IPAddressFamily represents the IP address resources of a single address
+// family as defined in RFC 3779 section 2.2.3.3. AFI is an IANA address
+// family number, and only AFIIPv4 and AFIIPv6 are supported. SAFI is a
+// subsequent address family identifier, and is absent if zero. Exactly one
+// of Inherit and Addresses should be set. Addresses must be listed in
+// ascending order, and may be neither overlapping nor adjacent.
+//
+// IPAddressFamily ::= SEQUENCE {
+//      addressFamily        OCTET STRING (SIZE (2..3)),
+//      ipAddressChoice      IPAddressChoice }
+//
+// IPAddressChoice ::= CHOICE {
+//      inherit              NULL,
+//      addressesOrRanges    SEQUENCE OF IPAddressOrRange }
+type IPAddressFamily struct {
+	AFI       int
+	SAFI      int
+	Inherit   bool
+	Addresses []IPAddressOrRange
+}
+
+// IPAddressOrRange represents an IP address prefix or an inclusive range of
+// IP addresses as defined in RFC 3779 section 2.2.3.7. Exactly one of Prefix
+// and the Min and Max pair should be set. As RFC 3779 requires, a range
+// which covers exactly one prefix is encoded as that prefix, and is parsed
+// as a Prefix.
+//
+// IPAddressOrRange ::= CHOICE {
+//      addressPrefix        IPAddress,
+//      addressRange         IPAddressRange }
+//
+// IPAddressRange ::= SEQUENCE {
+//      min                  IPAddress,
+//      max                  IPAddress }
+//
+// IPAddress ::= BIT STRING
+type IPAddressOrRange struct {
+	Prefix *net.IPNet
+	Min    net.IP
+	Max    net.IP
+}
+
+// Address family number values. See RFC 3779 section 2.2.3.3.
+const (
+	AFIIPv4 = 1
+	AFIIPv6 = 2
+)
+
+// maxSAFI is the largest subsequent address family identifier.
+const maxSAFI = 255
+
+// ipAddressFamily is the intermediate representation of an IPAddressFamily.
+type ipAddressFamily struct {
+	AddressFamily []byte
+	Choice        asn1.RawValue
+}
+
+// ipAddressRange is the intermediate representation of an IP address range.
+type ipAddressRange struct {
+	Min asn1.BitString
+	Max asn1.BitString
+}
+
+// Marshal returns the ASN.1 DER-encoding of a value.
+func (e IPAddrBlocks) Marshal() ([]byte, error) {
+	if len(e) == 0 {
+		return nil, errors.New("no IP address families specified")
+	}
+
+	var tmp []ipAddressFamily
+	var last []byte
+
+	for _, f := range e {
+		raw, err := f.raw()
+		if err != nil {
+			return nil, err
+		}
+
+		if err := checkAddressFamilyOrder(last, raw.AddressFamily); err != nil {
+			return nil, err
+		}
+		last = raw.AddressFamily
+
+		tmp = append(tmp, raw)
+	}
+
+	return asn1.Marshal(tmp)
+}
+
+// Unmarshal parses an DER-encoded ASN.1 data structure and stores the result
+// in the object.
+func (e *IPAddrBlocks) Unmarshal(b []byte) error {
+	var raw []ipAddressFamily
+
+	rest, err := asn1.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	} else if len(rest) != 0 {
+		return errors.New("trailing bytes")
+	}
+
+	if len(raw) == 0 {
+		return errors.New("no IP address families specified")
+	}
+
+	var tmp IPAddrBlocks
+	var last []byte
+
+	for _, r := range raw {
+		if err := checkAddressFamilyOrder(last, r.AddressFamily); err != nil {
+			return err
+		}
+		last = r.AddressFamily
+
+		f, err := ipAddressFamilyFromRaw(r)
+		if err != nil {
+			return err
+		}
+
+		tmp = append(tmp, f)
+	}
+
+	*e = tmp
+
+	return nil
+}
+
+// Family returns the address family with the specified AFI and SAFI, if
+// present.
+func (e IPAddrBlocks) Family(afi, safi int) (IPAddressFamily, bool) {
+	for _, f := range e {
+		if f.AFI == afi && f.SAFI == safi {
+			return f, true
+		}
+	}
+
+	return IPAddressFamily{}, false
+}
+
+// Contains returns true if every IP address resource in child is also among
+// the resources in e, as RFC 3779 section 2.3 requires of a certificate with
+// respect to its issuer. An address family which child inherits is
+// contained if e includes that address family. Since the addresses of an
+// address family which e inherits are not known, such an address family
+// contains only an inherited address family, and Resolve should be used to
+// replace inherited resources before calling Contains. Invalid addresses are
+// never contained.
+func (e IPAddrBlocks) Contains(child IPAddrBlocks) bool {
+	for _, cf := range child {
+		f, ok := e.Family(cf.AFI, cf.SAFI)
+		if !ok {
+			return false
+		}
+
+		if cf.Inherit {
+			continue
+		} else if f.Inherit {
+			return false
+		}
+
+		if !f.contains(cf) {
+			return false
+		}
+	}
+
+	return true
+}
+
+// Resolve returns a copy of e in which each inherited address family is
+// replaced with the same address family from issuer, which should itself
+// already be resolved. An error is returned if issuer does not include an
+// address family which e inherits.
+func (e IPAddrBlocks) Resolve(issuer IPAddrBlocks) (IPAddrBlocks, error) {
+	var tmp IPAddrBlocks
+
+	for _, f := range e {
+		if f.Inherit {
+			inherited, ok := issuer.Family(f.AFI, f.SAFI)
+			if !ok {
+				return nil, fmt.Errorf("issuer has no resources for address family %d", f.AFI)
+			}
+
+			f = inherited
+		}
+
+		tmp = append(tmp, f)
+	}
+
+	return tmp, nil
+}
+
+// raw converts an IPAddressFamily to its intermediate representation.
+func (e IPAddressFamily) raw() (ipAddressFamily, error) {
+	n, err := addressLength(e.AFI)
+	if err != nil {
+		return ipAddressFamily{}, err
+	}
+
+	var family = []byte{byte(e.AFI >> 8), byte(e.AFI)}
+
+	if e.SAFI < 0 || e.SAFI > maxSAFI {
+		return ipAddressFamily{}, fmt.Errorf("invalid SAFI: %d", e.SAFI)
+	} else if e.SAFI != 0 {
+		family = append(family, byte(e.SAFI))
+	}
+
+	if e.Inherit && len(e.Addresses) != 0 {
+		return ipAddressFamily{}, errors.New("IP address family both inherits and lists addresses")
+	}
+
+	var vals []asn1.RawValue
+	var last []byte
+
+	for _, a := range e.Addresses {
+		min, max, err := a.bounds(n)
+		if err != nil {
+			return ipAddressFamily{}, err
+		}
+
+		if err := checkAddressOrder(last, min); err != nil {
+			return ipAddressFamily{}, err
+		}
+		last = max
+
+		val, err := ipAddressOrRangeRaw(min, max)
+		if err != nil {
+			return ipAddressFamily{}, err
+		}
+
+		vals = append(vals, val)
+	}
+
+	choice, err := inheritChoiceRaw(e.Inherit, vals)
+	if err != nil {
+		return ipAddressFamily{}, err
+	}
+
+	return ipAddressFamily{
+		AddressFamily: family,
+		Choice:        choice,
+	}, nil
+}
+
+// contains returns true if every address in child lies within an address in
+// e. Since the addresses in e are neither overlapping nor adjacent, an
+// address in child which lies within the addresses in e must lie within a
+// single one of them.
+func (e IPAddressFamily) contains(child IPAddressFamily) bool {
+	n, err := addressLength(e.AFI)
+	if err != nil {
+		return false
+	}
+
+	for _, ca := range child.Addresses {
+		cmin, cmax, err := ca.bounds(n)
+		if err != nil {
+			return false
+		}
+
+		var found bool
+
+		for _, a := range e.Addresses {
+			min, max, err := a.bounds(n)
+			if err != nil {
+				return false
+			}
+
+			if bytes.Compare(min, cmin) <= 0 && bytes.Compare(cmax, max) <= 0 {
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return false
+		}
+	}
+
+	return true
+}
+
+// bounds returns the lowest and highest addresses in an IP address prefix or
+// range, as byte slices of the specified length.
+func (e IPAddressOrRange) bounds(n int) ([]byte, []byte, error) {
+	if e.Prefix != nil {
+		if e.Min != nil || e.Max != nil {
+			return nil, nil, errors.New("IP address entry contains both a prefix and a range")
+		}
+
+		var ip = addressOfLength(e.Prefix.IP, n)
+		if ip == nil || len(e.Prefix.Mask) != n {
+			return nil, nil, fmt.Errorf("IP prefix %v does not match address family", e.Prefix)
+		}
+
+		if err := checkIPAndMask(ip, e.Prefix.Mask); err != nil {
+			return nil, nil, err
+		}
+
+		var max = make([]byte, n)
+		for i := range max {
+			max[i] = ip[i] | ^e.Prefix.Mask[i]
+		}
+
+		return ip, max, nil
+	}
+
+	if e.Min == nil || e.Max == nil {
+		return nil, nil, errors.New("IP address entry contains neither a prefix nor a range")
+	}
+
+	var min, max = addressOfLength(e.Min, n), addressOfLength(e.Max, n)
+	if min == nil || max == nil {
+		return nil, nil, fmt.Errorf("IP address range %v-%v does not match address family", e.Min, e.Max)
+	}
+
+	if bytes.Compare(min, max) > 0 {
+		return nil, nil, fmt.Errorf("invalid IP address range: %v-%v", e.Min, e.Max)
+	}
+
+	return min, max, nil
+}
+
+// ipAddressFamilyFromRaw converts the intermediate representation of an
+// IPAddressFamily.
+func ipAddressFamilyFromRaw(raw ipAddressFamily) (IPAddressFamily, error) {
+	if len(raw.AddressFamily) < 2 || len(raw.AddressFamily) > 3 {
+		return IPAddressFamily{}, fmt.Errorf("invalid address family length: %d", len(raw.AddressFamily))
+	}
+
+	var f = IPAddressFamily{
+		AFI: int(raw.AddressFamily[0])<<8 | int(raw.AddressFamily[1]),
+	}
+
+	if len(raw.AddressFamily) == 3 {
+		f.SAFI = int(raw.AddressFamily[2])
+		if f.SAFI == 0 {
+			return IPAddressFamily{}, errors.New("invalid SAFI: 0")
+		}
+	}
+
+	n, err := addressLength(f.AFI)
+	if err != nil {
+		return IPAddressFamily{}, err
+	}
+
+	inherit, vals, err := inheritChoiceFromRaw(raw.Choice)
+	if err != nil {
+		return IPAddressFamily{}, err
+	}
+
+	f.Inherit = inherit
+
+	var last []byte
+
+	for _, val := range vals {
+		a, min, max, err := ipAddressOrRangeFromRaw(val, n)
+		if err != nil {
+			return IPAddressFamily{}, err
+		}
+
+		if err := checkAddressOrder(last, min); err != nil {
+			return IPAddressFamily{}, err
+		}
+		last = max
+
+		f.Addresses = append(f.Addresses, a)
+	}
+
+	return f, nil
+}
+
+// ipAddressOrRangeRaw returns a raw value containing the minimal encoding of
+// the IP address range with the specified bounds. A range which covers
+// exactly one prefix is encoded as that prefix. Otherwise, trailing zero
+// bits are removed from the lowest address and trailing one bits are removed
+// from the highest address. See RFC 3779 sections 2.2.3.7 and 2.2.3.8.
+func ipAddressOrRangeRaw(min, max []byte) (asn1.RawValue, error) {
+	var der []byte
+	var err error
+
+	if ones, ok := rangePrefixLength(min, max); ok {
+		der, err = asn1.Marshal(addressBitString(min, ones))
+	} else {
+		der, err = asn1.Marshal(ipAddressRange{
+			Min: addressBitString(min, len(min)*8-trailingBits(min, 0)),
+			Max: addressBitString(max, len(max)*8-trailingBits(max, 1)),
+		})
+	}
+
+	if err != nil {
+		return asn1.RawValue{}, err
+	}
+
+	return asn1.RawValue{FullBytes: der}, nil
+}
+
+// ipAddressOrRangeFromRaw parses an IP address prefix or range, and returns
+// it along with its lowest and highest addresses as byte slices of the
+// specified length. An error is returned if the encoding is not minimal.
+func ipAddressOrRangeFromRaw(val asn1.RawValue, n int) (IPAddressOrRange, []byte, []byte, error) {
+	if val.Class != asn1.ClassUniversal {
+		return IPAddressOrRange{}, nil, nil, errors.New("invalid IP address entry class")
+	}
+
+	switch val.Tag {
+	case asn1.TagBitString:
+		var bs asn1.BitString
+		if rest, err := asn1.Unmarshal(val.FullBytes, &bs); err != nil {
+			return IPAddressOrRange{}, nil, nil, err
+		} else if len(rest) != 0 {
+			return IPAddressOrRange{}, nil, nil, errors.New("trailing bytes")
+		}
+
+		min, err := addressFromBitString(bs, n, false)
+		if err != nil {
+			return IPAddressOrRange{}, nil, nil, err
+		}
+
+		max, err := addressFromBitString(bs, n, true)
+		if err != nil {
+			return IPAddressOrRange{}, nil, nil, err
+		}
+
+		var prefix = &net.IPNet{
+			IP:   net.IP(min),
+			Mask: net.CIDRMask(bs.BitLength, n*8),
+		}
+
+		return IPAddressOrRange{Prefix: prefix}, min, max, nil
+
+	case asn1.TagSequence:
+		var r ipAddressRange
+		if rest, err := asn1.Unmarshal(val.FullBytes, &r); err != nil {
+			return IPAddressOrRange{}, nil, nil, err
+		} else if len(rest) != 0 {
+			return IPAddressOrRange{}, nil, nil, errors.New("trailing bytes")
+		}
+
+		if (r.Min.BitLength > 0 && r.Min.At(r.Min.BitLength-1) != 1) ||
+			(r.Max.BitLength > 0 && r.Max.At(r.Max.BitLength-1) != 0) {
+			return IPAddressOrRange{}, nil, nil, errors.New("non-minimal IP address range encoding")
+		}
+
+		min, err := addressFromBitString(r.Min, n, false)
+		if err != nil {
+			return IPAddressOrRange{}, nil, nil, err
+		}
+
+		max, err := addressFromBitString(r.Max, n, true)
+		if err != nil {
+			return IPAddressOrRange{}, nil, nil, err
+		}
+
+		if bytes.Compare(min, max) > 0 {
+			return IPAddressOrRange{}, nil, nil, fmt.Errorf("invalid IP address range: %v-%v", net.IP(min), net.IP(max))
+		}
+
+		if _, ok := rangePrefixLength(min, max); ok {
+			return IPAddressOrRange{}, nil, nil, errors.New("IP address range not encoded as a prefix")
+		}
+
+		return IPAddressOrRange{Min: net.IP(min), Max: net.IP(max)}, min, max, nil
+	}
+
+	return IPAddressOrRange{}, nil, nil, fmt.Errorf("unexpected tag in IP address entry: %d", val.Tag)
+}
+
+// addressLength returns the length in bytes of an address in the specified
+// address family.
+func addressLength(afi int) (int, error) {
+	switch afi {
+	case AFIIPv4:
+		return net.IPv4len, nil
+
+	case AFIIPv6:
+		return net.IPv6len, nil
+	}
+
+	return 0, fmt.Errorf("unsupported address family: %d", afi)
+}
+
+// addressOfLength returns an IP address as a byte slice of the specified
+// length, or nil if the address cannot be represented at that length.
+func addressOfLength(ip net.IP, n int) []byte {
+	if n == net.IPv4len {
+		return ip.To4()
+	} else if len(ip) != n {
+		return nil
+	}
+
+	return ip
+}
+
+// addressBitString returns a bit string containing the leading bits of an
+// address.
+func addressBitString(ip []byte, length int) asn1.BitString {
+	var b = append([]byte{}, ip[:(length+7)/8]...)
+
+	if r := length % 8; r != 0 {
+		b[len(b)-1] &= byte(0xff << (8 - r))
+	}
+
+	return asn1.BitString{
+		Bytes:     b,
+		BitLength: length,
+	}
+}
+
+// addressFromBitString returns an address of the specified length with its
+// leading bits taken from a bit string, and with its remaining bits set to
+// one if fill is true, or zero otherwise.
+func addressFromBitString(bs asn1.BitString, n int, fill bool) ([]byte, error) {
+	if bs.BitLength > n*8 {
+		return nil, fmt.Errorf("IP address bit string too long: %d", bs.BitLength)
+	}
+
+	var ip = make([]byte, n)
+	copy(ip, bs.Bytes)
+
+	if fill {
+		for i := bs.BitLength; i < n*8; i++ {
+			ip[i/8] |= 0x80 >> (i % 8)
+		}
+	}
+
+	return ip, nil
+}
+
+// trailingBits returns the number of trailing bits in an address which are
+// equal to the specified bit.
+func trailingBits(ip []byte, bit int) int {
+	var count int
+
+	for i := len(ip) - 1; i >= 0; i-- {
+		var b = ip[i]
+		if bit == 1 {
+			b = ^b
+		}
+
+		if b != 0 {
+			return count + bits.TrailingZeros8(b)
+		}
+
+		count += 8
+	}
+
+	return count
+}
+
+// rangePrefixLength returns the prefix length and true if the IP address
+// range with the specified bounds covers exactly one prefix.
+func rangePrefixLength(min, max []byte) (int, bool) {
+	var ones int
+
+	for i := range min {
+		if x := min[i] ^ max[i]; x != 0 {
+			ones += bits.LeadingZeros8(x)
+			break
+		}
+
+		ones += 8
+	}
+
+	var hostBits = len(min)*8 - ones
+
+	return ones, trailingBits(min, 0) >= hostBits && trailingBits(max, 1) >= hostBits
+}
+
+// checkAddressOrder returns an error if an address range beginning at min
+// does not follow the address range ending at last, without overlapping or
+// being adjacent to it. A nil last indicates that there is no preceding
+// range.
+func checkAddressOrder(last, min []byte) error {
+	if last == nil {
+		return nil
+	}
+
+	var next = append([]byte{}, last...)
+
+	for i := len(next) - 1; i >= 0; i-- {
+		next[i]++
+		if next[i] != 0 {
+			break
+		} else if i == 0 {
+			return errors.New("IP addresses out of order")
+		}
+	}
+
+	if bytes.Compare(min, next) <= 0 {
+		return errors.New("IP addresses out of order, overlapping or adjacent")
+	}
+
+	return nil
+}
+
+// checkAddressFamilyOrder returns an error if an encoded address family
+// does not follow the previous encoded address family. A nil last indicates
+// that there is no previous address family.
+func checkAddressFamilyOrder(last, family []byte) error {
+	if last != nil && bytes.Compare(last, family) >= 0 {
+		return errors.New("IP address families out of order or duplicated")
+	}
+
+	return nil
+}
+
+// inheritChoiceRaw returns a raw value containing a NULL if inherit is true,
+// or a SEQUENCE OF the specified values otherwise, as for the resource
+// choices defined in RFC 3779 sections 2.2.3.4 and 3.2.3.2.
+func inheritChoiceRaw(inherit bool, vals []asn1.RawValue) (asn1.RawValue, error) {
+	if inherit {
+		return asn1.NullRawValue, nil
+	}
+
+	if len(vals) == 0 {
+		return asn1.RawValue{}, errors.New("no resources specified")
+	}
+
+	der, err := asn1.Marshal(vals)
+	if err != nil {
+		return asn1.RawValue{}, err
+	}
+
+	return asn1.RawValue{FullBytes: der}, nil
+}
+
+// inheritChoiceFromRaw parses a resource choice, and returns true if it is
+// a NULL, or the values in the SEQUENCE OF otherwise.
+func inheritChoiceFromRaw(val asn1.RawValue) (bool, []asn1.RawValue, error) {
+	if val.Class != asn1.ClassUniversal {
+		return false, nil, errors.New("invalid resource choice class")
+	}
+
+	switch {
+	case val.Tag == asn1.TagNull && !val.IsCompound:
+		if len(val.Bytes) != 0 {
+			return false, nil, errors.New("invalid NULL value")
+		}
+
+		return true, nil, nil
+
+	case val.Tag == asn1.TagSequence && val.IsCompound:
+		var vals []asn1.RawValue
+		if rest, err := asn1.Unmarshal(val.FullBytes, &vals); err != nil {
+			return false, nil, err
+		} else if len(rest) != 0 {
+			return false, nil, errors.New("trailing bytes")
+		}
+
+		if len(vals) == 0 {
+			return false, nil, errors.New("no resources specified")
+		}
+
+		return false, vals, nil
+	}
+
+	return false, nil, fmt.Errorf("unexpected tag in resource choice: %d", val.Tag)
+}
+
+// ipAddressFamilyJSON is the JSON representation of an IPAddressFamily.
+type ipAddressFamilyJSON struct {
+	AFI       int                `json:"afi"`
+	SAFI      int                `json:"safi,omitempty"`
+	Inherit   bool               `json:"inherit,omitempty"`
+	Addresses []IPAddressOrRange `json:"addresses,omitempty"`
+}
+
+// MarshalJSON returns the JSON encoding of a value. The family is encoded as
+// an object with an "afi" integer, an optional "safi" integer, and either
+// an "inherit" boolean or an "addresses" list. See
+// IPAddressOrRange.MarshalJSON.
+func (e IPAddressFamily) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ipAddressFamilyJSON(e))
+}
+
+// UnmarshalJSON parses a JSON-encoded value and stores the result in the
+// object.
+func (e *IPAddressFamily) UnmarshalJSON(b []byte) error {
+	var tmp ipAddressFamilyJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = IPAddressFamily(tmp)
+
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of a value. A prefix is encoded as a
+// string in CIDR notation, such as "192.0.2.0/24", and a range as a string
+// containing the lowest and highest addresses separated by a hyphen, such
+// as "192.0.2.1-192.0.2.6".
+func (e IPAddressOrRange) MarshalJSON() ([]byte, error) {
+	if e.Prefix != nil {
+		return json.Marshal(e.Prefix.String())
+	}
+
+	return json.Marshal(fmt.Sprintf("%v-%v", e.Min, e.Max))
+}
+
+// UnmarshalJSON parses a JSON-encoded value and stores the result in the
+// object.
+func (e *IPAddressOrRange) UnmarshalJSON(b []byte) error {
+	var s string
+	if err := json.Unmarshal(b, &s); err != nil {
+		return err
+	}
+
+	if i := strings.IndexByte(s, '-'); i != -1 {
+		var min, max = net.ParseIP(s[:i]), net.ParseIP(s[i+1:])
+		if min == nil || max == nil {
+			return fmt.Errorf("invalid IP address range: %q", s)
+		}
+
+		*e = IPAddressOrRange{Min: min, Max: max}
+
+		return nil
+	}
+
+	_, prefix, err := net.ParseCIDR(s)
+	if err != nil {
+		return err
+	}
+
+	*e = IPAddressOrRange{Prefix: prefix}
+
+	return nil
+}
@@ -0,0 +1,549 @@
+package asn1_test
+
+import (
+	"bytes"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+const testIPAddrBlocks = "302a302004020001301a0302000a300e030500c0a80001030500" +
+	"c0a803fe030401c0a8043006040200020500"
+
+func TestIPAddrBlocksMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		obj  pgasn1.IPAddrBlocks
+		want string
+		err  error
+	}{
+		{
+			name: "OK",
+			obj: pgasn1.IPAddrBlocks{
+				{
+					AFI: pgasn1.AFIIPv4,
+					Addresses: []pgasn1.IPAddressOrRange{
+						{Prefix: mustParseCIDR(t, "10.0.0.0/8")},
+						{Min: net.IP{192, 168, 0, 1}, Max: net.IP{192, 168, 3, 254}},
+						{Min: net.ParseIP("192.168.4.0"), Max: net.ParseIP("192.168.5.255")},
+					},
+				},
+				{AFI: pgasn1.AFIIPv6, Inherit: true},
+			},
+			want: testIPAddrBlocks,
+		},
+		{
+			name: "TrimmedRange",
+			obj: pgasn1.IPAddrBlocks{
+				{
+					AFI: pgasn1.AFIIPv4,
+					Addresses: []pgasn1.IPAddressOrRange{
+						{Min: net.IP{10, 0, 0, 0}, Max: net.IP{10, 0, 2, 255}},
+					},
+				},
+			},
+			want: "3014301204020001300c300a030201" + "0a030400" + "0a0002",
+		},
+		{
+			name: "SAFI",
+			obj: pgasn1.IPAddrBlocks{
+				{
+					AFI:  pgasn1.AFIIPv6,
+					SAFI: 1,
+					Addresses: []pgasn1.IPAddressOrRange{
+						{Prefix: mustParseCIDR(t, "2001:db8::/32")},
+					},
+				},
+			},
+			want: "3010300e0403000201300703050020010db8",
+		},
+		{
+			name: "Empty",
+			obj:  pgasn1.IPAddrBlocks{},
+			err:  errors.New("no families"),
+		},
+		{
+			name: "UnsupportedAFI",
+			obj:  pgasn1.IPAddrBlocks{{AFI: 3, Inherit: true}},
+			err:  errors.New("unsupported AFI"),
+		},
+		{
+			name: "BadSAFI",
+			obj:  pgasn1.IPAddrBlocks{{AFI: pgasn1.AFIIPv4, SAFI: 256, Inherit: true}},
+			err:  errors.New("bad SAFI"),
+		},
+		{
+			name: "NoAddresses",
+			obj:  pgasn1.IPAddrBlocks{{AFI: pgasn1.AFIIPv4}},
+			err:  errors.New("no addresses"),
+		},
+		{
+			name: "InheritAndAddresses",
+			obj: pgasn1.IPAddrBlocks{
+				{
+					AFI:       pgasn1.AFIIPv4,
+					Inherit:   true,
+					Addresses: []pgasn1.IPAddressOrRange{{Prefix: mustParseCIDR(t, "10.0.0.0/8")}},
+				},
+			},
+			err: errors.New("inherit and addresses"),
+		},
+		{
+			name: "FamiliesOutOfOrder",
+			obj: pgasn1.IPAddrBlocks{
+				{AFI: pgasn1.AFIIPv6, Inherit: true},
+				{AFI: pgasn1.AFIIPv4, Inherit: true},
+			},
+			err: errors.New("families out of order"),
+		},
+		{
+			name: "DuplicateFamily",
+			obj: pgasn1.IPAddrBlocks{
+				{AFI: pgasn1.AFIIPv4, Inherit: true},
+				{AFI: pgasn1.AFIIPv4, Inherit: true},
+			},
+			err: errors.New("duplicate family"),
+		},
+		{
+			name: "AddressesOutOfOrder",
+			obj: pgasn1.IPAddrBlocks{
+				{
+					AFI: pgasn1.AFIIPv4,
+					Addresses: []pgasn1.IPAddressOrRange{
+						{Prefix: mustParseCIDR(t, "192.168.0.0/16")},
+						{Prefix: mustParseCIDR(t, "10.0.0.0/8")},
+					},
+				},
+			},
+			err: errors.New("addresses out of order"),
+		},
+		{
+			name: "AddressesAdjacent",
+			obj: pgasn1.IPAddrBlocks{
+				{
+					AFI: pgasn1.AFIIPv4,
+					Addresses: []pgasn1.IPAddressOrRange{
+						{Prefix: mustParseCIDR(t, "10.0.0.0/8")},
+						{Prefix: mustParseCIDR(t, "11.0.0.0/8")},
+					},
+				},
+			},
+			err: errors.New("addresses adjacent"),
+		},
+		{
+			name: "AddressesOverlapping",
+			obj: pgasn1.IPAddrBlocks{
+				{
+					AFI: pgasn1.AFIIPv4,
+					Addresses: []pgasn1.IPAddressOrRange{
+						{Prefix: mustParseCIDR(t, "10.0.0.0/8")},
+						{Min: net.IP{10, 255, 0, 0}, Max: net.IP{12, 0, 0, 1}},
+					},
+				},
+			},
+			err: errors.New("addresses overlapping"),
+		},
+		{
+			name: "PrefixAndRange",
+			obj: pgasn1.IPAddrBlocks{
+				{
+					AFI: pgasn1.AFIIPv4,
+					Addresses: []pgasn1.IPAddressOrRange{
+						{
+							Prefix: mustParseCIDR(t, "10.0.0.0/8"),
+							Min:    net.IP{10, 0, 0, 0},
+							Max:    net.IP{10, 0, 0, 1},
+						},
+					},
+				},
+			},
+			err: errors.New("prefix and range"),
+		},
+		{
+			name: "MismatchedFamily",
+			obj: pgasn1.IPAddrBlocks{
+				{
+					AFI: pgasn1.AFIIPv4,
+					Addresses: []pgasn1.IPAddressOrRange{
+						{Prefix: mustParseCIDR(t, "2001:db8::/32")},
+					},
+				},
+			},
+			err: errors.New("mismatched family"),
+		},
+		{
+			name: "HostBitsSet",
+			obj: pgasn1.IPAddrBlocks{
+				{
+					AFI: pgasn1.AFIIPv4,
+					Addresses: []pgasn1.IPAddressOrRange{
+						{
+							Prefix: &net.IPNet{
+								IP:   net.IP{10, 0, 0, 1},
+								Mask: net.CIDRMask(8, 32),
+							},
+						},
+					},
+				},
+			},
+			err: errors.New("host bits set"),
+		},
+		{
+			name: "MinAfterMax",
+			obj: pgasn1.IPAddrBlocks{
+				{
+					AFI: pgasn1.AFIIPv4,
+					Addresses: []pgasn1.IPAddressOrRange{
+						{Min: net.IP{10, 0, 0, 2}, Max: net.IP{10, 0, 0, 1}},
+					},
+				},
+			},
+			err: errors.New("min after max"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.obj.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if want := mustDecodeHex(t, tc.want); !bytes.Equal(got, want) {
+				t.Errorf("got %x, want %x", got, want)
+			}
+		})
+	}
+}
+
+func TestIPAddrBlocksUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		der  string
+		want pgasn1.IPAddrBlocks
+		err  error
+	}{
+		{
+			name: "OK",
+			der:  testIPAddrBlocks,
+			want: pgasn1.IPAddrBlocks{
+				{
+					AFI: pgasn1.AFIIPv4,
+					Addresses: []pgasn1.IPAddressOrRange{
+						{Prefix: mustParseCIDR(t, "10.0.0.0/8")},
+						{Min: net.IP{192, 168, 0, 1}, Max: net.IP{192, 168, 3, 254}},
+						{Prefix: mustParseCIDR(t, "192.168.4.0/23")},
+					},
+				},
+				{AFI: pgasn1.AFIIPv6, Inherit: true},
+			},
+		},
+		{
+			name: "TrimmedRange",
+			der:  "3014301204020001300c300a030201" + "0a030400" + "0a0002",
+			want: pgasn1.IPAddrBlocks{
+				{
+					AFI: pgasn1.AFIIPv4,
+					Addresses: []pgasn1.IPAddressOrRange{
+						{Min: net.IP{10, 0, 0, 0}, Max: net.IP{10, 0, 2, 255}},
+					},
+				},
+			},
+		},
+		{
+			name: "SAFI",
+			der:  "3010300e0403000201300703050020010db8",
+			want: pgasn1.IPAddrBlocks{
+				{
+					AFI:  pgasn1.AFIIPv6,
+					SAFI: 1,
+					Addresses: []pgasn1.IPAddressOrRange{
+						{Prefix: mustParseCIDR(t, "2001:db8::/32")},
+					},
+				},
+			},
+		},
+		{
+			name: "Empty",
+			der:  "3000",
+			err:  errors.New("no families"),
+		},
+		{
+			name: "BadFamilyLength",
+			der:  "3007300504010105" + "00",
+			err:  errors.New("bad family length"),
+		},
+		{
+			name: "ZeroSAFI",
+			der:  "30093007040300010005" + "00",
+			err:  errors.New("zero SAFI"),
+		},
+		{
+			name: "UnsupportedAFI",
+			der:  "300830060402000305" + "00",
+			err:  errors.New("unsupported AFI"),
+		},
+		{
+			name: "NoAddresses",
+			der:  "30083006040200013000",
+			err:  errors.New("no addresses"),
+		},
+		{
+			name: "BadChoice",
+			der:  "300930070402000102" + "0100",
+			err:  errors.New("bad choice"),
+		},
+		{
+			name: "FamiliesOutOfOrder",
+			der:  "3010300604020002" + "0500" + "3006040200010500",
+			err:  errors.New("families out of order"),
+		},
+		{
+			name: "AddressesOutOfOrder",
+			der:  "3012301004020001300a" + "030300c0a8" + "0302000a",
+			err:  errors.New("addresses out of order"),
+		},
+		{
+			name: "PrefixTooLong",
+			der:  "3011300f040200013009030700" + "0a00000000" + "00",
+			err:  errors.New("prefix too long"),
+		},
+		{
+			name: "NonMinimalMin",
+			der:  "3014301204020001300c300a" + "030200" + "0a" + "030400" + "0a0002",
+			err:  errors.New("non-minimal min"),
+		},
+		{
+			name: "NonMinimalMax",
+			der:  "3015301304020001300d300b030201" + "0a" + "030500" + "0a0002ff",
+			err:  errors.New("non-minimal max"),
+		},
+		{
+			name: "RangeIsPrefix",
+			der:  "3012301004020001300a3008" + "0302000b" + "03020208",
+			err:  errors.New("range is prefix"),
+		},
+		{
+			name: "TrailingBytes",
+			der:  "30083006040200010500" + "00",
+			err:  errors.New("trailing bytes"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got pgasn1.IPAddrBlocks
+			err := got.Unmarshal(mustDecodeHex(t, tc.der))
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIPAddrBlocksContains(t *testing.T) {
+	t.Parallel()
+
+	var issuer = pgasn1.IPAddrBlocks{
+		{
+			AFI: pgasn1.AFIIPv4,
+			Addresses: []pgasn1.IPAddressOrRange{
+				{Prefix: mustParseCIDR(t, "10.0.0.0/8")},
+				{Min: net.IP{192, 168, 0, 1}, Max: net.IP{192, 168, 3, 254}},
+			},
+		},
+		{AFI: pgasn1.AFIIPv6, Inherit: true},
+	}
+
+	var testcases = []struct {
+		name  string
+		child pgasn1.IPAddrBlocks
+		want  bool
+	}{
+		{
+			name: "Contained",
+			child: pgasn1.IPAddrBlocks{
+				{
+					AFI: pgasn1.AFIIPv4,
+					Addresses: []pgasn1.IPAddressOrRange{
+						{Prefix: mustParseCIDR(t, "10.1.0.0/16")},
+						{Min: net.IP{192, 168, 0, 1}, Max: net.IP{192, 168, 0, 1}},
+						{Prefix: mustParseCIDR(t, "192.168.2.0/24")},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name: "Equal",
+			child: pgasn1.IPAddrBlocks{
+				{
+					AFI: pgasn1.AFIIPv4,
+					Addresses: []pgasn1.IPAddressOrRange{
+						{Prefix: mustParseCIDR(t, "10.0.0.0/8")},
+						{Min: net.IP{192, 168, 0, 1}, Max: net.IP{192, 168, 3, 254}},
+					},
+				},
+			},
+			want: true,
+		},
+		{
+			name:  "Empty",
+			child: pgasn1.IPAddrBlocks{},
+			want:  true,
+		},
+		{
+			name: "Inherited",
+			child: pgasn1.IPAddrBlocks{
+				{AFI: pgasn1.AFIIPv4, Inherit: true},
+				{AFI: pgasn1.AFIIPv6, Inherit: true},
+			},
+			want: true,
+		},
+		{
+			name: "OutsideRange",
+			child: pgasn1.IPAddrBlocks{
+				{
+					AFI: pgasn1.AFIIPv4,
+					Addresses: []pgasn1.IPAddressOrRange{
+						{Prefix: mustParseCIDR(t, "192.168.0.0/24")},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "SpanningRanges",
+			child: pgasn1.IPAddrBlocks{
+				{
+					AFI: pgasn1.AFIIPv4,
+					Addresses: []pgasn1.IPAddressOrRange{
+						{Min: net.IP{10, 255, 255, 255}, Max: net.IP{192, 168, 0, 1}},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "IssuerInherits",
+			child: pgasn1.IPAddrBlocks{
+				{
+					AFI: pgasn1.AFIIPv6,
+					Addresses: []pgasn1.IPAddressOrRange{
+						{Prefix: mustParseCIDR(t, "2001:db8::/32")},
+					},
+				},
+			},
+			want: false,
+		},
+		{
+			name: "MissingFamily",
+			child: pgasn1.IPAddrBlocks{
+				{AFI: pgasn1.AFIIPv4, SAFI: 1, Inherit: true},
+			},
+			want: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := issuer.Contains(tc.child); got != tc.want {
+				t.Errorf("got %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIPAddrBlocksResolve(t *testing.T) {
+	t.Parallel()
+
+	var issuer = pgasn1.IPAddrBlocks{
+		{
+			AFI: pgasn1.AFIIPv4,
+			Addresses: []pgasn1.IPAddressOrRange{
+				{Prefix: mustParseCIDR(t, "10.0.0.0/8")},
+			},
+		},
+	}
+
+	var testcases = []struct {
+		name  string
+		child pgasn1.IPAddrBlocks
+		want  pgasn1.IPAddrBlocks
+		err   error
+	}{
+		{
+			name: "OK",
+			child: pgasn1.IPAddrBlocks{
+				{AFI: pgasn1.AFIIPv4, Inherit: true},
+				{
+					AFI: pgasn1.AFIIPv6,
+					Addresses: []pgasn1.IPAddressOrRange{
+						{Prefix: mustParseCIDR(t, "2001:db8::/32")},
+					},
+				},
+			},
+			want: pgasn1.IPAddrBlocks{
+				{
+					AFI: pgasn1.AFIIPv4,
+					Addresses: []pgasn1.IPAddressOrRange{
+						{Prefix: mustParseCIDR(t, "10.0.0.0/8")},
+					},
+				},
+				{
+					AFI: pgasn1.AFIIPv6,
+					Addresses: []pgasn1.IPAddressOrRange{
+						{Prefix: mustParseCIDR(t, "2001:db8::/32")},
+					},
+				},
+			},
+		},
+		{
+			name: "MissingFamily",
+			child: pgasn1.IPAddrBlocks{
+				{AFI: pgasn1.AFIIPv6, Inherit: true},
+			},
+			err: errors.New("missing family"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.child.Resolve(issuer)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
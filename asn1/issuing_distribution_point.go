@@ -0,0 +1,215 @@
+package asn1
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+)
+
+// IssuingDistributionPoint represents an X509 CRL issuing distribution point
+// extension as defined in RFC 5280 section 5.2.5. At most one of the
+// OnlyContains fields may be set.
+//
+// id-ce-issuingDistributionPoint OBJECT IDENTIFIER ::= { id-ce 28 }
+//
+// IssuingDistributionPoint ::= SEQUENCE {
+//      distributionPoint          [0] DistributionPointName OPTIONAL,
+//      onlyContainsUserCerts      [1] BOOLEAN DEFAULT FALSE,
+//      onlyContainsCACerts        [2] BOOLEAN DEFAULT FALSE,
+//      onlySomeReasons            [3] ReasonFlags OPTIONAL,
+//      indirectCRL                [4] BOOLEAN DEFAULT FALSE,
+//      onlyContainsAttributeCerts [5] BOOLEAN DEFAULT FALSE }
+type IssuingDistributionPoint struct {
+	Name                       DistributionPointName
+	OnlyContainsUserCerts      bool
+	OnlyContainsCACerts        bool
+	OnlySomeReasons            ReasonFlags
+	IndirectCRL                bool
+	OnlyContainsAttributeCerts bool
+}
+
+// Issuing distribution point tag values.
+const (
+	idpTagDistributionPoint = iota
+	idpTagOnlyContainsUserCerts
+	idpTagOnlyContainsCACerts
+	idpTagOnlySomeReasons
+	idpTagIndirectCRL
+	idpTagOnlyContainsAttributeCerts
+)
+
+// Marshal returns the ASN.1 DER-encoding of a value.
+func (e IssuingDistributionPoint) Marshal() ([]byte, error) {
+	if err := e.check(); err != nil {
+		return nil, err
+	}
+
+	var vals []asn1.RawValue
+
+	if !e.Name.IsEmpty() {
+		val, err := e.Name.raw()
+		if err != nil {
+			return nil, err
+		}
+
+		der, err := asn1.Marshal(val)
+		if err != nil {
+			return nil, err
+		}
+
+		vals = append(vals, explicitTag(der, idpTagDistributionPoint))
+	}
+
+	if e.OnlyContainsUserCerts {
+		vals = append(vals, trueRaw(idpTagOnlyContainsUserCerts))
+	}
+
+	if e.OnlyContainsCACerts {
+		vals = append(vals, trueRaw(idpTagOnlyContainsCACerts))
+	}
+
+	if e.OnlySomeReasons != 0 {
+		val, err := e.OnlySomeReasons.raw(idpTagOnlySomeReasons)
+		if err != nil {
+			return nil, err
+		}
+
+		vals = append(vals, val)
+	}
+
+	if e.IndirectCRL {
+		vals = append(vals, trueRaw(idpTagIndirectCRL))
+	}
+
+	if e.OnlyContainsAttributeCerts {
+		vals = append(vals, trueRaw(idpTagOnlyContainsAttributeCerts))
+	}
+
+	return asn1.Marshal(vals)
+}
+
+// Unmarshal parses an DER-encoded ASN.1 data structure and stores the result
+// in the object.
+func (e *IssuingDistributionPoint) Unmarshal(b []byte) error {
+	var vals []asn1.RawValue
+
+	rest, err := asn1.Unmarshal(b, &vals)
+	if err != nil {
+		return err
+	} else if len(rest) != 0 {
+		return errors.New("trailing bytes")
+	}
+
+	var tmp IssuingDistributionPoint
+	var last = -1
+
+	for _, val := range vals {
+		if val.Class != asn1.ClassContextSpecific || val.Tag <= last {
+			return errors.New("unexpected element in issuing distribution point")
+		}
+		last = val.Tag
+
+		switch val.Tag {
+		case idpTagDistributionPoint:
+			err = tmp.Name.unmarshalExplicit(val)
+
+		case idpTagOnlyContainsUserCerts:
+			err = trueFromRaw(val)
+			tmp.OnlyContainsUserCerts = true
+
+		case idpTagOnlyContainsCACerts:
+			err = trueFromRaw(val)
+			tmp.OnlyContainsCACerts = true
+
+		case idpTagOnlySomeReasons:
+			tmp.OnlySomeReasons, err = reasonFlagsFromRaw(val)
+
+		case idpTagIndirectCRL:
+			err = trueFromRaw(val)
+			tmp.IndirectCRL = true
+
+		case idpTagOnlyContainsAttributeCerts:
+			err = trueFromRaw(val)
+			tmp.OnlyContainsAttributeCerts = true
+
+		default:
+			err = fmt.Errorf("unexpected tag in issuing distribution point: %d", val.Tag)
+		}
+
+		if err != nil {
+			return err
+		}
+	}
+
+	if err := tmp.check(); err != nil {
+		return err
+	}
+
+	*e = tmp
+
+	return nil
+}
+
+// check returns an error if the issuing distribution point is empty or sets
+// mutually exclusive flags.
+func (e IssuingDistributionPoint) check() error {
+
+	// Conforming CRL issuers MUST NOT issue CRLs where the DER encoding of
+	// the issuing distribution point is an empty sequence, and at most one
+	// of the onlyContains fields may be set to TRUE. See RFC 5280 section
+	// 5.2.5.
+	var count int
+
+	for _, only := range []bool{
+		e.OnlyContainsUserCerts,
+		e.OnlyContainsCACerts,
+		e.OnlyContainsAttributeCerts,
+	} {
+		if only {
+			count++
+		}
+	}
+
+	if count > 1 {
+		return errors.New("issuing distribution point sets more than one onlyContains field")
+	}
+
+	if count == 0 && e.Name.IsEmpty() && e.OnlySomeReasons == 0 && !e.IndirectCRL {
+		return errors.New("empty issuing distribution point")
+	}
+
+	return nil
+}
+
+// trueRaw returns an IMPLICIT tagged raw value containing a BOOLEAN TRUE.
+// Since the fields in which it is used have a default value of FALSE, DER
+// requires that FALSE is never encoded.
+func trueRaw(tag int) asn1.RawValue {
+	return asn1.RawValue{
+		Class: asn1.ClassContextSpecific,
+		Tag:   tag,
+		Bytes: []byte{0xff},
+	}
+}
+
+// trueFromRaw returns an error if an IMPLICIT tagged raw value does not
+// contain a DER-encoded BOOLEAN TRUE.
+func trueFromRaw(val asn1.RawValue) error {
+	der, err := universalTag(val, asn1.TagBoolean)
+	if err != nil {
+		return err
+	}
+
+	var b bool
+	if rest, err := asn1.Unmarshal(der, &b); err != nil {
+		return err
+	} else if len(rest) != 0 {
+		return errors.New("trailing bytes")
+	}
+
+	if !b {
+		return errors.New("default FALSE value explicitly encoded")
+	}
+
+	return nil
+}
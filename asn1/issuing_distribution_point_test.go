@@ -0,0 +1,193 @@
+package asn1_test
+
+import (
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+func TestIssuingDistributionPointMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		obj  pgasn1.IssuingDistributionPoint
+		want []byte
+		err  error
+	}{
+		{
+			name: "FullNameOnlyCACerts",
+			obj: pgasn1.IssuingDistributionPoint{
+				Name: pgasn1.DistributionPointName{
+					FullName: pgasn1.GeneralNames{
+						{URI: mustParseURI(t, "http://c/x")},
+					},
+				},
+				OnlyContainsCACerts: true,
+			},
+			want: []byte{asn1.TagSequence | bit6, 19,
+				asn1.ClassContextSpecific<<6 | bit6 | 0, 14,
+				asn1.ClassContextSpecific<<6 | bit6 | 0, 12,
+				nameTagURI | asn1.ClassContextSpecific<<6, 10,
+				'h', 't', 't', 'p', ':', '/', '/', 'c', '/', 'x',
+				asn1.ClassContextSpecific<<6 | 2, 1, 0xff,
+			},
+		},
+		{
+			name: "ReasonsAndIndirectCRL",
+			obj: pgasn1.IssuingDistributionPoint{
+				OnlySomeReasons: pgasn1.ReasonFlagKeyCompromise,
+				IndirectCRL:     true,
+			},
+			want: []byte{asn1.TagSequence | bit6, 7,
+				asn1.ClassContextSpecific<<6 | 3, 2, 6, 0x40,
+				asn1.ClassContextSpecific<<6 | 4, 1, 0xff,
+			},
+		},
+		{
+			name: "OnlyContainsAttributeCerts",
+			obj: pgasn1.IssuingDistributionPoint{
+				OnlyContainsAttributeCerts: true,
+			},
+			want: []byte{asn1.TagSequence | bit6, 3,
+				asn1.ClassContextSpecific<<6 | 5, 1, 0xff,
+			},
+		},
+		{
+			name: "Empty",
+			obj:  pgasn1.IssuingDistributionPoint{},
+			err:  errors.New("empty issuing distribution point"),
+		},
+		{
+			name: "MutuallyExclusive",
+			obj: pgasn1.IssuingDistributionPoint{
+				OnlyContainsUserCerts: true,
+				OnlyContainsCACerts:   true,
+			},
+			err: errors.New("mutually exclusive"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.obj.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIssuingDistributionPointUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name  string
+		input []byte
+		want  pgasn1.IssuingDistributionPoint
+		err   error
+	}{
+		{
+			name: "FullNameOnlyUserCerts",
+			input: []byte{asn1.TagSequence | bit6, 19,
+				asn1.ClassContextSpecific<<6 | bit6 | 0, 14,
+				asn1.ClassContextSpecific<<6 | bit6 | 0, 12,
+				nameTagURI | asn1.ClassContextSpecific<<6, 10,
+				'h', 't', 't', 'p', ':', '/', '/', 'c', '/', 'x',
+				asn1.ClassContextSpecific<<6 | 1, 1, 0xff,
+			},
+			want: pgasn1.IssuingDistributionPoint{
+				Name: pgasn1.DistributionPointName{
+					FullName: pgasn1.GeneralNames{
+						{URI: mustParseURI(t, "http://c/x")},
+					},
+				},
+				OnlyContainsUserCerts: true,
+			},
+		},
+		{
+			name: "ReasonsAndIndirectCRL",
+			input: []byte{asn1.TagSequence | bit6, 7,
+				asn1.ClassContextSpecific<<6 | 3, 2, 6, 0x40,
+				asn1.ClassContextSpecific<<6 | 4, 1, 0xff,
+			},
+			want: pgasn1.IssuingDistributionPoint{
+				OnlySomeReasons: pgasn1.ReasonFlagKeyCompromise,
+				IndirectCRL:     true,
+			},
+		},
+		{
+			name:  "Empty",
+			input: []byte{asn1.TagSequence | bit6, 0},
+			err:   errors.New("empty issuing distribution point"),
+		},
+		{
+			name: "MutuallyExclusive",
+			input: []byte{asn1.TagSequence | bit6, 6,
+				asn1.ClassContextSpecific<<6 | 2, 1, 0xff,
+				asn1.ClassContextSpecific<<6 | 5, 1, 0xff,
+			},
+			err: errors.New("mutually exclusive"),
+		},
+		{
+			name: "ExplicitFalse",
+			input: []byte{asn1.TagSequence | bit6, 3,
+				asn1.ClassContextSpecific<<6 | 4, 1, 0x00,
+			},
+			err: errors.New("default value encoded"),
+		},
+		{
+			name: "OutOfOrder",
+			input: []byte{asn1.TagSequence | bit6, 6,
+				asn1.ClassContextSpecific<<6 | 4, 1, 0xff,
+				asn1.ClassContextSpecific<<6 | 1, 1, 0xff,
+			},
+			err: errors.New("out of order"),
+		},
+		{
+			name: "UnknownTag",
+			input: []byte{asn1.TagSequence | bit6, 3,
+				asn1.ClassContextSpecific<<6 | 6, 1, 0xff,
+			},
+			err: errors.New("unknown tag"),
+		},
+		{
+			name: "TrailingBytes",
+			input: []byte{asn1.TagSequence | bit6, 3,
+				asn1.ClassContextSpecific<<6 | 4, 1, 0xff, 0x00,
+			},
+			err: errors.New("trailing bytes"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got pgasn1.IssuingDistributionPoint
+
+			err := got.Unmarshal(tc.input)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
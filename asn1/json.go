@@ -0,0 +1,179 @@
+package asn1
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/hex"
+	"errors"
+)
+
+// attributeJSON is the JSON representation of an attribute type and value
+// in a distinguished name. String values are represented as strings, and
+// any other value as the hex encoding of its DER-encoding.
+type attributeJSON struct {
+	Type  string  `json:"type"`
+	Value *string `json:"value,omitempty"`
+	DER   string  `json:"der,omitempty"`
+}
+
+// nameJSON is the JSON representation of a distinguished name, as a list
+// of relative distinguished names.
+type nameJSON [][]attributeJSON
+
+// oidStrings returns the names of a list of OIDs. See OIDName.
+func oidStrings(oids []asn1.ObjectIdentifier) []string {
+	var names []string
+
+	for _, oid := range oids {
+		names = append(names, OIDName(oid))
+	}
+
+	return names
+}
+
+// parseOIDNames parses a list of OID names. See ParseOIDName.
+func parseOIDNames(names []string) ([]asn1.ObjectIdentifier, error) {
+	var oids []asn1.ObjectIdentifier
+
+	for _, name := range names {
+		oid, err := ParseOIDName(name)
+		if err != nil {
+			return nil, err
+		}
+
+		oids = append(oids, oid)
+	}
+
+	return oids, nil
+}
+
+// rawToHex returns the hex encoding of the DER-encoding of a raw value, or
+// an empty string if the raw value is absent.
+func rawToHex(val asn1.RawValue) (string, error) {
+	if len(val.FullBytes) == 0 && len(val.Bytes) == 0 && val.Tag == 0 {
+		return "", nil
+	}
+
+	der, err := asn1.Marshal(val)
+	if err != nil {
+		return "", err
+	}
+
+	return hex.EncodeToString(der), nil
+}
+
+// rawFromHex parses a raw value from the hex encoding of its DER-encoding.
+// An empty string results in an absent raw value.
+func rawFromHex(s string) (asn1.RawValue, error) {
+	if s == "" {
+		return asn1.RawValue{}, nil
+	}
+
+	der, err := hex.DecodeString(s)
+	if err != nil {
+		return asn1.RawValue{}, err
+	}
+
+	var val asn1.RawValue
+	if rest, err := asn1.Unmarshal(der, &val); err != nil {
+		return asn1.RawValue{}, err
+	} else if len(rest) != 0 {
+		return asn1.RawValue{}, errors.New("trailing bytes")
+	}
+
+	return val, nil
+}
+
+// rdnToJSON converts a relative distinguished name to its JSON
+// representation.
+func rdnToJSON(rdn pkix.RelativeDistinguishedNameSET) ([]attributeJSON, error) {
+	var attrs []attributeJSON
+
+	for _, atv := range rdn {
+		var attr = attributeJSON{Type: OIDName(atv.Type)}
+
+		if s, ok := atv.Value.(string); ok {
+			attr.Value = &s
+		} else {
+			der, err := asn1.Marshal(atv.Value)
+			if err != nil {
+				return nil, err
+			}
+
+			attr.DER = hex.EncodeToString(der)
+		}
+
+		attrs = append(attrs, attr)
+	}
+
+	return attrs, nil
+}
+
+// rdnFromJSON converts the JSON representation of a relative distinguished
+// name.
+func rdnFromJSON(attrs []attributeJSON) (pkix.RelativeDistinguishedNameSET, error) {
+	var rdn pkix.RelativeDistinguishedNameSET
+
+	for _, attr := range attrs {
+		oid, err := ParseOIDName(attr.Type)
+		if err != nil {
+			return nil, err
+		}
+
+		var atv = pkix.AttributeTypeAndValue{Type: oid}
+
+		switch {
+		case attr.Value != nil && attr.DER != "":
+			return nil, errors.New("attribute has both a value and a DER-encoding")
+
+		case attr.Value != nil:
+			atv.Value = *attr.Value
+
+		default:
+			val, err := rawFromHex(attr.DER)
+			if err != nil {
+				return nil, err
+			} else if len(val.FullBytes) == 0 {
+				return nil, errors.New("attribute has no value")
+			}
+
+			atv.Value = val
+		}
+
+		rdn = append(rdn, atv)
+	}
+
+	return rdn, nil
+}
+
+// nameToJSON converts a distinguished name to its JSON representation.
+func nameToJSON(name pkix.RDNSequence) (nameJSON, error) {
+	var tmp = nameJSON{}
+
+	for _, rdn := range name {
+		attrs, err := rdnToJSON(rdn)
+		if err != nil {
+			return nil, err
+		}
+
+		tmp = append(tmp, attrs)
+	}
+
+	return tmp, nil
+}
+
+// nameFromJSON converts the JSON representation of a distinguished name.
+func nameFromJSON(name nameJSON) (pkix.RDNSequence, error) {
+	var tmp = pkix.RDNSequence{}
+
+	for _, attrs := range name {
+		rdn, err := rdnFromJSON(attrs)
+		if err != nil {
+			return nil, err
+		}
+
+		tmp = append(tmp, rdn)
+	}
+
+	return tmp, nil
+}
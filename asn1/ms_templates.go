@@ -0,0 +1,155 @@
+package asn1
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+)
+
+// CertificateTemplateName represents a Microsoft version 1 certificate
+// template name extension, which is a BMPString containing the name of the
+// Active Directory Certificate Services template used to issue the
+// certificate.
+//
+// szOID_ENROLL_CERTTYPE_EXTENSION OBJECT IDENTIFIER ::=
+//      { 1 3 6 1 4 1 311 20 2 }
+type CertificateTemplateName string
+
+// CertificateTemplate represents a Microsoft version 2 certificate template
+// extension. A MinorVersion of -1 indicates that the minor version is
+// absent.
+//
+// szOID_CERTIFICATE_TEMPLATE OBJECT IDENTIFIER ::=
+//      { 1 3 6 1 4 1 311 21 7 }
+//
+// CertificateTemplate ::= SEQUENCE {
+//      templateID              OBJECT IDENTIFIER,
+//      templateMajorVersion    INTEGER (0..4294967295),
+//      templateMinorVersion    INTEGER (0..4294967295) OPTIONAL }
+type CertificateTemplate struct {
+	ID           asn1.ObjectIdentifier
+	MajorVersion int64
+	MinorVersion int64 `asn1:"optional,default:-1"`
+}
+
+// certificateTemplate is the intermediate representation of a
+// CertificateTemplate, used when unmarshalling to distinguish an absent
+// minor version from an explicitly encoded negative one.
+type certificateTemplate struct {
+	ID           asn1.ObjectIdentifier
+	MajorVersion int64
+	MinorVersion asn1.RawValue `asn1:"optional"`
+}
+
+// maxTemplateVersion is the maximum template major or minor version.
+const maxTemplateVersion = 1<<32 - 1
+
+// Marshal returns the ASN.1 DER-encoding of a value.
+func (e CertificateTemplateName) Marshal() ([]byte, error) {
+	if e == "" {
+		return nil, errors.New("no certificate template name specified")
+	}
+
+	b, err := encodeBMPString(string(e))
+	if err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(asn1.RawValue{
+		Class: asn1.ClassUniversal,
+		Tag:   TagBMPString,
+		Bytes: b,
+	})
+}
+
+// Unmarshal parses an DER-encoded ASN.1 data structure and stores the result
+// in the object.
+func (e *CertificateTemplateName) Unmarshal(b []byte) error {
+	var val asn1.RawValue
+
+	rest, err := asn1.Unmarshal(b, &val)
+	if err != nil {
+		return err
+	} else if len(rest) != 0 {
+		return errors.New("trailing bytes")
+	}
+
+	if val.Class != asn1.ClassUniversal || val.Tag != TagBMPString || val.IsCompound {
+		return errors.New("certificate template name is not a BMPString")
+	}
+
+	s, err := DecodeBMPString(val.Bytes)
+	if err != nil {
+		return err
+	}
+
+	*e = CertificateTemplateName(s)
+
+	return nil
+}
+
+// Marshal returns the ASN.1 DER-encoding of a value.
+func (e CertificateTemplate) Marshal() ([]byte, error) {
+	if err := e.check(); err != nil {
+		return nil, err
+	}
+
+	return asn1.Marshal(e)
+}
+
+// Unmarshal parses an DER-encoded ASN.1 data structure and stores the result
+// in the object.
+func (e *CertificateTemplate) Unmarshal(b []byte) error {
+	var raw certificateTemplate
+
+	rest, err := asn1.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	} else if len(rest) != 0 {
+		return errors.New("trailing bytes")
+	}
+
+	var tmp = CertificateTemplate{
+		ID:           raw.ID,
+		MajorVersion: raw.MajorVersion,
+		MinorVersion: -1,
+	}
+
+	if len(raw.MinorVersion.FullBytes) != 0 {
+		if rest, err := asn1.Unmarshal(raw.MinorVersion.FullBytes, &tmp.MinorVersion); err != nil {
+			return err
+		} else if len(rest) != 0 {
+			return errors.New("trailing bytes")
+		}
+
+		if tmp.MinorVersion < 0 {
+			return fmt.Errorf("invalid template minor version: %d", tmp.MinorVersion)
+		}
+	}
+
+	if err := tmp.check(); err != nil {
+		return err
+	}
+
+	*e = tmp
+
+	return nil
+}
+
+// check returns an error if the template identifier is missing or if either
+// version is out of range.
+func (e CertificateTemplate) check() error {
+	if len(e.ID) == 0 {
+		return errors.New("no certificate template identifier specified")
+	}
+
+	if e.MajorVersion < 0 || e.MajorVersion > maxTemplateVersion {
+		return fmt.Errorf("invalid template major version: %d", e.MajorVersion)
+	}
+
+	if e.MinorVersion < -1 || e.MinorVersion > maxTemplateVersion {
+		return fmt.Errorf("invalid template minor version: %d", e.MinorVersion)
+	}
+
+	return nil
+}
@@ -0,0 +1,247 @@
+package asn1_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+func TestCertificateTemplateNameMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		obj  pgasn1.CertificateTemplateName
+		want []byte
+		err  error
+	}{
+		{
+			name: "OK",
+			obj:  "User",
+			want: []byte{pgasn1.TagBMPString, 8, 0, 'U', 0, 's', 0, 'e', 0, 'r'},
+		},
+		{
+			name: "Empty",
+			obj:  "",
+			err:  errors.New("empty name"),
+		},
+		{
+			name: "NotBMP",
+			obj:  "\U0001F600",
+			err:  errors.New("not BMP"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.obj.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !bytes.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCertificateTemplateNameUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name  string
+		input []byte
+		want  pgasn1.CertificateTemplateName
+		err   error
+	}{
+		{
+			name:  "OK",
+			input: []byte{pgasn1.TagBMPString, 8, 0, 'U', 0, 's', 0, 'e', 0, 'r'},
+			want:  "User",
+		},
+		{
+			name:  "NotBMPString",
+			input: []byte{asn1.TagUTF8String, 4, 'U', 's', 'e', 'r'},
+			err:   errors.New("not BMPString"),
+		},
+		{
+			name:  "OddLength",
+			input: []byte{pgasn1.TagBMPString, 3, 0, 'U', 0},
+			err:   errors.New("odd length"),
+		},
+		{
+			name:  "TrailingBytes",
+			input: []byte{pgasn1.TagBMPString, 2, 0, 'U', 0},
+			err:   errors.New("trailing bytes"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got pgasn1.CertificateTemplateName
+
+			err := got.Unmarshal(tc.input)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCertificateTemplateMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		obj  pgasn1.CertificateTemplate
+		want []byte
+		err  error
+	}{
+		{
+			name: "MinorVersion",
+			obj: pgasn1.CertificateTemplate{
+				ID:           asn1.ObjectIdentifier{1, 2, 3},
+				MajorVersion: 100,
+				MinorVersion: 4,
+			},
+			want: []byte{asn1.TagSequence | bit6, 10,
+				asn1.TagOID, 2, 0x2a, 0x03,
+				asn1.TagInteger, 1, 100,
+				asn1.TagInteger, 1, 4,
+			},
+		},
+		{
+			name: "NoMinorVersion",
+			obj: pgasn1.CertificateTemplate{
+				ID:           asn1.ObjectIdentifier{1, 2, 3},
+				MajorVersion: 100,
+				MinorVersion: -1,
+			},
+			want: []byte{asn1.TagSequence | bit6, 7,
+				asn1.TagOID, 2, 0x2a, 0x03,
+				asn1.TagInteger, 1, 100,
+			},
+		},
+		{
+			name: "NoID",
+			obj:  pgasn1.CertificateTemplate{MajorVersion: 1},
+			err:  errors.New("no ID"),
+		},
+		{
+			name: "MajorVersionOutOfRange",
+			obj: pgasn1.CertificateTemplate{
+				ID:           asn1.ObjectIdentifier{1, 2, 3},
+				MajorVersion: 1 << 32,
+			},
+			err: errors.New("major version out of range"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.obj.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !bytes.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCertificateTemplateUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name  string
+		input []byte
+		want  pgasn1.CertificateTemplate
+		err   error
+	}{
+		{
+			name: "MinorVersion",
+			input: []byte{asn1.TagSequence | bit6, 10,
+				asn1.TagOID, 2, 0x2a, 0x03,
+				asn1.TagInteger, 1, 100,
+				asn1.TagInteger, 1, 0,
+			},
+			want: pgasn1.CertificateTemplate{
+				ID:           asn1.ObjectIdentifier{1, 2, 3},
+				MajorVersion: 100,
+				MinorVersion: 0,
+			},
+		},
+		{
+			name: "NoMinorVersion",
+			input: []byte{asn1.TagSequence | bit6, 7,
+				asn1.TagOID, 2, 0x2a, 0x03,
+				asn1.TagInteger, 1, 100,
+			},
+			want: pgasn1.CertificateTemplate{
+				ID:           asn1.ObjectIdentifier{1, 2, 3},
+				MajorVersion: 100,
+				MinorVersion: -1,
+			},
+		},
+		{
+			name: "NegativeMinorVersion",
+			input: []byte{asn1.TagSequence | bit6, 10,
+				asn1.TagOID, 2, 0x2a, 0x03,
+				asn1.TagInteger, 1, 100,
+				asn1.TagInteger, 1, 0xff,
+			},
+			err: errors.New("negative minor version"),
+		},
+		{
+			name: "TrailingBytes",
+			input: []byte{asn1.TagSequence | bit6, 7,
+				asn1.TagOID, 2, 0x2a, 0x03,
+				asn1.TagInteger, 1, 100, 0,
+			},
+			err: errors.New("trailing bytes"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got pgasn1.CertificateTemplate
+
+			err := got.Unmarshal(tc.input)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
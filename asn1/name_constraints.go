@@ -0,0 +1,425 @@
+package asn1
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"net"
+	"strings"
+)
+
+// NameConstraints represents an X509 name constraints extension as defined
+// in RFC 5280 section 4.2.1.10.
+//
+// id-ce-nameConstraints OBJECT IDENTIFIER ::=  { id-ce 30 }
+//
+// NameConstraints ::= SEQUENCE {
+//      permittedSubtrees       [0]     GeneralSubtrees OPTIONAL,
+//      excludedSubtrees        [1]     GeneralSubtrees OPTIONAL }
+//
+// GeneralSubtrees ::= SEQUENCE SIZE (1..MAX) OF GeneralSubtree
+type NameConstraints struct {
+	Permitted []GeneralSubtree
+	Excluded  []GeneralSubtree
+}
+
+// GeneralSubtree represents a general subtree as defined in RFC 5280 section
+// 4.2.1.10. Exactly one of the name fields should be set. RFC 5280 does not
+// use the minimum and maximum fields with any name form, so the minimum is
+// always zero and the maximum is always absent.
+//
+// GeneralSubtree ::= SEQUENCE {
+//      base                    GeneralName,
+//      minimum         [0]     BaseDistance DEFAULT 0,
+//      maximum         [1]     BaseDistance OPTIONAL }
+//
+// BaseDistance ::= INTEGER (0..MAX)
+type GeneralSubtree struct {
+	DNSName       string
+	DirectoryName pkix.RDNSequence
+	EmailAddress  string
+	IPRange       *net.IPNet
+	URIDomain     string
+}
+
+// generalSubtree is the intermediate representation of a GeneralSubtree.
+type generalSubtree struct {
+	Base    asn1.RawValue
+	Minimum int `asn1:"optional,tag:0"`
+	Maximum int `asn1:"optional,tag:1,default:-1"`
+}
+
+// nameConstraints is the intermediate representation of a NameConstraints.
+type nameConstraints struct {
+	Permitted []generalSubtree `asn1:"optional,tag:0"`
+	Excluded  []generalSubtree `asn1:"optional,tag:1"`
+}
+
+// Marshal returns the ASN.1 DER-encoding of a value.
+func (e NameConstraints) Marshal() ([]byte, error) {
+
+	// Conforming CAs MUST NOT issue certificates where name constraints is
+	// an empty sequence. See RFC 5280 section 4.2.1.10.
+	if len(e.Permitted) == 0 && len(e.Excluded) == 0 {
+		return nil, errors.New("no subtrees specified")
+	}
+
+	var tmp nameConstraints
+
+	for _, subtree := range e.Permitted {
+		raw, err := subtree.raw()
+		if err != nil {
+			return nil, err
+		}
+
+		tmp.Permitted = append(tmp.Permitted, raw)
+	}
+
+	for _, subtree := range e.Excluded {
+		raw, err := subtree.raw()
+		if err != nil {
+			return nil, err
+		}
+
+		tmp.Excluded = append(tmp.Excluded, raw)
+	}
+
+	return asn1.Marshal(tmp)
+}
+
+// Unmarshal parses an DER-encoded ASN.1 data structure and stores the result
+// in the object.
+func (e *NameConstraints) Unmarshal(b []byte) error {
+	var raw nameConstraints
+
+	rest, err := asn1.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	} else if len(rest) != 0 {
+		return errors.New("trailing bytes")
+	}
+
+	var tmp NameConstraints
+
+	for _, r := range raw.Permitted {
+		subtree, err := subtreeFromRaw(r)
+		if err != nil {
+			return err
+		}
+
+		tmp.Permitted = append(tmp.Permitted, subtree)
+	}
+
+	for _, r := range raw.Excluded {
+		subtree, err := subtreeFromRaw(r)
+		if err != nil {
+			return err
+		}
+
+		tmp.Excluded = append(tmp.Excluded, subtree)
+	}
+
+	*e = tmp
+
+	return nil
+}
+
+// raw converts a GeneralSubtree to its intermediate representation.
+func (e GeneralSubtree) raw() (generalSubtree, error) {
+	var count int
+	var base asn1.RawValue
+	var err error
+
+	if e.DNSName != "" {
+		count++
+		base, err = marshalDNSConstraint(e.DNSName)
+	}
+
+	if e.DirectoryName != nil {
+		count++
+		base, err = marshalDirectoryName(e.DirectoryName)
+	}
+
+	if e.EmailAddress != "" {
+		count++
+		base, err = marshalEmailConstraint(e.EmailAddress)
+	}
+
+	if e.IPRange != nil {
+		count++
+		base, err = marshalIPConstraint(e.IPRange)
+	}
+
+	if e.URIDomain != "" {
+		count++
+		base, err = marshalURIConstraint(e.URIDomain)
+	}
+
+	if count != 1 {
+		return generalSubtree{}, fmt.Errorf("subtree must contain exactly one name, found %d", count)
+	} else if err != nil {
+		return generalSubtree{}, err
+	}
+
+	return generalSubtree{
+		Base:    base,
+		Maximum: -1,
+	}, nil
+}
+
+// subtreeFromRaw converts the intermediate representation of a
+// GeneralSubtree.
+func subtreeFromRaw(raw generalSubtree) (GeneralSubtree, error) {
+	// Within the RFC 5280 profile, the minimum MUST be zero and the maximum
+	// MUST be absent. See RFC 5280 section 4.2.1.10.
+	if raw.Minimum != 0 {
+		return GeneralSubtree{}, fmt.Errorf("invalid minimum base distance: %d", raw.Minimum)
+	}
+
+	if raw.Maximum != -1 {
+		return GeneralSubtree{}, fmt.Errorf("invalid maximum base distance: %d", raw.Maximum)
+	}
+
+	var subtree GeneralSubtree
+
+	if raw.Base.Class != asn1.ClassContextSpecific {
+		return GeneralSubtree{}, errors.New("invalid general name class")
+	}
+
+	var err error
+
+	switch raw.Base.Tag {
+	case nameTagDNSName:
+		subtree.DNSName = string(raw.Base.Bytes)
+		_, err = marshalDNSConstraint(subtree.DNSName)
+
+	case nameTagRFC822Name:
+		subtree.EmailAddress = string(raw.Base.Bytes)
+		_, err = marshalEmailConstraint(subtree.EmailAddress)
+
+	case nameTagURI:
+		subtree.URIDomain = string(raw.Base.Bytes)
+		_, err = marshalURIConstraint(subtree.URIDomain)
+
+	case nameTagIPAddress:
+		subtree.IPRange, err = unmarshalIPConstraint(raw.Base)
+
+	case nameTagDirectoryName:
+		subtree.DirectoryName, err = unmarshalDirectoryName(raw.Base)
+
+	default:
+		err = fmt.Errorf("unsupported name constraint type: %d", raw.Base.Tag)
+	}
+
+	if err != nil {
+		return GeneralSubtree{}, err
+	}
+
+	return subtree, nil
+}
+
+// marshalDNSConstraint returns a GeneralName raw value containing a DNS name
+// constraint. A leading period is permitted.
+func marshalDNSConstraint(name string) (asn1.RawValue, error) {
+	if _, err := marshalDNSName(strings.TrimPrefix(name, ".")); err != nil {
+		return asn1.RawValue{}, err
+	}
+
+	return asn1.RawValue{
+		Tag:   nameTagDNSName,
+		Class: asn1.ClassContextSpecific,
+		Bytes: []byte(name),
+	}, nil
+}
+
+// marshalEmailConstraint returns a GeneralName raw value containing an email
+// address constraint, which may be a mailbox, a host, or a domain with a
+// leading period. See RFC 5280 section 4.2.1.10.
+func marshalEmailConstraint(addr string) (asn1.RawValue, error) {
+	if strings.Contains(addr, "@") {
+		return marshalEmailAddress(addr)
+	}
+
+	if err := isIA5String(addr); err != nil {
+		return asn1.RawValue{}, err
+	}
+
+	if _, ok := domainToReverseLabels(strings.TrimPrefix(addr, ".")); !ok {
+		return asn1.RawValue{}, fmt.Errorf("couldn't parse %q as email constraint", addr)
+	}
+
+	return asn1.RawValue{
+		Tag:   nameTagRFC822Name,
+		Class: asn1.ClassContextSpecific,
+		Bytes: []byte(addr),
+	}, nil
+}
+
+// marshalURIConstraint returns a GeneralName raw value containing a URI
+// constraint, which is a host or a domain with a leading period. See RFC 5280
+// section 4.2.1.10.
+func marshalURIConstraint(domain string) (asn1.RawValue, error) {
+	if err := isIA5String(domain); err != nil {
+		return asn1.RawValue{}, err
+	}
+
+	if _, ok := domainToReverseLabels(strings.TrimPrefix(domain, ".")); !ok {
+		return asn1.RawValue{}, fmt.Errorf("couldn't parse %q as URI constraint", domain)
+	}
+
+	return asn1.RawValue{
+		Tag:   nameTagURI,
+		Class: asn1.ClassContextSpecific,
+		Bytes: []byte(domain),
+	}, nil
+}
+
+// marshalIPConstraint returns a GeneralName raw value containing an IP
+// address and subnet mask.
+func marshalIPConstraint(ipnet *net.IPNet) (asn1.RawValue, error) {
+	var ip = ipnet.IP
+
+	switch len(ipnet.Mask) {
+	case net.IPv4len:
+		ip = ip.To4()
+
+	case net.IPv6len:
+		if len(ip) != net.IPv6len {
+			ip = nil
+		}
+
+	default:
+		return asn1.RawValue{}, fmt.Errorf("invalid IP mask length: %d", len(ipnet.Mask))
+	}
+
+	if ip == nil {
+		return asn1.RawValue{}, fmt.Errorf("IP address %v does not match mask length", ipnet.IP)
+	}
+
+	if err := checkIPAndMask(ip, ipnet.Mask); err != nil {
+		return asn1.RawValue{}, err
+	}
+
+	return asn1.RawValue{
+		Tag:   nameTagIPAddress,
+		Class: asn1.ClassContextSpecific,
+		Bytes: append(append([]byte{}, ip...), ipnet.Mask...),
+	}, nil
+}
+
+// unmarshalIPConstraint parses an IP address and subnet mask from a
+// GeneralName raw value.
+func unmarshalIPConstraint(val asn1.RawValue) (*net.IPNet, error) {
+	var n int
+
+	switch len(val.Bytes) {
+	case 2 * net.IPv4len:
+		n = net.IPv4len
+
+	case 2 * net.IPv6len:
+		n = net.IPv6len
+
+	default:
+		return nil, fmt.Errorf("invalid IP constraint length: %d", len(val.Bytes))
+	}
+
+	var ipnet = &net.IPNet{
+		IP:   net.IP(append([]byte{}, val.Bytes[:n]...)),
+		Mask: net.IPMask(append([]byte{}, val.Bytes[n:]...)),
+	}
+
+	if err := checkIPAndMask(ipnet.IP, ipnet.Mask); err != nil {
+		return nil, err
+	}
+
+	return ipnet, nil
+}
+
+// checkIPAndMask returns an error if a subnet mask is not a contiguous
+// sequence of leading one bits, or if the address has bits set outside of
+// the mask.
+func checkIPAndMask(ip net.IP, mask net.IPMask) error {
+	if ones, bits := mask.Size(); ones == 0 && bits == 0 {
+		return fmt.Errorf("non-canonical IP mask: %v", mask)
+	}
+
+	if !bytes.Equal(ip.Mask(mask), ip) {
+		return fmt.Errorf("IP address %v has bits set outside of mask %v", ip, mask)
+	}
+
+	return nil
+}
+
+// generalSubtreeJSON is the JSON representation of a GeneralSubtree.
+type generalSubtreeJSON struct {
+	DNSName       string    `json:"dnsName,omitempty"`
+	DirectoryName *nameJSON `json:"directoryName,omitempty"`
+	EmailAddress  string    `json:"emailAddress,omitempty"`
+	IPRange       string    `json:"ipRange,omitempty"`
+	URIDomain     string    `json:"uriDomain,omitempty"`
+}
+
+// MarshalJSON returns the JSON encoding of a value. The subtree is encoded
+// as an object with exactly one of "dnsName", "directoryName",
+// "emailAddress", "ipRange" and "uriDomain". An IP range is a string in CIDR
+// notation. See GeneralNames.MarshalJSON for the encoding of a directory
+// name.
+func (e GeneralSubtree) MarshalJSON() ([]byte, error) {
+	var tmp = generalSubtreeJSON{
+		DNSName:      e.DNSName,
+		EmailAddress: e.EmailAddress,
+		URIDomain:    e.URIDomain,
+	}
+
+	if e.DirectoryName != nil {
+		dn, err := nameToJSON(e.DirectoryName)
+		if err != nil {
+			return nil, err
+		}
+		tmp.DirectoryName = &dn
+	}
+
+	if e.IPRange != nil {
+		tmp.IPRange = e.IPRange.String()
+	}
+
+	return json.Marshal(tmp)
+}
+
+// UnmarshalJSON parses a JSON-encoded value and stores the result in the
+// object.
+func (e *GeneralSubtree) UnmarshalJSON(b []byte) error {
+	var tmp generalSubtreeJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	var subtree = GeneralSubtree{
+		DNSName:      tmp.DNSName,
+		EmailAddress: tmp.EmailAddress,
+		URIDomain:    tmp.URIDomain,
+	}
+
+	if tmp.DirectoryName != nil {
+		dn, err := nameFromJSON(*tmp.DirectoryName)
+		if err != nil {
+			return err
+		}
+		subtree.DirectoryName = dn
+	}
+
+	if tmp.IPRange != "" {
+		_, ipnet, err := net.ParseCIDR(tmp.IPRange)
+		if err != nil {
+			return err
+		}
+		subtree.IPRange = ipnet
+	}
+
+	*e = subtree
+
+	return nil
+}
@@ -0,0 +1,281 @@
+package asn1_test
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+func TestNameConstraintsMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		obj  pgasn1.NameConstraints
+		want []byte
+		err  error
+	}{
+		{
+			name: "PermittedAndExcluded",
+			obj: pgasn1.NameConstraints{
+				Permitted: []pgasn1.GeneralSubtree{
+					{DNSName: "example.com"},
+				},
+				Excluded: []pgasn1.GeneralSubtree{
+					{IPRange: mustParseCIDR(t, "10.0.0.0/8")},
+				},
+			},
+			want: []byte{asn1.TagSequence | bit6, 31,
+				asn1.ClassContextSpecific<<6 | bit6 | 0, 15,
+				asn1.TagSequence | bit6, 13,
+				nameTagDNSName | asn1.ClassContextSpecific<<6, 11,
+				'e', 'x', 'a', 'm', 'p', 'l', 'e', '.', 'c', 'o', 'm',
+				asn1.ClassContextSpecific<<6 | bit6 | 1, 12,
+				asn1.TagSequence | bit6, 10,
+				nameTagIPAddress | asn1.ClassContextSpecific<<6, 8, 10, 0, 0, 0, 255, 0, 0, 0,
+			},
+		},
+		{
+			name: "Empty",
+			obj:  pgasn1.NameConstraints{},
+			err:  errors.New("no subtrees"),
+		},
+		{
+			name: "NoName",
+			obj: pgasn1.NameConstraints{
+				Permitted: []pgasn1.GeneralSubtree{{}},
+			},
+			err: errors.New("no name"),
+		},
+		{
+			name: "TwoNames",
+			obj: pgasn1.NameConstraints{
+				Permitted: []pgasn1.GeneralSubtree{
+					{DNSName: "example.com", URIDomain: "example.com"},
+				},
+			},
+			err: errors.New("two names"),
+		},
+
+		{
+			name: "NonCanonicalMask",
+			obj: pgasn1.NameConstraints{
+				Excluded: []pgasn1.GeneralSubtree{
+					{
+						IPRange: &net.IPNet{
+							IP:   net.IP{10, 0, 0, 0},
+							Mask: net.IPMask{255, 0, 255, 0},
+						},
+					},
+				},
+			},
+			err: errors.New("non-canonical mask"),
+		},
+		{
+			name: "HostBitsSet",
+			obj: pgasn1.NameConstraints{
+				Excluded: []pgasn1.GeneralSubtree{
+					{
+						IPRange: &net.IPNet{
+							IP:   net.IP{10, 0, 0, 1},
+							Mask: net.IPMask{255, 0, 0, 0},
+						},
+					},
+				},
+			},
+			err: errors.New("host bits set"),
+		},
+		{
+			name: "MismatchedFamily",
+			obj: pgasn1.NameConstraints{
+				Excluded: []pgasn1.GeneralSubtree{
+					{
+						IPRange: &net.IPNet{
+							IP:   net.ParseIP("2001:db8::"),
+							Mask: net.CIDRMask(8, 32),
+						},
+					},
+				},
+			},
+			err: errors.New("mismatched family"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.obj.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !bytes.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNameConstraintsUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		der  []byte
+		want pgasn1.NameConstraints
+		err  error
+	}{
+		{
+			name: "PermittedAndExcluded",
+			der: []byte{asn1.TagSequence | bit6, 31,
+				asn1.ClassContextSpecific<<6 | bit6 | 0, 15,
+				asn1.TagSequence | bit6, 13,
+				nameTagDNSName | asn1.ClassContextSpecific<<6, 11,
+				'e', 'x', 'a', 'm', 'p', 'l', 'e', '.', 'c', 'o', 'm',
+				asn1.ClassContextSpecific<<6 | bit6 | 1, 12,
+				asn1.TagSequence | bit6, 10,
+				nameTagIPAddress | asn1.ClassContextSpecific<<6, 8, 10, 0, 0, 0, 255, 0, 0, 0,
+			},
+			want: pgasn1.NameConstraints{
+				Permitted: []pgasn1.GeneralSubtree{
+					{DNSName: "example.com"},
+				},
+				Excluded: []pgasn1.GeneralSubtree{
+					{
+						IPRange: &net.IPNet{
+							IP:   net.IP{10, 0, 0, 0},
+							Mask: net.IPMask{255, 0, 0, 0},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "DirectoryName",
+			der: []byte{asn1.TagSequence | bit6, 22,
+				asn1.ClassContextSpecific<<6 | bit6 | 0, 20,
+				asn1.TagSequence | bit6, 18,
+				nameTagDirectoryName | asn1.ClassContextSpecific<<6 | bit6, 16,
+				asn1.TagSequence | bit6, 14,
+				asn1.TagSet | bit6, 12,
+				asn1.TagSequence | bit6, 10,
+				asn1.TagOID, 3, 0x55, 0x04, 0x0a,
+				asn1.TagPrintableString, 3, 'F', 'o', 'o',
+			},
+			want: pgasn1.NameConstraints{
+				Permitted: []pgasn1.GeneralSubtree{
+					{
+						DirectoryName: pkix.RDNSequence{
+							{{Type: asn1.ObjectIdentifier{2, 5, 4, 10}, Value: "Foo"}},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "MinimumAndMaximum",
+			der: []byte{asn1.TagSequence | bit6, 24,
+				asn1.ClassContextSpecific<<6 | bit6 | 0, 22,
+				asn1.TagSequence | bit6, 20,
+				nameTagRFC822Name | asn1.ClassContextSpecific<<6, 12,
+				'.', 'e', 'x', 'a', 'm', 'p', 'l', 'e', '.', 'c', 'o', 'm',
+				asn1.ClassContextSpecific<<6 | 0, 1, 1,
+				asn1.ClassContextSpecific<<6 | 1, 1, 2,
+			},
+			err: errors.New("bad minimum"),
+		},
+		{
+			name: "Maximum",
+			der: []byte{asn1.TagSequence | bit6, 21,
+				asn1.ClassContextSpecific<<6 | bit6 | 0, 19,
+				asn1.TagSequence | bit6, 17,
+				nameTagRFC822Name | asn1.ClassContextSpecific<<6, 12,
+				'.', 'e', 'x', 'a', 'm', 'p', 'l', 'e', '.', 'c', 'o', 'm',
+				asn1.ClassContextSpecific<<6 | 1, 1, 0,
+			},
+			err: errors.New("bad maximum"),
+		},
+		{
+			name: "BadIPLength",
+			der: []byte{asn1.TagSequence | bit6, 10,
+				asn1.ClassContextSpecific<<6 | bit6 | 1, 8,
+				asn1.TagSequence | bit6, 6,
+				nameTagIPAddress | asn1.ClassContextSpecific<<6, 4, 10, 0, 0, 0,
+			},
+			err: errors.New("bad IP length"),
+		},
+		{
+			name: "NonCanonicalMask",
+			der: []byte{asn1.TagSequence | bit6, 14,
+				asn1.ClassContextSpecific<<6 | bit6 | 1, 12,
+				asn1.TagSequence | bit6, 10,
+				nameTagIPAddress | asn1.ClassContextSpecific<<6, 8, 10, 0, 0, 0, 255, 0, 255, 0,
+			},
+			err: errors.New("non-canonical mask"),
+		},
+		{
+			name: "UnsupportedNameType",
+			der: []byte{asn1.TagSequence | bit6, 9,
+				asn1.ClassContextSpecific<<6 | bit6 | 1, 7,
+				asn1.TagSequence | bit6, 5,
+				nameTagRegisteredID | asn1.ClassContextSpecific<<6, 3, 0x2a, 0x03, 0x04,
+			},
+			err: errors.New("unsupported name type"),
+		},
+		{
+			name: "TrailingBytes",
+			der: []byte{asn1.TagSequence | bit6, 14,
+				asn1.ClassContextSpecific<<6 | bit6 | 1, 12,
+				asn1.TagSequence | bit6, 10,
+				nameTagIPAddress | asn1.ClassContextSpecific<<6, 8, 10, 0, 0, 0, 255, 0, 0, 0,
+				0xff,
+			},
+			err: errors.New("trailing bytes"),
+		},
+		{
+			name: "BadASN1",
+			der:  []byte{0xff},
+			err:  errors.New("bad ASN.1"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got pgasn1.NameConstraints
+
+			err := got.Unmarshal(tc.der)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func mustParseCIDR(t *testing.T, s string) *net.IPNet {
+	t.Helper()
+
+	_, ipnet, err := net.ParseCIDR(s)
+	if err != nil {
+		t.Fatalf("couldn't parse CIDR: %v", err)
+	}
+
+	return ipnet
+}
@@ -0,0 +1,190 @@
+package asn1
+
+import (
+	goasn1 "encoding/asn1"
+	"fmt"
+	"strings"
+)
+
+// oidNames associates names with commonly-used OIDs. The OpenSSL short and
+// long names are used where OpenSSL defines them, and otherwise the name is
+// taken from the ASN.1 module or specification which defines the OID. An
+// empty long name indicates that it is the same as the short name.
+var oidNames = []struct {
+	oid      goasn1.ObjectIdentifier
+	name     string
+	longName string
+}{
+	{OIDSubjectDirectoryAttributes, "subjectDirectoryAttributes", "X509v3 Subject Directory Attributes"},
+	{OIDSubjectKeyIdentifier, "subjectKeyIdentifier", "X509v3 Subject Key Identifier"},
+	{OIDKeyUsage, "keyUsage", "X509v3 Key Usage"},
+	{OIDPrivateKeyUsagePeriod, "privateKeyUsagePeriod", "X509v3 Private Key Usage Period"},
+	{OIDSubjectAltName, "subjectAltName", "X509v3 Subject Alternative Name"},
+	{OIDIssuerAltName, "issuerAltName", "X509v3 Issuer Alternative Name"},
+	{OIDBasicConstraints, "basicConstraints", "X509v3 Basic Constraints"},
+	{OIDCRLNumber, "crlNumber", "X509v3 CRL Number"},
+	{OIDReasonCode, "CRLReason", "X509v3 CRL Reason Code"},
+	{OIDInvalidityDate, "invalidityDate", "Invalidity Date"},
+	{OIDDeltaCRLIndicator, "deltaCRL", "X509v3 Delta CRL Indicator"},
+	{OIDIssuingDistributionPoint, "issuingDistributionPoint", "X509v3 Issuing Distribution Point"},
+	{OIDCertificateIssuer, "certificateIssuer", "X509v3 Certificate Issuer"},
+	{OIDNameConstraints, "nameConstraints", "X509v3 Name Constraints"},
+	{OIDCRLDistributionPoints, "crlDistributionPoints", "X509v3 CRL Distribution Points"},
+	{OIDCertificatePolicies, "certificatePolicies", "X509v3 Certificate Policies"},
+	{OIDPolicyMappings, "policyMappings", "X509v3 Policy Mappings"},
+	{OIDAuthorityKeyIdentifier, "authorityKeyIdentifier", "X509v3 Authority Key Identifier"},
+	{OIDPolicyConstraints, "policyConstraints", "X509v3 Policy Constraints"},
+	{OIDExtendedKeyUsage, "extendedKeyUsage", "X509v3 Extended Key Usage"},
+	{OIDFreshestCRL, "freshestCRL", "X509v3 Freshest CRL"},
+	{OIDInhibitAnyPolicy, "inhibitAnyPolicy", "X509v3 Inhibit Any Policy"},
+	{OIDAuthorityInfoAccess, "authorityInfoAccess", "Authority Information Access"},
+	{OIDQCStatements, "qcStatements", ""},
+	{OIDIPAddrBlocks, "sbgp-ipAddrBlock", ""},
+	{OIDAutonomousSysIDs, "sbgp-autonomousSysNum", ""},
+	{OIDSubjectInfoAccess, "subjectInfoAccess", "Subject Information Access"},
+	{OIDTLSFeature, "tlsfeature", "TLS Feature"},
+	{OIDACMEIdentifier, "id-pe-acmeIdentifier", "ACME Identifier"},
+	{OIDOCSPNonce, "Nonce", "OCSP Nonce"},
+	{OIDOCSPNoCheck, "noCheck", "OCSP No Check"},
+	{OIDSMIMECapabilities, "SMIME-CAPS", "S/MIME Capabilities"},
+	{OIDSignedCertificateTimestampList, "ct_precert_scts", "CT Precertificate SCTs"},
+	{OIDPrecertificatePoison, "ct_precert_poison", "CT Precertificate Poison"},
+	{OIDMSCertificateTemplateName, "szOID_ENROLL_CERTTYPE_EXTENSION", "Certificate Template Name"},
+	{OIDMSCAVersion, "szOID_CERTSRV_CA_VERSION", "CA Version"},
+	{OIDMSCertificateTemplate, "szOID_CERTIFICATE_TEMPLATE", "Certificate Template Information"},
+	{OIDMSApplicationPolicies, "szOID_APPLICATION_CERT_POLICIES", "Application Policies"},
+
+	{OIDAccessMethodOCSP, "OCSP", ""},
+	{OIDAccessMethodCAIssuers, "caIssuers", "CA Issuers"},
+	{OIDAccessMethodTimeStamping, "ad_timestamping", "AD Time Stamping"},
+	{OIDAccessMethodCARepository, "caRepository", "CA Repository"},
+
+	{OIDExtKeyUsageAny, "anyExtendedKeyUsage", "Any Extended Key Usage"},
+	{OIDExtKeyUsageServerAuth, "serverAuth", "TLS Web Server Authentication"},
+	{OIDExtKeyUsageClientAuth, "clientAuth", "TLS Web Client Authentication"},
+	{OIDExtKeyUsageCodeSigning, "codeSigning", "Code Signing"},
+	{OIDExtKeyUsageEmailProtection, "emailProtection", "E-mail Protection"},
+	{OIDExtKeyUsageIPSECEndSystem, "ipsecEndSystem", "IPSec End System"},
+	{OIDExtKeyUsageIPSECTunnel, "ipsecTunnel", "IPSec Tunnel"},
+	{OIDExtKeyUsageIPSECUser, "ipsecUser", "IPSec User"},
+	{OIDExtKeyUsageTimeStamping, "timeStamping", "Time Stamping"},
+	{OIDExtKeyUsageOCSPSigning, "OCSPSigning", "OCSP Signing"},
+
+	{OIDAnyPolicy, "anyPolicy", "X509v3 Any Policy"},
+	{OIDPolicyQualifierCPS, "id-qt-cps", "Policy Qualifier CPS"},
+	{OIDPolicyQualifierUserNotice, "id-qt-unotice", "Policy Qualifier User Notice"},
+
+	{OIDCommonName, "CN", "commonName"},
+	{OIDSurname, "SN", "surname"},
+	{OIDSerialNumber, "serialNumber", ""},
+	{OIDCountryName, "C", "countryName"},
+	{OIDLocalityName, "L", "localityName"},
+	{OIDStateOrProvinceName, "ST", "stateOrProvinceName"},
+	{OIDStreetAddress, "street", "streetAddress"},
+	{OIDOrganizationName, "O", "organizationName"},
+	{OIDOrganizationalUnitName, "OU", "organizationalUnitName"},
+	{OIDPostalCode, "postalCode", ""},
+	{OIDGivenName, "GN", "givenName"},
+	{OIDEmailAddress, "emailAddress", ""},
+	{OIDDomainComponent, "DC", "domainComponent"},
+	{OIDUserID, "UID", "userId"},
+
+	{OIDDateOfBirth, "id-pda-dateOfBirth", ""},
+	{OIDPlaceOfBirth, "id-pda-placeOfBirth", ""},
+	{OIDGender, "id-pda-gender", ""},
+	{OIDCountryOfCitizenship, "id-pda-countryOfCitizenship", ""},
+	{OIDCountryOfResidence, "id-pda-countryOfResidence", ""},
+
+	{OIDAES128CBC, "AES-128-CBC", "aes-128-cbc"},
+	{OIDAES128Wrap, "id-aes128-wrap", ""},
+	{OIDAES128GCM, "id-aes128-GCM", "aes-128-gcm"},
+	{OIDAES192CBC, "AES-192-CBC", "aes-192-cbc"},
+	{OIDAES192Wrap, "id-aes192-wrap", ""},
+	{OIDAES192GCM, "id-aes192-GCM", "aes-192-gcm"},
+	{OIDAES256CBC, "AES-256-CBC", "aes-256-cbc"},
+	{OIDAES256Wrap, "id-aes256-wrap", ""},
+	{OIDAES256GCM, "id-aes256-GCM", "aes-256-gcm"},
+	{OIDRC2CBC, "RC2-CBC", "rc2-cbc"},
+	{OIDDESEDE3CBC, "DES-EDE3-CBC", "des-ede3-cbc"},
+
+	{OIDQCCompliance, "id-etsi-qcs-QcCompliance", ""},
+	{OIDQCLimitValue, "id-etsi-qcs-QcLimitValue", ""},
+	{OIDQCRetentionPeriod, "id-etsi-qcs-QcRetentionPeriod", ""},
+	{OIDQCSSCD, "id-etsi-qcs-QcSSCD", ""},
+	{OIDQCPDS, "id-etsi-qcs-QcPDS", ""},
+	{OIDQCType, "id-etsi-qcs-QcType", ""},
+	{OIDQCTypeESign, "id-etsi-qct-esign", ""},
+	{OIDQCTypeESeal, "id-etsi-qct-eseal", ""},
+	{OIDQCTypeWeb, "id-etsi-qct-web", ""},
+	{OIDQCPSD2, "id-etsi-psd2-qcStatement", ""},
+	{OIDPSD2RolePSPAS, "id-psd2-role-psp-as", ""},
+	{OIDPSD2RolePSPPI, "id-psd2-role-psp-pi", ""},
+	{OIDPSD2RolePSPAI, "id-psd2-role-psp-ai", ""},
+	{OIDPSD2RolePSPIC, "id-psd2-role-psp-ic", ""},
+
+	{OIDSignatureMD2WithRSA, "RSA-MD2", "md2WithRSAEncryption"},
+	{OIDSignatureMD5WithRSA, "RSA-MD5", "md5WithRSAEncryption"},
+	{OIDSignatureSHA1WithRSA, "RSA-SHA1", "sha1WithRSAEncryption"},
+	{OIDSignatureSHA256WithRSA, "RSA-SHA256", "sha256WithRSAEncryption"},
+	{OIDSignatureSHA384WithRSA, "RSA-SHA384", "sha384WithRSAEncryption"},
+	{OIDSignatureSHA512WithRSA, "RSA-SHA512", "sha512WithRSAEncryption"},
+	{OIDSignatureRSAPSS, "RSASSA-PSS", "rsassaPss"},
+	{OIDSignatureDSAWithSHA1, "DSA-SHA1", "dsaWithSHA1"},
+	{OIDSignatureDSAWithSHA256, "dsa_with_SHA256", ""},
+	{OIDSignatureECDSAWithSHA1, "ecdsa-with-SHA1", ""},
+	{OIDSignatureECDSAWithSHA256, "ecdsa-with-SHA256", ""},
+	{OIDSignatureECDSAWithSHA384, "ecdsa-with-SHA384", ""},
+	{OIDSignatureECDSAWithSHA512, "ecdsa-with-SHA512", ""},
+	{OIDSignatureEd25519, "ED25519", ""},
+	{OIDSHA256, "SHA256", "sha256"},
+	{OIDSHA384, "SHA384", "sha384"},
+	{OIDSHA512, "SHA512", "sha512"},
+	{OIDMGF1, "MGF1", "mgf1"},
+	{OIDISOSignatureSHA1WithRSA, "RSA-SHA1-2", "sha1WithRSA"},
+}
+
+// OIDName returns the name of an OID, or its dotted decimal string
+// representation if it has no name. Names are OpenSSL short names where
+// OpenSSL defines one, such as "serverAuth" or "keyUsage".
+func OIDName(oid goasn1.ObjectIdentifier) string {
+	for _, n := range oidNames {
+		if n.oid.Equal(oid) {
+			return n.name
+		}
+	}
+
+	return oid.String()
+}
+
+// OIDLongName returns the long name of an OID, such as "X509v3 Key Usage"
+// or "TLS Web Server Authentication", for use in human-readable output. If
+// the OID has no long name, its name is returned as by OIDName.
+func OIDLongName(oid goasn1.ObjectIdentifier) string {
+	for _, n := range oidNames {
+		if n.oid.Equal(oid) {
+			if n.longName != "" {
+				return n.longName
+			}
+
+			return n.name
+		}
+	}
+
+	return oid.String()
+}
+
+// ParseOIDName parses either an OID name as returned by OIDName, or a
+// dotted decimal string representation of an OID.
+func ParseOIDName(s string) (goasn1.ObjectIdentifier, error) {
+	for _, n := range oidNames {
+		if n.name == s {
+			return append(goasn1.ObjectIdentifier{}, n.oid...), nil
+		}
+	}
+
+	if !strings.Contains(s, ".") {
+		return nil, fmt.Errorf("unknown OID name: %q", s)
+	}
+
+	return ParseOID(s)
+}
@@ -0,0 +1,121 @@
+package asn1_test
+
+import (
+	"encoding/asn1"
+	"errors"
+	"testing"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+func TestOIDName(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		oid  asn1.ObjectIdentifier
+		want string
+	}{
+		{
+			oid:  pgasn1.OIDKeyUsage,
+			want: "keyUsage",
+		},
+		{
+			oid:  pgasn1.OIDExtKeyUsageServerAuth,
+			want: "serverAuth",
+		},
+		{
+			oid:  asn1.ObjectIdentifier{1, 2, 3, 4},
+			want: "1.2.3.4",
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.want, func(t *testing.T) {
+			t.Parallel()
+
+			if got := pgasn1.OIDName(tc.oid); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestParseOIDName(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		s    string
+		want asn1.ObjectIdentifier
+		err  error
+	}{
+		{
+			s:    "keyUsage",
+			want: pgasn1.OIDKeyUsage,
+		},
+		{
+			s:    "1.2.3.4",
+			want: asn1.ObjectIdentifier{1, 2, 3, 4},
+		},
+		{
+			s:   "noSuchName",
+			err: errors.New("unknown OID name"),
+		},
+		{
+			s:   "1.2.x",
+			err: errors.New("not an OID"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.s, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := pgasn1.ParseOIDName(tc.s)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !got.Equal(tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOIDLongName(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		oid  asn1.ObjectIdentifier
+		want string
+	}{
+		{
+			oid:  pgasn1.OIDKeyUsage,
+			want: "X509v3 Key Usage",
+		},
+		{
+			oid:  pgasn1.OIDIPAddrBlocks,
+			want: "sbgp-ipAddrBlock",
+		},
+		{
+			oid:  asn1.ObjectIdentifier{1, 2, 3, 4},
+			want: "1.2.3.4",
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.want, func(t *testing.T) {
+			t.Parallel()
+
+			if got := pgasn1.OIDLongName(tc.oid); got != tc.want {
+				t.Errorf("got %q, want %q", got, tc.want)
+			}
+		})
+	}
+}
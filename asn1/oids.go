@@ -8,12 +8,136 @@ import (
 
 // Extension OID values.
 var (
-	OIDSubjectKeyIdentifier   = goasn1.ObjectIdentifier{2, 5, 29, 14}
-	OIDKeyUsage               = goasn1.ObjectIdentifier{2, 5, 29, 15}
-	OIDSubjectAltName         = goasn1.ObjectIdentifier{2, 5, 29, 17}
-	OIDBasicConstraints       = goasn1.ObjectIdentifier{2, 5, 29, 19}
-	OIDAuthorityKeyIdentifier = goasn1.ObjectIdentifier{2, 5, 29, 35}
-	OIDExtendedKeyUsage       = goasn1.ObjectIdentifier{2, 5, 29, 37}
+	OIDSubjectDirectoryAttributes = goasn1.ObjectIdentifier{2, 5, 29, 9}
+	OIDSubjectKeyIdentifier       = goasn1.ObjectIdentifier{2, 5, 29, 14}
+	OIDKeyUsage                   = goasn1.ObjectIdentifier{2, 5, 29, 15}
+	OIDPrivateKeyUsagePeriod      = goasn1.ObjectIdentifier{2, 5, 29, 16}
+	OIDSubjectAltName             = goasn1.ObjectIdentifier{2, 5, 29, 17}
+	OIDIssuerAltName              = goasn1.ObjectIdentifier{2, 5, 29, 18}
+	OIDBasicConstraints           = goasn1.ObjectIdentifier{2, 5, 29, 19}
+	OIDCRLNumber                  = goasn1.ObjectIdentifier{2, 5, 29, 20}
+	OIDReasonCode                 = goasn1.ObjectIdentifier{2, 5, 29, 21}
+	OIDInvalidityDate             = goasn1.ObjectIdentifier{2, 5, 29, 24}
+	OIDDeltaCRLIndicator          = goasn1.ObjectIdentifier{2, 5, 29, 27}
+	OIDIssuingDistributionPoint   = goasn1.ObjectIdentifier{2, 5, 29, 28}
+	OIDCertificateIssuer          = goasn1.ObjectIdentifier{2, 5, 29, 29}
+	OIDNameConstraints            = goasn1.ObjectIdentifier{2, 5, 29, 30}
+	OIDCRLDistributionPoints      = goasn1.ObjectIdentifier{2, 5, 29, 31}
+	OIDCertificatePolicies        = goasn1.ObjectIdentifier{2, 5, 29, 32}
+	OIDPolicyMappings             = goasn1.ObjectIdentifier{2, 5, 29, 33}
+	OIDAuthorityKeyIdentifier     = goasn1.ObjectIdentifier{2, 5, 29, 35}
+	OIDPolicyConstraints          = goasn1.ObjectIdentifier{2, 5, 29, 36}
+	OIDExtendedKeyUsage           = goasn1.ObjectIdentifier{2, 5, 29, 37}
+	OIDFreshestCRL                = goasn1.ObjectIdentifier{2, 5, 29, 46}
+	OIDInhibitAnyPolicy           = goasn1.ObjectIdentifier{2, 5, 29, 54}
+	OIDAuthorityInfoAccess        = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 1}
+	OIDQCStatements               = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 3}
+	OIDIPAddrBlocks               = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 7}
+	OIDAutonomousSysIDs           = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 8}
+	OIDSubjectInfoAccess          = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 11}
+	OIDTLSFeature                 = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 24}
+	OIDACMEIdentifier             = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 1, 31}
+	OIDOCSPNonce                  = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1, 2}
+	OIDOCSPNoCheck                = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1, 5}
+	OIDSMIMECapabilities          = goasn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 15}
+
+	OIDSignedCertificateTimestampList = goasn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 2}
+	OIDPrecertificatePoison           = goasn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 11129, 2, 4, 3}
+
+	OIDMSCertificateTemplateName = goasn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 20, 2}
+	OIDMSCAVersion               = goasn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 21, 1}
+	OIDMSCertificateTemplate     = goasn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 21, 7}
+	OIDMSApplicationPolicies     = goasn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 21, 10}
+)
+
+// Access method OID values.
+var (
+	OIDAccessMethodOCSP         = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 1}
+	OIDAccessMethodCAIssuers    = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 2}
+	OIDAccessMethodTimeStamping = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 3}
+	OIDAccessMethodCARepository = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 48, 5}
+)
+
+// Extended key usage OID values.
+var (
+	OIDExtKeyUsageAny             = goasn1.ObjectIdentifier{2, 5, 29, 37, 0}
+	OIDExtKeyUsageServerAuth      = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 1}
+	OIDExtKeyUsageClientAuth      = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 2}
+	OIDExtKeyUsageCodeSigning     = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 3}
+	OIDExtKeyUsageEmailProtection = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 4}
+	OIDExtKeyUsageIPSECEndSystem  = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 5}
+	OIDExtKeyUsageIPSECTunnel     = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 6}
+	OIDExtKeyUsageIPSECUser       = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 7}
+	OIDExtKeyUsageTimeStamping    = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 8}
+	OIDExtKeyUsageOCSPSigning     = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 9}
+)
+
+// Name attribute type OID values.
+var (
+	OIDCommonName             = goasn1.ObjectIdentifier{2, 5, 4, 3}
+	OIDSurname                = goasn1.ObjectIdentifier{2, 5, 4, 4}
+	OIDSerialNumber           = goasn1.ObjectIdentifier{2, 5, 4, 5}
+	OIDCountryName            = goasn1.ObjectIdentifier{2, 5, 4, 6}
+	OIDLocalityName           = goasn1.ObjectIdentifier{2, 5, 4, 7}
+	OIDStateOrProvinceName    = goasn1.ObjectIdentifier{2, 5, 4, 8}
+	OIDStreetAddress          = goasn1.ObjectIdentifier{2, 5, 4, 9}
+	OIDOrganizationName       = goasn1.ObjectIdentifier{2, 5, 4, 10}
+	OIDOrganizationalUnitName = goasn1.ObjectIdentifier{2, 5, 4, 11}
+	OIDPostalCode             = goasn1.ObjectIdentifier{2, 5, 4, 17}
+	OIDGivenName              = goasn1.ObjectIdentifier{2, 5, 4, 42}
+	OIDEmailAddress           = goasn1.ObjectIdentifier{1, 2, 840, 113549, 1, 9, 1}
+	OIDDomainComponent        = goasn1.ObjectIdentifier{0, 9, 2342, 19200300, 100, 1, 25}
+	OIDUserID                 = goasn1.ObjectIdentifier{0, 9, 2342, 19200300, 100, 1, 1}
+)
+
+// Certificate policy OID values.
+var (
+	OIDAnyPolicy                 = goasn1.ObjectIdentifier{2, 5, 29, 32, 0}
+	OIDPolicyQualifierCPS        = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 2, 1}
+	OIDPolicyQualifierUserNotice = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 2, 2}
+)
+
+// Personal data attribute OID values. See RFC 3739 section 3.2.2.
+var (
+	OIDDateOfBirth          = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 9, 1}
+	OIDPlaceOfBirth         = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 9, 2}
+	OIDGender               = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 9, 3}
+	OIDCountryOfCitizenship = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 9, 4}
+	OIDCountryOfResidence   = goasn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 9, 5}
+)
+
+// S/MIME capability OID values.
+var (
+	OIDAES128CBC  = goasn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 2}
+	OIDAES128Wrap = goasn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 5}
+	OIDAES128GCM  = goasn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 6}
+	OIDAES192CBC  = goasn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 22}
+	OIDAES192Wrap = goasn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 25}
+	OIDAES192GCM  = goasn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 26}
+	OIDAES256CBC  = goasn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 42}
+	OIDAES256Wrap = goasn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 45}
+	OIDAES256GCM  = goasn1.ObjectIdentifier{2, 16, 840, 1, 101, 3, 4, 1, 46}
+	OIDRC2CBC     = goasn1.ObjectIdentifier{1, 2, 840, 113549, 3, 2}
+	OIDDESEDE3CBC = goasn1.ObjectIdentifier{1, 2, 840, 113549, 3, 7}
+)
+
+// QC statement OID values. See ETSI EN 319 412-5 and ETSI TS 119 495.
+var (
+	OIDQCCompliance      = goasn1.ObjectIdentifier{0, 4, 0, 1862, 1, 1}
+	OIDQCLimitValue      = goasn1.ObjectIdentifier{0, 4, 0, 1862, 1, 2}
+	OIDQCRetentionPeriod = goasn1.ObjectIdentifier{0, 4, 0, 1862, 1, 3}
+	OIDQCSSCD            = goasn1.ObjectIdentifier{0, 4, 0, 1862, 1, 4}
+	OIDQCPDS             = goasn1.ObjectIdentifier{0, 4, 0, 1862, 1, 5}
+	OIDQCType            = goasn1.ObjectIdentifier{0, 4, 0, 1862, 1, 6}
+	OIDQCTypeESign       = goasn1.ObjectIdentifier{0, 4, 0, 1862, 1, 6, 1}
+	OIDQCTypeESeal       = goasn1.ObjectIdentifier{0, 4, 0, 1862, 1, 6, 2}
+	OIDQCTypeWeb         = goasn1.ObjectIdentifier{0, 4, 0, 1862, 1, 6, 3}
+	OIDQCPSD2            = goasn1.ObjectIdentifier{0, 4, 0, 19495, 2}
+
+	OIDPSD2RolePSPAS = goasn1.ObjectIdentifier{0, 4, 0, 19495, 1, 1}
+	OIDPSD2RolePSPPI = goasn1.ObjectIdentifier{0, 4, 0, 19495, 1, 2}
+	OIDPSD2RolePSPAI = goasn1.ObjectIdentifier{0, 4, 0, 19495, 1, 3}
+	OIDPSD2RolePSPIC = goasn1.ObjectIdentifier{0, 4, 0, 19495, 1, 4}
 )
 
 // Signature and hash OID values.
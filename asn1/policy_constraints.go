@@ -0,0 +1,110 @@
+package asn1
+
+import (
+	"encoding/asn1"
+	"errors"
+	"fmt"
+)
+
+// PolicyConstraints represents an X509 policy constraints extension as
+// defined in RFC 5280 section 4.2.1.11. A value of -1 indicates that the
+// corresponding field is absent.
+//
+// id-ce-policyConstraints OBJECT IDENTIFIER ::=  { id-ce 36 }
+//
+// PolicyConstraints ::= SEQUENCE {
+//      requireExplicitPolicy           [0] SkipCerts OPTIONAL,
+//      inhibitPolicyMapping            [1] SkipCerts OPTIONAL }
+//
+// SkipCerts ::= INTEGER (0..MAX)
+type PolicyConstraints struct {
+	RequireExplicitPolicy int `asn1:"optional,tag:0,default:-1"`
+	InhibitPolicyMapping  int `asn1:"optional,tag:1,default:-1"`
+}
+
+// policyConstraints is the intermediate representation of a
+// PolicyConstraints, used when unmarshalling to distinguish an absent field
+// from an explicitly encoded negative one.
+type policyConstraints struct {
+	RequireExplicitPolicy asn1.RawValue `asn1:"optional,tag:0"`
+	InhibitPolicyMapping  asn1.RawValue `asn1:"optional,tag:1"`
+}
+
+// Marshal returns the ASN.1 DER-encoding of a value.
+func (e PolicyConstraints) Marshal() ([]byte, error) {
+
+	// Conforming CAs MUST NOT issue certificates where policy constraints is
+	// an empty sequence. See RFC 5280 section 4.2.1.11.
+	if e.RequireExplicitPolicy == -1 && e.InhibitPolicyMapping == -1 {
+		return nil, errors.New("no policy constraints specified")
+	}
+
+	if e.RequireExplicitPolicy < -1 {
+		return nil, fmt.Errorf("invalid require explicit policy: %d", e.RequireExplicitPolicy)
+	}
+
+	if e.InhibitPolicyMapping < -1 {
+		return nil, fmt.Errorf("invalid inhibit policy mapping: %d", e.InhibitPolicyMapping)
+	}
+
+	return asn1.Marshal(e)
+}
+
+// Unmarshal parses an DER-encoded ASN.1 data structure and stores the result
+// in the object.
+func (e *PolicyConstraints) Unmarshal(b []byte) error {
+	var raw policyConstraints
+
+	rest, err := asn1.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	} else if len(rest) != 0 {
+		return errors.New("trailing bytes")
+	}
+
+	if len(raw.RequireExplicitPolicy.FullBytes) == 0 && len(raw.InhibitPolicyMapping.FullBytes) == 0 {
+		return errors.New("no policy constraints specified")
+	}
+
+	var tmp = PolicyConstraints{
+		RequireExplicitPolicy: -1,
+		InhibitPolicyMapping:  -1,
+	}
+
+	if err := unmarshalSkipCerts(raw.RequireExplicitPolicy, 0, &tmp.RequireExplicitPolicy); err != nil {
+		return err
+	}
+
+	if err := unmarshalSkipCerts(raw.InhibitPolicyMapping, 1, &tmp.InhibitPolicyMapping); err != nil {
+		return err
+	}
+
+	*e = tmp
+
+	return nil
+}
+
+// unmarshalSkipCerts parses an optional SkipCerts value with the specified
+// context-specific tag, leaving the value unchanged if it is absent.
+func unmarshalSkipCerts(val asn1.RawValue, tag int, out *int) error {
+	if len(val.FullBytes) == 0 {
+		return nil
+	}
+
+	var n int
+
+	rest, err := asn1.UnmarshalWithParams(val.FullBytes, &n, fmt.Sprintf("tag:%d", tag))
+	if err != nil {
+		return err
+	} else if len(rest) != 0 {
+		return errors.New("trailing bytes")
+	}
+
+	if n < 0 {
+		return fmt.Errorf("negative skip certs value: %d", n)
+	}
+
+	*out = n
+
+	return nil
+}
@@ -0,0 +1,143 @@
+package asn1_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	goasn1 "encoding/asn1"
+
+	"github.com/paulgriffiths/pki/asn1"
+)
+
+func TestPolicyConstraintsMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		obj  asn1.PolicyConstraints
+		want []byte
+		err  error
+	}{
+		{
+			name: "RequireExplicitPolicy",
+			obj:  asn1.PolicyConstraints{RequireExplicitPolicy: 0, InhibitPolicyMapping: -1},
+			want: []byte{goasn1.TagSequence | bit6, 3, goasn1.ClassContextSpecific << 6, 1, 0},
+		},
+		{
+			name: "InhibitPolicyMapping",
+			obj:  asn1.PolicyConstraints{RequireExplicitPolicy: -1, InhibitPolicyMapping: 3},
+			want: []byte{goasn1.TagSequence | bit6, 3, goasn1.ClassContextSpecific<<6 | 1, 1, 3},
+		},
+		{
+			name: "Both",
+			obj:  asn1.PolicyConstraints{RequireExplicitPolicy: 2, InhibitPolicyMapping: 0},
+			want: []byte{goasn1.TagSequence | bit6, 6,
+				goasn1.ClassContextSpecific << 6, 1, 2,
+				goasn1.ClassContextSpecific<<6 | 1, 1, 0,
+			},
+		},
+		{
+			name: "Empty",
+			obj:  asn1.PolicyConstraints{RequireExplicitPolicy: -1, InhibitPolicyMapping: -1},
+			err:  errors.New("empty"),
+		},
+		{
+			name: "Negative",
+			obj:  asn1.PolicyConstraints{RequireExplicitPolicy: -2, InhibitPolicyMapping: 1},
+			err:  errors.New("negative"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.obj.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !bytes.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPolicyConstraintsUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		der  []byte
+		want asn1.PolicyConstraints
+		err  error
+	}{
+		{
+			name: "RequireExplicitPolicy",
+			der:  []byte{goasn1.TagSequence | bit6, 3, goasn1.ClassContextSpecific << 6, 1, 0},
+			want: asn1.PolicyConstraints{RequireExplicitPolicy: 0, InhibitPolicyMapping: -1},
+		},
+		{
+			name: "InhibitPolicyMapping",
+			der:  []byte{goasn1.TagSequence | bit6, 3, goasn1.ClassContextSpecific<<6 | 1, 1, 3},
+			want: asn1.PolicyConstraints{RequireExplicitPolicy: -1, InhibitPolicyMapping: 3},
+		},
+		{
+			name: "Negative",
+			der:  []byte{goasn1.TagSequence | bit6, 3, goasn1.ClassContextSpecific<<6 | 1, 1, 0xfe},
+			err:  errors.New("negative"),
+		},
+		{
+			name: "Both",
+			der: []byte{goasn1.TagSequence | bit6, 6,
+				goasn1.ClassContextSpecific << 6, 1, 2,
+				goasn1.ClassContextSpecific<<6 | 1, 1, 0,
+			},
+			want: asn1.PolicyConstraints{RequireExplicitPolicy: 2, InhibitPolicyMapping: 0},
+		},
+		{
+			name: "ExplicitMinusOne",
+			der:  []byte{goasn1.TagSequence | bit6, 3, goasn1.ClassContextSpecific << 6, 1, 0xff},
+			err:  errors.New("negative"),
+		},
+		{
+			name: "Empty",
+			der:  []byte{goasn1.TagSequence | bit6, 0},
+			err:  errors.New("empty"),
+		},
+		{
+			name: "TrailingData",
+			der:  []byte{goasn1.TagSequence | bit6, 3, goasn1.ClassContextSpecific << 6, 1, 0, 0xff},
+			err:  errors.New("trailing data"),
+		},
+		{
+			name: "BadASN1",
+			der:  []byte{0xff, 0xff, 0xff},
+			err:  errors.New("bad ASN.1"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got asn1.PolicyConstraints
+
+			err := got.Unmarshal(tc.der)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
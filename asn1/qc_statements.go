@@ -0,0 +1,545 @@
+package asn1
+
+import (
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"unicode/utf8"
+)
+
+// QCStatements represents a qualified certificate statements extension as
+// defined in RFC 3739 section 3.2.6 and ETSI EN 319 412-5.
+//
+// id-pe-qcStatements OBJECT IDENTIFIER ::= { id-pe 3 }
+//
+// QCStatements ::= SEQUENCE OF QCStatement
+type QCStatements []QCStatement
+
+// QCStatement represents a qualified certificate statement as defined in RFC
+// 3739 section 3.2.6. Types is used when ID is OIDQCType, RetentionPeriod
+// when ID is OIDQCRetentionPeriod, PDSLocations when ID is OIDQCPDS,
+// LimitValue when ID is OIDQCLimitValue, and PSD2 when ID is OIDQCPSD2. The
+// OIDQCCompliance and OIDQCSSCD statements have no statement information.
+// For any other statement ID, Raw contains the encoded statement
+// information, if present.
+//
+//	QCStatement ::= SEQUENCE {
+//	     statementId        OBJECT IDENTIFIER,
+//	     statementInfo      ANY DEFINED BY statementId OPTIONAL }
+type QCStatement struct {
+	ID              asn1.ObjectIdentifier
+	Types           []asn1.ObjectIdentifier
+	RetentionPeriod int
+	PDSLocations    []PDSLocation
+	LimitValue      MonetaryValue
+	PSD2            PSD2QCType
+	Raw             asn1.RawValue
+}
+
+// PDSLocation represents the location of a PKI disclosure statement as
+// defined in ETSI EN 319 412-5 section 4.3.4. Language is a two-letter ISO
+// 639-1 language code.
+//
+//	PdsLocation ::= SEQUENCE {
+//	     url                IA5String,
+//	     language           PrintableString (SIZE(2)) }
+type PDSLocation struct {
+	URL      string `asn1:"ia5" json:"url"`
+	Language string `asn1:"printable" json:"language"`
+}
+
+// MonetaryValue represents a transaction value limit as defined in ETSI EN
+// 319 412-5 section 4.3.2. Exactly one of Currency, an alphabetic ISO 4217
+// currency code, and NumericCurrency, a numeric ISO 4217 currency code,
+// should be set. The value is Amount * 10^Exponent.
+//
+//	MonetaryValue ::= SEQUENCE {
+//	     currency           Iso4217CurrencyCode,
+//	     amount             INTEGER,
+//	     exponent           INTEGER }
+//
+//	Iso4217CurrencyCode ::= CHOICE {
+//	     alphabetic         PrintableString (SIZE (3)),
+//	     numeric            INTEGER (1..999) }
+type MonetaryValue struct {
+	Currency        string `json:"currency,omitempty"`
+	NumericCurrency int    `json:"numericCurrency,omitempty"`
+	Amount          int    `json:"amount"`
+	Exponent        int    `json:"exponent"`
+}
+
+// PSD2QCType represents a PSD2 qualified certificate statement as defined in
+// ETSI TS 119 495 section 5.1.
+//
+//	PSD2QcType ::= SEQUENCE {
+//	     rolesOfPSP         RolesOfPSP,
+//	     nCAName            NCAName,
+//	     nCAId              NCAId }
+//
+// RolesOfPSP ::= SEQUENCE OF RoleOfPSP
+//
+// NCAName ::= UTF8String (SIZE (256))
+//
+// NCAId ::= UTF8String (SIZE (256))
+type PSD2QCType struct {
+	Roles   []PSD2Role `json:"roles"`
+	NCAName string     `asn1:"utf8" json:"ncaName"`
+	NCAID   string     `asn1:"utf8" json:"ncaId"`
+}
+
+// PSD2Role represents the role of a payment service provider as defined in
+// ETSI TS 119 495 section 5.1.
+//
+//	RoleOfPSP ::= SEQUENCE {
+//	     roleOfPspOid       RoleOfPspOid,
+//	     roleOfPspName      RoleOfPspName }
+//
+// RoleOfPspName ::= UTF8String (SIZE(256))
+type PSD2Role struct {
+	ID   asn1.ObjectIdentifier
+	Name string `asn1:"utf8"`
+}
+
+// Maximum lengths of ETSI TS 119 495 strings and ISO 4217 numeric codes.
+const (
+	maxPSD2StringLength   = 256
+	maxNumericCurrency    = 999
+	alphabeticCurrencyLen = 3
+)
+
+// qcStatement is the intermediate representation of a QCStatement.
+type qcStatement struct {
+	ID   asn1.ObjectIdentifier
+	Info asn1.RawValue `asn1:"optional"`
+}
+
+// monetaryValue is the intermediate representation of a MonetaryValue.
+type monetaryValue struct {
+	Currency asn1.RawValue
+	Amount   int
+	Exponent int
+}
+
+// Marshal returns the ASN.1 DER-encoding of a value.
+func (e QCStatements) Marshal() ([]byte, error) {
+	if len(e) == 0 {
+		return nil, errors.New("no QC statements specified")
+	}
+
+	var tmp []qcStatement
+
+	for _, s := range e {
+		if len(s.ID) == 0 {
+			return nil, errors.New("no QC statement identifier specified")
+		}
+
+		info, err := s.raw()
+		if err != nil {
+			return nil, err
+		}
+
+		tmp = append(tmp, qcStatement{ID: s.ID, Info: info})
+	}
+
+	return asn1.Marshal(tmp)
+}
+
+// Unmarshal parses an DER-encoded ASN.1 data structure and stores the result
+// in the object.
+func (e *QCStatements) Unmarshal(b []byte) error {
+	var raw []qcStatement
+
+	rest, err := asn1.Unmarshal(b, &raw)
+	if err != nil {
+		return err
+	} else if len(rest) != 0 {
+		return errors.New("trailing bytes")
+	}
+
+	var tmp QCStatements
+
+	for _, r := range raw {
+		s, err := qcStatementFromRaw(r)
+		if err != nil {
+			return err
+		}
+
+		tmp = append(tmp, s)
+	}
+
+	*e = tmp
+
+	return nil
+}
+
+// raw returns the encoded statement information, or a zero value if the
+// statement information is absent.
+func (e QCStatement) raw() (asn1.RawValue, error) {
+	var info interface{}
+
+	switch {
+	case e.ID.Equal(OIDQCCompliance), e.ID.Equal(OIDQCSSCD):
+		return asn1.RawValue{}, nil
+
+	case e.ID.Equal(OIDQCType):
+		if len(e.Types) == 0 {
+			return asn1.RawValue{}, errors.New("no QC types specified")
+		}
+		info = e.Types
+
+	case e.ID.Equal(OIDQCRetentionPeriod):
+		if e.RetentionPeriod < 0 {
+			return asn1.RawValue{}, fmt.Errorf("invalid retention period: %d", e.RetentionPeriod)
+		}
+		info = e.RetentionPeriod
+
+	case e.ID.Equal(OIDQCPDS):
+		if err := checkPDSLocations(e.PDSLocations); err != nil {
+			return asn1.RawValue{}, err
+		}
+		info = e.PDSLocations
+
+	case e.ID.Equal(OIDQCLimitValue):
+		val, err := e.LimitValue.raw()
+		if err != nil {
+			return asn1.RawValue{}, err
+		}
+		info = val
+
+	case e.ID.Equal(OIDQCPSD2):
+		if err := e.PSD2.check(); err != nil {
+			return asn1.RawValue{}, err
+		}
+		info = e.PSD2
+
+	default:
+		return e.Raw, nil
+	}
+
+	der, err := asn1.Marshal(info)
+	if err != nil {
+		return asn1.RawValue{}, err
+	}
+
+	return asn1.RawValue{FullBytes: der}, nil
+}
+
+// qcStatementFromRaw converts the intermediate representation of a
+// QCStatement.
+func qcStatementFromRaw(raw qcStatement) (QCStatement, error) {
+	var s = QCStatement{ID: raw.ID}
+	var present = len(raw.Info.FullBytes) != 0
+
+	switch {
+	case raw.ID.Equal(OIDQCCompliance), raw.ID.Equal(OIDQCSSCD):
+		if present {
+			return QCStatement{}, fmt.Errorf("unexpected information for QC statement %v", raw.ID)
+		}
+		return s, nil
+
+	case !raw.ID.Equal(OIDQCType) &&
+		!raw.ID.Equal(OIDQCRetentionPeriod) &&
+		!raw.ID.Equal(OIDQCPDS) &&
+		!raw.ID.Equal(OIDQCLimitValue) &&
+		!raw.ID.Equal(OIDQCPSD2):
+		s.Raw = raw.Info
+		return s, nil
+
+	case !present:
+		return QCStatement{}, fmt.Errorf("missing information for QC statement %v", raw.ID)
+	}
+
+	var err error
+
+	switch {
+	case raw.ID.Equal(OIDQCType):
+		if err = unmarshalInfo(raw.Info, &s.Types); err == nil && len(s.Types) == 0 {
+			err = errors.New("no QC types specified")
+		}
+
+	case raw.ID.Equal(OIDQCRetentionPeriod):
+		if err = unmarshalInfo(raw.Info, &s.RetentionPeriod); err == nil && s.RetentionPeriod < 0 {
+			err = fmt.Errorf("invalid retention period: %d", s.RetentionPeriod)
+		}
+
+	case raw.ID.Equal(OIDQCPDS):
+		if err = unmarshalInfo(raw.Info, &s.PDSLocations); err == nil {
+			err = checkPDSLocations(s.PDSLocations)
+		}
+
+	case raw.ID.Equal(OIDQCLimitValue):
+		err = s.LimitValue.unmarshalRaw(raw.Info)
+
+	case raw.ID.Equal(OIDQCPSD2):
+		if err = unmarshalInfo(raw.Info, &s.PSD2); err == nil {
+			err = s.PSD2.check()
+		}
+	}
+
+	if err != nil {
+		return QCStatement{}, err
+	}
+
+	return s, nil
+}
+
+// unmarshalInfo parses statement information from a raw value.
+func unmarshalInfo(val asn1.RawValue, out interface{}) error {
+	if rest, err := asn1.Unmarshal(val.FullBytes, out); err != nil {
+		return err
+	} else if len(rest) != 0 {
+		return errors.New("trailing bytes")
+	}
+
+	return nil
+}
+
+// checkPDSLocations returns an error if the PDS location list is empty or if
+// any language code is not two characters long.
+func checkPDSLocations(locs []PDSLocation) error {
+	if len(locs) == 0 {
+		return errors.New("no PDS locations specified")
+	}
+
+	for _, loc := range locs {
+		if len(loc.Language) != 2 {
+			return fmt.Errorf("invalid PDS language code: %q", loc.Language)
+		}
+	}
+
+	return nil
+}
+
+// raw returns the encoded monetary value.
+func (e MonetaryValue) raw() (monetaryValue, error) {
+	var tmp = monetaryValue{
+		Amount:   e.Amount,
+		Exponent: e.Exponent,
+	}
+
+	switch {
+	case e.Currency != "" && e.NumericCurrency != 0:
+		return monetaryValue{}, errors.New("monetary value has both alphabetic and numeric currency codes")
+
+	case e.Currency != "":
+		if len(e.Currency) != alphabeticCurrencyLen {
+			return monetaryValue{}, fmt.Errorf("invalid alphabetic currency code: %q", e.Currency)
+		}
+
+		der, err := asn1.MarshalWithParams(e.Currency, "printable")
+		if err != nil {
+			return monetaryValue{}, err
+		}
+		tmp.Currency = asn1.RawValue{FullBytes: der}
+
+	case e.NumericCurrency > 0 && e.NumericCurrency <= maxNumericCurrency:
+		der, err := asn1.Marshal(e.NumericCurrency)
+		if err != nil {
+			return monetaryValue{}, err
+		}
+		tmp.Currency = asn1.RawValue{FullBytes: der}
+
+	default:
+		return monetaryValue{}, fmt.Errorf("invalid numeric currency code: %d", e.NumericCurrency)
+	}
+
+	return tmp, nil
+}
+
+// unmarshalRaw parses a monetary value from a raw value and stores the
+// result in the object.
+func (e *MonetaryValue) unmarshalRaw(val asn1.RawValue) error {
+	var raw monetaryValue
+	if err := unmarshalInfo(val, &raw); err != nil {
+		return err
+	}
+
+	var tmp = MonetaryValue{
+		Amount:   raw.Amount,
+		Exponent: raw.Exponent,
+	}
+
+	if raw.Currency.Class != asn1.ClassUniversal {
+		return errors.New("invalid currency code")
+	}
+
+	switch raw.Currency.Tag {
+	case asn1.TagPrintableString:
+		tmp.Currency = string(raw.Currency.Bytes)
+		if len(tmp.Currency) != alphabeticCurrencyLen {
+			return fmt.Errorf("invalid alphabetic currency code: %q", tmp.Currency)
+		}
+
+	case asn1.TagInteger:
+		if err := unmarshalInfo(raw.Currency, &tmp.NumericCurrency); err != nil {
+			return err
+		}
+
+		if tmp.NumericCurrency < 1 || tmp.NumericCurrency > maxNumericCurrency {
+			return fmt.Errorf("invalid numeric currency code: %d", tmp.NumericCurrency)
+		}
+
+	default:
+		return fmt.Errorf("unexpected currency code tag: %d", raw.Currency.Tag)
+	}
+
+	*e = tmp
+
+	return nil
+}
+
+// check returns an error if any PSD2 string is too long.
+func (e PSD2QCType) check() error {
+	for _, s := range []string{e.NCAName, e.NCAID} {
+		if utf8.RuneCountInString(s) > maxPSD2StringLength {
+			return fmt.Errorf("PSD2 string too long: %q", s)
+		}
+	}
+
+	for _, role := range e.Roles {
+		if utf8.RuneCountInString(role.Name) > maxPSD2StringLength {
+			return fmt.Errorf("PSD2 role name too long: %q", role.Name)
+		}
+	}
+
+	return nil
+}
+
+// qcStatementJSON is the JSON representation of a QCStatement.
+type qcStatementJSON struct {
+	ID              string         `json:"id"`
+	Types           []string       `json:"types,omitempty"`
+	RetentionPeriod *int           `json:"retentionPeriod,omitempty"`
+	PDSLocations    []PDSLocation  `json:"pdsLocations,omitempty"`
+	LimitValue      *MonetaryValue `json:"limitValue,omitempty"`
+	PSD2            *PSD2QCType    `json:"psd2,omitempty"`
+	Raw             string         `json:"raw,omitempty"`
+}
+
+// psd2RoleJSON is the JSON representation of a PSD2Role.
+type psd2RoleJSON struct {
+	ID   string `json:"id"`
+	Name string `json:"name"`
+}
+
+// MarshalJSON returns the JSON encoding of a value. The statement is encoded
+// as an object with an "id" member containing the named or dotted statement
+// OID and, depending on the statement, one of a "types" list of OIDs, a
+// "retentionPeriod" integer, a "pdsLocations" list of {"url", "language"}
+// objects, a "limitValue" object with a "currency" string or
+// "numericCurrency" integer and "amount" and "exponent" integers, a "psd2"
+// object with a "roles" list of {"id", "name"} objects and "ncaName" and
+// "ncaId" strings, or for any other statement an optional "raw" hex string
+// containing the DER-encoded statement information.
+func (e QCStatement) MarshalJSON() ([]byte, error) {
+	var tmp = qcStatementJSON{ID: OIDName(e.ID)}
+
+	switch {
+	case e.ID.Equal(OIDQCCompliance), e.ID.Equal(OIDQCSSCD):
+		// These statements have no statement information.
+
+	case e.ID.Equal(OIDQCType):
+		tmp.Types = oidStrings(e.Types)
+
+	case e.ID.Equal(OIDQCRetentionPeriod):
+		var period = e.RetentionPeriod
+		tmp.RetentionPeriod = &period
+
+	case e.ID.Equal(OIDQCPDS):
+		tmp.PDSLocations = e.PDSLocations
+
+	case e.ID.Equal(OIDQCLimitValue):
+		tmp.LimitValue = &e.LimitValue
+
+	case e.ID.Equal(OIDQCPSD2):
+		tmp.PSD2 = &e.PSD2
+
+	default:
+		raw, err := rawToHex(e.Raw)
+		if err != nil {
+			return nil, err
+		}
+		tmp.Raw = raw
+	}
+
+	return json.Marshal(tmp)
+}
+
+// UnmarshalJSON parses a JSON-encoded value and stores the result in the
+// object.
+func (e *QCStatement) UnmarshalJSON(b []byte) error {
+	var tmp qcStatementJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	oid, err := ParseOIDName(tmp.ID)
+	if err != nil {
+		return err
+	}
+
+	types, err := parseOIDNames(tmp.Types)
+	if err != nil {
+		return err
+	}
+
+	raw, err := rawFromHex(tmp.Raw)
+	if err != nil {
+		return err
+	}
+
+	var s = QCStatement{
+		ID:           oid,
+		Types:        types,
+		PDSLocations: tmp.PDSLocations,
+		Raw:          raw,
+	}
+
+	if tmp.RetentionPeriod != nil {
+		s.RetentionPeriod = *tmp.RetentionPeriod
+	}
+
+	if tmp.LimitValue != nil {
+		s.LimitValue = *tmp.LimitValue
+	}
+
+	if tmp.PSD2 != nil {
+		s.PSD2 = *tmp.PSD2
+	}
+
+	*e = s
+
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of a value. The role is encoded as
+// an object with an "id" member containing the named or dotted role OID,
+// and a "name" string.
+func (e PSD2Role) MarshalJSON() ([]byte, error) {
+	return json.Marshal(psd2RoleJSON{
+		ID:   OIDName(e.ID),
+		Name: e.Name,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded value and stores the result in the
+// object.
+func (e *PSD2Role) UnmarshalJSON(b []byte) error {
+	var tmp psd2RoleJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	oid, err := ParseOIDName(tmp.ID)
+	if err != nil {
+		return err
+	}
+
+	*e = PSD2Role{
+		ID:   oid,
+		Name: tmp.Name,
+	}
+
+	return nil
+}
@@ -0,0 +1,277 @@
+package asn1_test
+
+import (
+	"bytes"
+	"encoding/hex"
+	"errors"
+	"reflect"
+	"strings"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+func TestQCStatementsMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		obj  pgasn1.QCStatements
+		want string
+		err  error
+	}{
+		{
+			name: "NumericCurrency",
+			obj: pgasn1.QCStatements{
+				{
+					ID: pgasn1.OIDQCLimitValue,
+					LimitValue: pgasn1.MonetaryValue{
+						NumericCurrency: 978,
+						Amount:          5,
+						Exponent:        -2,
+					},
+				},
+			},
+			want: "30163014060604008e460102300a020203d20201050201fe",
+		},
+		{
+			name: "UnknownWithoutInfo",
+			obj: pgasn1.QCStatements{
+				{ID: asn1.ObjectIdentifier{1, 2, 3}},
+			},
+			want: "3006300406022a03",
+		},
+		{
+			name: "Empty",
+			obj:  pgasn1.QCStatements{},
+			err:  errors.New("no statements"),
+		},
+		{
+			name: "NoID",
+			obj:  pgasn1.QCStatements{{}},
+			err:  errors.New("no statement ID"),
+		},
+		{
+			name: "NegativeRetentionPeriod",
+			obj: pgasn1.QCStatements{
+				{ID: pgasn1.OIDQCRetentionPeriod, RetentionPeriod: -1},
+			},
+			err: errors.New("negative retention period"),
+		},
+		{
+			name: "NoQCTypes",
+			obj: pgasn1.QCStatements{
+				{ID: pgasn1.OIDQCType},
+			},
+			err: errors.New("no QC types"),
+		},
+		{
+			name: "NoPDSLocations",
+			obj: pgasn1.QCStatements{
+				{ID: pgasn1.OIDQCPDS},
+			},
+			err: errors.New("no PDS locations"),
+		},
+		{
+			name: "BadPDSLanguage",
+			obj: pgasn1.QCStatements{
+				{
+					ID:           pgasn1.OIDQCPDS,
+					PDSLocations: []pgasn1.PDSLocation{{URL: "https://a/pds", Language: "eng"}},
+				},
+			},
+			err: errors.New("bad language"),
+		},
+		{
+			name: "BothCurrencies",
+			obj: pgasn1.QCStatements{
+				{
+					ID: pgasn1.OIDQCLimitValue,
+					LimitValue: pgasn1.MonetaryValue{
+						Currency:        "EUR",
+						NumericCurrency: 978,
+					},
+				},
+			},
+			err: errors.New("both currencies"),
+		},
+		{
+			name: "NoCurrency",
+			obj: pgasn1.QCStatements{
+				{ID: pgasn1.OIDQCLimitValue},
+			},
+			err: errors.New("no currency"),
+		},
+		{
+			name: "LongNCAName",
+			obj: pgasn1.QCStatements{
+				{
+					ID:   pgasn1.OIDQCPSD2,
+					PSD2: pgasn1.PSD2QCType{NCAName: strings.Repeat("x", 257)},
+				},
+			},
+			err: errors.New("NCA name too long"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.obj.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if want := mustDecodeHex(t, tc.want); !bytes.Equal(got, want) {
+				t.Errorf("got %x, want %x", got, want)
+			}
+		})
+	}
+}
+
+func TestQCStatementsUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name  string
+		input string
+		want  pgasn1.QCStatements
+		err   error
+	}{
+		{
+			name: "OpenSSL",
+			input: "30819b3008060604008e4601013013060604008e4601063009060704008e" +
+				"46010603300b060604008e46010302010f301f060604008e46010530153013" +
+				"160d68747470733a2f2f612f7064731302656e3015060604008e460102300b" +
+				"1303455552020164020103302b06060400819827023021301330110607040" +
+				"081982701030c065053505f41490c0442616e6b0c0458582d423008060604" +
+				"008e460104",
+			want: pgasn1.QCStatements{
+				{ID: pgasn1.OIDQCCompliance},
+				{
+					ID:    pgasn1.OIDQCType,
+					Types: []asn1.ObjectIdentifier{pgasn1.OIDQCTypeWeb},
+				},
+				{
+					ID:              pgasn1.OIDQCRetentionPeriod,
+					RetentionPeriod: 15,
+				},
+				{
+					ID: pgasn1.OIDQCPDS,
+					PDSLocations: []pgasn1.PDSLocation{
+						{URL: "https://a/pds", Language: "en"},
+					},
+				},
+				{
+					ID: pgasn1.OIDQCLimitValue,
+					LimitValue: pgasn1.MonetaryValue{
+						Currency: "EUR",
+						Amount:   100,
+						Exponent: 3,
+					},
+				},
+				{
+					ID: pgasn1.OIDQCPSD2,
+					PSD2: pgasn1.PSD2QCType{
+						Roles: []pgasn1.PSD2Role{
+							{ID: pgasn1.OIDPSD2RolePSPAI, Name: "PSP_AI"},
+						},
+						NCAName: "Bank",
+						NCAID:   "XX-B",
+					},
+				},
+				{ID: pgasn1.OIDQCSSCD},
+			},
+		},
+		{
+			name:  "ComplianceWithInfo",
+			input: "300d300b060604008e4601010101ff",
+			err:   errors.New("unexpected statement info"),
+		},
+		{
+			name:  "MissingInfo",
+			input: "300a3008060604008e460103",
+			err:   errors.New("missing statement info"),
+		},
+		{
+			name:  "NoQCTypes",
+			input: "300c300a060604008e4601063000",
+			err:   errors.New("no QC types"),
+		},
+		{
+			name:  "NumericCurrencyOutOfRange",
+			input: "30163014060604008e460102300a020203e80201050201fe",
+			err:   errors.New("numeric currency out of range"),
+		},
+		{
+			name:  "BadCurrencyType",
+			input: "30173015060604008e460102300b0c03455552020164020103",
+			err:   errors.New("bad currency type"),
+		},
+		{
+			name:  "TrailingBytes",
+			input: "300a3008060604008e46010100",
+			err:   errors.New("trailing bytes"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got pgasn1.QCStatements
+
+			err := got.Unmarshal(mustDecodeHex(t, tc.input))
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestQCStatementsRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var der = mustDecodeHex(t, "3081b23008060604008e4601013013060604008e460106300906"+
+		"0704008e46010603300b060604008e46010302010f301f060604008e4601053015"+
+		"3013160d68747470733a2f2f612f7064731302656e3015060604008e460102300b"+
+		"1303455552020164020103302b06060400819827023021301330110607040081"+
+		"982701030c065053505f41490c0442616e6b0c0458582d42301506082b060105"+
+		"05070b023009060704008bec4901013008060604008e460104")
+
+	var statements pgasn1.QCStatements
+	if err := statements.Unmarshal(der); err != nil {
+		t.Fatalf("couldn't unmarshal QC statements: %v", err)
+	}
+
+	got, err := statements.Marshal()
+	if err != nil {
+		t.Fatalf("couldn't marshal QC statements: %v", err)
+	}
+
+	if !bytes.Equal(got, der) {
+		t.Errorf("got %x, want %x", got, der)
+	}
+}
+
+func mustDecodeHex(t *testing.T, s string) []byte {
+	t.Helper()
+
+	b, err := hex.DecodeString(s)
+	if err != nil {
+		t.Fatalf("couldn't decode hex: %v", err)
+	}
+
+	return b
+}
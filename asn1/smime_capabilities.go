@@ -0,0 +1,110 @@
+package asn1
+
+import (
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+)
+
+// SMIMECapabilities represents an S/MIME capabilities extension or attribute
+// value as defined in RFC 4262 section 2 and RFC 8551 section 2.5.2.
+// Capabilities are listed in order of preference.
+//
+// smimeCapabilities OBJECT IDENTIFIER ::= { iso(1) member-body(2)
+//      us(840) rsadsi(113549) pkcs(1) pkcs-9(9) 15 }
+//
+// SMIMECapabilities ::= SEQUENCE OF SMIMECapability
+type SMIMECapabilities []SMIMECapability
+
+// SMIMECapability represents a single S/MIME capability as defined in RFC
+// 8551 section 2.5.2. Parameters is absent if it is the zero value, and
+// otherwise contains the encoded capability-specific parameters, such as the
+// key length in bits for OIDRC2CBC.
+//
+// SMIMECapability ::= SEQUENCE {
+//      capabilityID            OBJECT IDENTIFIER,
+//      parameters              ANY DEFINED BY capabilityID OPTIONAL }
+type SMIMECapability struct {
+	ID         asn1.ObjectIdentifier
+	Parameters asn1.RawValue `asn1:"optional"`
+}
+
+// Marshal returns the ASN.1 DER-encoding of a value.
+func (e SMIMECapabilities) Marshal() ([]byte, error) {
+	if len(e) == 0 {
+		return nil, errors.New("no S/MIME capabilities specified")
+	}
+
+	for _, c := range e {
+		if len(c.ID) == 0 {
+			return nil, errors.New("no S/MIME capability identifier specified")
+		}
+	}
+
+	return asn1.Marshal([]SMIMECapability(e))
+}
+
+// Unmarshal parses an DER-encoded ASN.1 data structure and stores the result
+// in the object.
+func (e *SMIMECapabilities) Unmarshal(b []byte) error {
+	var tmp []SMIMECapability
+
+	rest, err := asn1.Unmarshal(b, &tmp)
+	if err != nil {
+		return err
+	} else if len(rest) != 0 {
+		return errors.New("trailing bytes")
+	}
+
+	*e = tmp
+
+	return nil
+}
+
+// smimeCapabilityJSON is the JSON representation of an SMIMECapability.
+type smimeCapabilityJSON struct {
+	ID         string `json:"id"`
+	Parameters string `json:"parameters,omitempty"`
+}
+
+// MarshalJSON returns the JSON encoding of a value. The capability is
+// encoded as an object with an "id" member containing the named or dotted
+// capability OID, and an optional "parameters" hex string containing the
+// DER-encoded parameters.
+func (e SMIMECapability) MarshalJSON() ([]byte, error) {
+	params, err := rawToHex(e.Parameters)
+	if err != nil {
+		return nil, err
+	}
+
+	return json.Marshal(smimeCapabilityJSON{
+		ID:         OIDName(e.ID),
+		Parameters: params,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded value and stores the result in the
+// object.
+func (e *SMIMECapability) UnmarshalJSON(b []byte) error {
+	var tmp smimeCapabilityJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	oid, err := ParseOIDName(tmp.ID)
+	if err != nil {
+		return err
+	}
+
+	params, err := rawFromHex(tmp.Parameters)
+	if err != nil {
+		return err
+	}
+
+	*e = SMIMECapability{
+		ID:         oid,
+		Parameters: params,
+	}
+
+	return nil
+}
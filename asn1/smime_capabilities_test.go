@@ -0,0 +1,119 @@
+package asn1_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+func TestSMIMECapabilitiesMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		obj  pgasn1.SMIMECapabilities
+		want string
+		err  error
+	}{
+		{
+			name: "OK",
+			obj: pgasn1.SMIMECapabilities{
+				{ID: pgasn1.OIDAES256CBC},
+				{
+					ID:         pgasn1.OIDRC2CBC,
+					Parameters: asn1.RawValue{FullBytes: []byte{asn1.TagInteger, 2, 0x00, 0x80}},
+				},
+			},
+			want: "301d300b060960864801650304012a300e06082a864886f70d030202020080",
+		},
+		{
+			name: "Empty",
+			obj:  pgasn1.SMIMECapabilities{},
+			err:  errors.New("no capabilities"),
+		},
+		{
+			name: "NoID",
+			obj:  pgasn1.SMIMECapabilities{{}},
+			err:  errors.New("no capability ID"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.obj.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if want := mustDecodeHex(t, tc.want); !bytes.Equal(got, want) {
+				t.Errorf("got %x, want %x", got, want)
+			}
+		})
+	}
+}
+
+func TestSMIMECapabilitiesUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name  string
+		input string
+		want  pgasn1.SMIMECapabilities
+		err   error
+	}{
+		{
+			name:  "OpenSSL",
+			input: "301d300b060960864801650304012a300e06082a864886f70d030202020080",
+			want: pgasn1.SMIMECapabilities{
+				{ID: pgasn1.OIDAES256CBC},
+				{
+					ID: pgasn1.OIDRC2CBC,
+					Parameters: asn1.RawValue{
+						Class:     asn1.ClassUniversal,
+						Tag:       asn1.TagInteger,
+						Bytes:     []byte{0x00, 0x80},
+						FullBytes: []byte{asn1.TagInteger, 2, 0x00, 0x80},
+					},
+				},
+			},
+		},
+		{
+			name:  "MissingID",
+			input: "300430020500",
+			err:   errors.New("missing capability ID"),
+		},
+		{
+			name:  "TrailingBytes",
+			input: "300d300b060960864801650304012a00",
+			err:   errors.New("trailing bytes"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got pgasn1.SMIMECapabilities
+
+			err := got.Unmarshal(mustDecodeHex(t, tc.input))
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,403 @@
+package asn1
+
+import (
+	"bytes"
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"sort"
+	"time"
+	"unicode/utf8"
+)
+
+// SubjectDirectoryAttributes represents an X509 subject directory attributes
+// extension as defined in RFC 5280 section 4.2.1.8.
+//
+// id-ce-subjectDirectoryAttributes OBJECT IDENTIFIER ::=  { id-ce 9 }
+//
+// SubjectDirectoryAttributes ::= SEQUENCE SIZE (1..MAX) OF Attribute
+type SubjectDirectoryAttributes []Attribute
+
+// Attribute represents a directory attribute as defined in RFC 5280 section
+// 4.1.2.4. Each value contains an encoded attribute value. Helpers such as
+// DateOfBirthAttribute and Attribute.DateOfBirth build and parse the personal
+// data attributes defined in RFC 3739 section 3.2.2.
+//
+// Attribute ::= SEQUENCE {
+//      type             AttributeType,
+//      values    SET OF AttributeValue }
+type Attribute struct {
+	Type   asn1.ObjectIdentifier
+	Values []asn1.RawValue `asn1:"set"`
+}
+
+// Gender attribute values. See RFC 3739 section 3.2.2.
+const (
+	GenderMale   = "M"
+	GenderFemale = "F"
+)
+
+// Marshal returns the ASN.1 DER-encoding of a value.
+func (e SubjectDirectoryAttributes) Marshal() ([]byte, error) {
+	if len(e) == 0 {
+		return nil, errors.New("no attributes specified")
+	}
+
+	var tmp []Attribute
+
+	for _, attr := range e {
+		raw, err := attr.raw()
+		if err != nil {
+			return nil, err
+		}
+
+		tmp = append(tmp, raw)
+	}
+
+	return asn1.Marshal(tmp)
+}
+
+// Unmarshal parses an DER-encoded ASN.1 data structure and stores the result
+// in the object.
+func (e *SubjectDirectoryAttributes) Unmarshal(b []byte) error {
+	var tmp []Attribute
+
+	rest, err := asn1.Unmarshal(b, &tmp)
+	if err != nil {
+		return err
+	} else if len(rest) != 0 {
+		return errors.New("trailing bytes")
+	}
+
+	if len(tmp) == 0 {
+		return errors.New("no attributes specified")
+	}
+
+	for _, attr := range tmp {
+		if len(attr.Values) == 0 {
+			return fmt.Errorf("no values for attribute %v", attr.Type)
+		}
+	}
+
+	*e = tmp
+
+	return nil
+}
+
+// raw returns a copy of the attribute with each value fully encoded and the
+// values sorted into the order required by DER for a SET OF.
+func (e Attribute) raw() (Attribute, error) {
+	if len(e.Type) == 0 {
+		return Attribute{}, errors.New("no attribute type specified")
+	}
+
+	if len(e.Values) == 0 {
+		return Attribute{}, fmt.Errorf("no values for attribute %v", e.Type)
+	}
+
+	var tmp = Attribute{Type: e.Type}
+
+	for _, val := range e.Values {
+		der, err := asn1.Marshal(val)
+		if err != nil {
+			return Attribute{}, err
+		}
+
+		tmp.Values = append(tmp.Values, asn1.RawValue{FullBytes: der})
+	}
+
+	sort.Slice(tmp.Values, func(i, j int) bool {
+		return bytes.Compare(tmp.Values[i].FullBytes, tmp.Values[j].FullBytes) < 0
+	})
+
+	return tmp, nil
+}
+
+// DateOfBirthAttribute returns a dateOfBirth attribute. Only the date in UTC
+// is used, and the time of day is set to 12:00:00 GMT per RFC 3739 section
+// 3.2.2.
+func DateOfBirthAttribute(t time.Time) (Attribute, error) {
+	if t.IsZero() {
+		return Attribute{}, errors.New("no date of birth specified")
+	}
+
+	t = t.UTC()
+
+	der, err := asn1.MarshalWithParams(time.Date(t.Year(), t.Month(), t.Day(), 12, 0, 0, 0, time.UTC), "generalized")
+	if err != nil {
+		return Attribute{}, err
+	}
+
+	return Attribute{
+		Type:   OIDDateOfBirth,
+		Values: []asn1.RawValue{{FullBytes: der}},
+	}, nil
+}
+
+// PlaceOfBirthAttribute returns a placeOfBirth attribute, encoded as a
+// UTF8String.
+func PlaceOfBirthAttribute(place string) (Attribute, error) {
+	if place == "" || !utf8.ValidString(place) {
+		return Attribute{}, fmt.Errorf("invalid place of birth: %q", place)
+	}
+
+	return Attribute{
+		Type: OIDPlaceOfBirth,
+		Values: []asn1.RawValue{
+			{Class: asn1.ClassUniversal, Tag: asn1.TagUTF8String, Bytes: []byte(place)},
+		},
+	}, nil
+}
+
+// GenderAttribute returns a gender attribute. The gender must be one of "M",
+// "F", "m" or "f".
+func GenderAttribute(gender string) (Attribute, error) {
+	if err := checkGender(gender); err != nil {
+		return Attribute{}, err
+	}
+
+	return Attribute{
+		Type: OIDGender,
+		Values: []asn1.RawValue{
+			{Class: asn1.ClassUniversal, Tag: asn1.TagPrintableString, Bytes: []byte(gender)},
+		},
+	}, nil
+}
+
+// CountryOfCitizenshipAttribute returns a countryOfCitizenship attribute
+// containing one or more two-letter ISO 3166 country codes.
+func CountryOfCitizenshipAttribute(countries ...string) (Attribute, error) {
+	return countryAttribute(OIDCountryOfCitizenship, countries)
+}
+
+// CountryOfResidenceAttribute returns a countryOfResidence attribute
+// containing one or more two-letter ISO 3166 country codes.
+func CountryOfResidenceAttribute(countries ...string) (Attribute, error) {
+	return countryAttribute(OIDCountryOfResidence, countries)
+}
+
+// DateOfBirth returns the value of a dateOfBirth attribute.
+func (e Attribute) DateOfBirth() (time.Time, error) {
+	val, err := e.singleValue(OIDDateOfBirth)
+	if err != nil {
+		return time.Time{}, err
+	}
+
+	if val.Class != asn1.ClassUniversal || val.Tag != asn1.TagGeneralizedTime {
+		return time.Time{}, errors.New("date of birth is not a GeneralizedTime")
+	}
+
+	var t time.Time
+	if _, err := asn1.UnmarshalWithParams(encodedValue(val), &t, "generalized"); err != nil {
+		return time.Time{}, err
+	}
+
+	return t, nil
+}
+
+// PlaceOfBirth returns the value of a placeOfBirth attribute.
+func (e Attribute) PlaceOfBirth() (string, error) {
+	val, err := e.singleValue(OIDPlaceOfBirth)
+	if err != nil {
+		return "", err
+	}
+
+	if val.Class != asn1.ClassUniversal || val.IsCompound {
+		return "", errors.New("invalid place of birth")
+	}
+
+	switch val.Tag {
+	case asn1.TagUTF8String:
+		if !utf8.Valid(val.Bytes) {
+			return "", errors.New("place of birth is not valid UTF-8")
+		}
+		return string(val.Bytes), nil
+
+	case asn1.TagPrintableString:
+		return string(val.Bytes), nil
+
+	case TagBMPString:
+		return DecodeBMPString(val.Bytes)
+	}
+
+	return "", fmt.Errorf("unsupported place of birth type: %d", val.Tag)
+}
+
+// Gender returns the value of a gender attribute.
+func (e Attribute) Gender() (string, error) {
+	val, err := e.singleValue(OIDGender)
+	if err != nil {
+		return "", err
+	}
+
+	if val.Class != asn1.ClassUniversal || val.Tag != asn1.TagPrintableString {
+		return "", errors.New("gender is not a PrintableString")
+	}
+
+	var gender = string(val.Bytes)
+	if err := checkGender(gender); err != nil {
+		return "", err
+	}
+
+	return gender, nil
+}
+
+// Countries returns the country codes in a countryOfCitizenship or
+// countryOfResidence attribute.
+func (e Attribute) Countries() ([]string, error) {
+	if !e.Type.Equal(OIDCountryOfCitizenship) && !e.Type.Equal(OIDCountryOfResidence) {
+		return nil, fmt.Errorf("attribute %v is not a country attribute", e.Type)
+	}
+
+	var countries []string
+
+	for _, val := range e.Values {
+		if val.Class != asn1.ClassUniversal || val.Tag != asn1.TagPrintableString {
+			return nil, errors.New("country code is not a PrintableString")
+		}
+
+		var country = string(val.Bytes)
+		if err := checkCountry(country); err != nil {
+			return nil, err
+		}
+
+		countries = append(countries, country)
+	}
+
+	return countries, nil
+}
+
+// singleValue returns the only value of an attribute, or an error if the
+// attribute is not of the expected type or does not have exactly one value.
+func (e Attribute) singleValue(oid asn1.ObjectIdentifier) (asn1.RawValue, error) {
+	if !e.Type.Equal(oid) {
+		return asn1.RawValue{}, fmt.Errorf("unexpected attribute type: %v", e.Type)
+	}
+
+	if len(e.Values) != 1 {
+		return asn1.RawValue{}, fmt.Errorf("attribute %v has %d values", e.Type, len(e.Values))
+	}
+
+	return e.Values[0], nil
+}
+
+// countryAttribute returns an attribute of the specified type containing one
+// or more country codes.
+func countryAttribute(oid asn1.ObjectIdentifier, countries []string) (Attribute, error) {
+	if len(countries) == 0 {
+		return Attribute{}, errors.New("no countries specified")
+	}
+
+	var attr = Attribute{Type: oid}
+
+	for _, country := range countries {
+		if err := checkCountry(country); err != nil {
+			return Attribute{}, err
+		}
+
+		attr.Values = append(attr.Values, asn1.RawValue{
+			Class: asn1.ClassUniversal,
+			Tag:   asn1.TagPrintableString,
+			Bytes: []byte(country),
+		})
+	}
+
+	return attr, nil
+}
+
+// encodedValue returns the DER-encoding of a raw value.
+func encodedValue(val asn1.RawValue) []byte {
+	if len(val.FullBytes) != 0 {
+		return val.FullBytes
+	}
+
+	der, _ := asn1.Marshal(val)
+
+	return der
+}
+
+// checkGender returns an error if a gender is not one of the values defined
+// in RFC 3739 section 3.2.2.
+func checkGender(gender string) error {
+	switch gender {
+	case GenderMale, GenderFemale, "m", "f":
+		return nil
+	}
+
+	return fmt.Errorf("invalid gender: %q", gender)
+}
+
+// checkCountry returns an error if a country code is not two letters long.
+func checkCountry(country string) error {
+	if len(country) != 2 {
+		return fmt.Errorf("invalid country code: %q", country)
+	}
+
+	for _, r := range country {
+		if (r < 'A' || r > 'Z') && (r < 'a' || r > 'z') {
+			return fmt.Errorf("invalid country code: %q", country)
+		}
+	}
+
+	return nil
+}
+
+// attributeValuesJSON is the JSON representation of an Attribute.
+type attributeValuesJSON struct {
+	Type   string   `json:"type"`
+	Values []string `json:"values"`
+}
+
+// MarshalJSON returns the JSON encoding of a value. The attribute is encoded
+// as an object with a "type" member containing the named or dotted
+// attribute type OID, and a "values" list of hex strings containing the
+// DER-encoded values.
+func (e Attribute) MarshalJSON() ([]byte, error) {
+	var tmp = attributeValuesJSON{
+		Type:   OIDName(e.Type),
+		Values: []string{},
+	}
+
+	for _, val := range e.Values {
+		s, err := rawToHex(val)
+		if err != nil {
+			return nil, err
+		}
+
+		tmp.Values = append(tmp.Values, s)
+	}
+
+	return json.Marshal(tmp)
+}
+
+// UnmarshalJSON parses a JSON-encoded value and stores the result in the
+// object.
+func (e *Attribute) UnmarshalJSON(b []byte) error {
+	var tmp attributeValuesJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	oid, err := ParseOIDName(tmp.Type)
+	if err != nil {
+		return err
+	}
+
+	var attr = Attribute{Type: oid}
+
+	for _, s := range tmp.Values {
+		val, err := rawFromHex(s)
+		if err != nil {
+			return err
+		} else if len(val.FullBytes) == 0 {
+			return errors.New("empty attribute value")
+		}
+
+		attr.Values = append(attr.Values, val)
+	}
+
+	*e = attr
+
+	return nil
+}
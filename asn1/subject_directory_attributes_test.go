@@ -0,0 +1,233 @@
+package asn1_test
+
+import (
+	"bytes"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+const testSubjectDirectoryAttributes = "3067301d06082b060105050709013111180f3139373030" +
+	"3130323132303030305a301406082b0601050507090231080c065a7572696368300f06" +
+	"082b060105050709033103130146301406082b0601050507090431081302434813024445" +
+	"300906022a033103020105"
+
+func TestSubjectDirectoryAttributesMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		obj  pgasn1.SubjectDirectoryAttributes
+		want string
+		err  error
+	}{
+		{
+			name: "OK",
+			obj: pgasn1.SubjectDirectoryAttributes{
+				mustAttribute(t)(pgasn1.DateOfBirthAttribute(time.Date(1970, 1, 2, 3, 4, 5, 0, time.UTC))),
+				mustAttribute(t)(pgasn1.PlaceOfBirthAttribute("Zurich")),
+				mustAttribute(t)(pgasn1.GenderAttribute(pgasn1.GenderFemale)),
+				mustAttribute(t)(pgasn1.CountryOfCitizenshipAttribute("DE", "CH")),
+				{
+					Type:   asn1.ObjectIdentifier{1, 2, 3},
+					Values: []asn1.RawValue{{FullBytes: []byte{asn1.TagInteger, 1, 5}}},
+				},
+			},
+			want: testSubjectDirectoryAttributes,
+		},
+		{
+			name: "Empty",
+			obj:  pgasn1.SubjectDirectoryAttributes{},
+			err:  errors.New("no attributes"),
+		},
+		{
+			name: "NoType",
+			obj: pgasn1.SubjectDirectoryAttributes{
+				{Values: []asn1.RawValue{{FullBytes: []byte{asn1.TagInteger, 1, 5}}}},
+			},
+			err: errors.New("no type"),
+		},
+		{
+			name: "NoValues",
+			obj: pgasn1.SubjectDirectoryAttributes{
+				{Type: asn1.ObjectIdentifier{1, 2, 3}},
+			},
+			err: errors.New("no values"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.obj.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if want := mustDecodeHex(t, tc.want); !bytes.Equal(got, want) {
+				t.Errorf("got %x, want %x", got, want)
+			}
+		})
+	}
+}
+
+func TestSubjectDirectoryAttributesUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name  string
+		input string
+		err   error
+	}{
+		{
+			name:  "OpenSSL",
+			input: testSubjectDirectoryAttributes,
+		},
+		{
+			name:  "Empty",
+			input: "3000",
+			err:   errors.New("no attributes"),
+		},
+		{
+			name:  "NoValues",
+			input: "3008300606022a033100",
+			err:   errors.New("no values"),
+		},
+		{
+			name:  "TrailingBytes",
+			input: "300b300906022a03310302010500",
+			err:   errors.New("trailing bytes"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got pgasn1.SubjectDirectoryAttributes
+
+			err := got.Unmarshal(mustDecodeHex(t, tc.input))
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			der, err := got.Marshal()
+			if err != nil {
+				t.Fatalf("couldn't marshal attributes: %v", err)
+			}
+
+			if want := mustDecodeHex(t, tc.input); !bytes.Equal(der, want) {
+				t.Errorf("got %x, want %x", der, want)
+			}
+		})
+	}
+}
+
+func TestAttributeValues(t *testing.T) {
+	t.Parallel()
+
+	var attrs pgasn1.SubjectDirectoryAttributes
+	if err := attrs.Unmarshal(mustDecodeHex(t, testSubjectDirectoryAttributes)); err != nil {
+		t.Fatalf("couldn't unmarshal attributes: %v", err)
+	}
+
+	if got, err := attrs[0].DateOfBirth(); err != nil {
+		t.Errorf("couldn't get date of birth: %v", err)
+	} else if want := time.Date(1970, 1, 2, 12, 0, 0, 0, time.UTC); !got.Equal(want) {
+		t.Errorf("got date of birth %v, want %v", got, want)
+	}
+
+	if got, err := attrs[1].PlaceOfBirth(); err != nil {
+		t.Errorf("couldn't get place of birth: %v", err)
+	} else if want := "Zurich"; got != want {
+		t.Errorf("got place of birth %q, want %q", got, want)
+	}
+
+	if got, err := attrs[2].Gender(); err != nil {
+		t.Errorf("couldn't get gender: %v", err)
+	} else if want := pgasn1.GenderFemale; got != want {
+		t.Errorf("got gender %q, want %q", got, want)
+	}
+
+	if got, err := attrs[3].Countries(); err != nil {
+		t.Errorf("couldn't get countries: %v", err)
+	} else if want := []string{"CH", "DE"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("got countries %v, want %v", got, want)
+	}
+
+	if _, err := attrs[4].Gender(); err == nil {
+		t.Errorf("unexpectedly got gender from attribute %v", attrs[4].Type)
+	}
+
+	if _, err := attrs[3].DateOfBirth(); err == nil {
+		t.Errorf("unexpectedly got date of birth from attribute %v", attrs[3].Type)
+	}
+}
+
+func TestAttributeHelpersInvalid(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		f    func() (pgasn1.Attribute, error)
+	}{
+		{
+			name: "ZeroDateOfBirth",
+			f:    func() (pgasn1.Attribute, error) { return pgasn1.DateOfBirthAttribute(time.Time{}) },
+		},
+		{
+			name: "EmptyPlaceOfBirth",
+			f:    func() (pgasn1.Attribute, error) { return pgasn1.PlaceOfBirthAttribute("") },
+		},
+		{
+			name: "BadGender",
+			f:    func() (pgasn1.Attribute, error) { return pgasn1.GenderAttribute("X") },
+		},
+		{
+			name: "NoCountries",
+			f:    func() (pgasn1.Attribute, error) { return pgasn1.CountryOfResidenceAttribute() },
+		},
+		{
+			name: "BadCountry",
+			f:    func() (pgasn1.Attribute, error) { return pgasn1.CountryOfResidenceAttribute("USA") },
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if _, err := tc.f(); err == nil {
+				t.Errorf("unexpectedly got no error")
+			}
+		})
+	}
+}
+
+func mustAttribute(t *testing.T) func(pgasn1.Attribute, error) pgasn1.Attribute {
+	return func(attr pgasn1.Attribute, err error) pgasn1.Attribute {
+		t.Helper()
+
+		if err != nil {
+			t.Fatalf("couldn't create attribute: %v", err)
+		}
+
+		return attr
+	}
+}
@@ -0,0 +1,48 @@
+package asn1
+
+import (
+	"encoding/asn1"
+	"errors"
+)
+
+// implicitTag converts a DER-encoded value to a context-specific raw value
+// with the specified tag, as for an IMPLICIT tagged type.
+func implicitTag(der []byte, tag int) (asn1.RawValue, error) {
+	var val asn1.RawValue
+
+	rest, err := asn1.Unmarshal(der, &val)
+	if err != nil {
+		return asn1.RawValue{}, err
+	} else if len(rest) != 0 {
+		return asn1.RawValue{}, errors.New("trailing bytes")
+	}
+
+	return asn1.RawValue{
+		Class:      asn1.ClassContextSpecific,
+		Tag:        tag,
+		IsCompound: val.IsCompound,
+		Bytes:      val.Bytes,
+	}, nil
+}
+
+// explicitTag wraps a DER-encoded value in a context-specific raw value with
+// the specified tag, as for an EXPLICIT tagged type.
+func explicitTag(der []byte, tag int) asn1.RawValue {
+	return asn1.RawValue{
+		Class:      asn1.ClassContextSpecific,
+		Tag:        tag,
+		IsCompound: true,
+		Bytes:      der,
+	}
+}
+
+// universalTag returns the DER-encoding of an IMPLICIT tagged raw value with
+// its original universal tag restored.
+func universalTag(val asn1.RawValue, tag int) ([]byte, error) {
+	return asn1.Marshal(asn1.RawValue{
+		Class:      asn1.ClassUniversal,
+		Tag:        tag,
+		IsCompound: val.IsCompound,
+		Bytes:      val.Bytes,
+	})
+}
@@ -0,0 +1,116 @@
+package extensions
+
+import (
+	"crypto/sha256"
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+// ACMEIdentifier represents an ACME identifier extension as defined in RFC
+// 8737 section 3, used in TLS-ALPN-01 challenge certificates. Digest is the
+// SHA-256 digest of the key authorization, and the extension must be
+// critical.
+type ACMEIdentifier struct {
+	Critical bool
+	Digest   []byte
+}
+
+// Marshal returns a pkix.Extension.
+func (e ACMEIdentifier) Marshal() (pkix.Extension, error) {
+	if err := checkACMEIdentifier(e.Critical, e.Digest); err != nil {
+		return pkix.Extension{}, err
+	}
+
+	der, err := asn1.Marshal(e.Digest)
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       pgasn1.OIDACMEIdentifier,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *ACMEIdentifier) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(pgasn1.OIDACMEIdentifier) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var digest []byte
+	if rest, err := asn1.Unmarshal(ext.Value, &digest); err != nil {
+		return err
+	} else if len(rest) > 0 {
+		return ErrTrailingBytes
+	}
+
+	if err := checkACMEIdentifier(ext.Critical, digest); err != nil {
+		return err
+	}
+
+	*e = ACMEIdentifier{
+		Critical: ext.Critical,
+		Digest:   digest,
+	}
+
+	return nil
+}
+
+// checkACMEIdentifier returns an error if the extension is not critical or if
+// the digest is not a SHA-256 digest.
+func checkACMEIdentifier(critical bool, digest []byte) error {
+
+	// The acmeIdentifier extension MUST be critical. See RFC 8737 section 3.
+	if !critical {
+		return errors.New("ACME identifier extension is not critical")
+	}
+
+	if len(digest) != sha256.Size {
+		return fmt.Errorf("invalid ACME identifier digest length: %d", len(digest))
+	}
+
+	return nil
+}
+
+// acmeIdentifierJSON is the JSON representation of an ACMEIdentifier.
+type acmeIdentifierJSON struct {
+	Critical bool     `json:"critical"`
+	Digest   hexBytes `json:"digest"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "digest" members. The digest is a hex string.
+func (e ACMEIdentifier) MarshalJSON() ([]byte, error) {
+	return json.Marshal(acmeIdentifierJSON{
+		Critical: e.Critical,
+		Digest:   e.Digest,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *ACMEIdentifier) UnmarshalJSON(b []byte) error {
+	var tmp acmeIdentifierJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = ACMEIdentifier{
+		Critical: tmp.Critical,
+		Digest:   tmp.Digest,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e ACMEIdentifier) String() string {
+	return text(pgasn1.OIDACMEIdentifier, e.Critical, hexText(e.Digest))
+}
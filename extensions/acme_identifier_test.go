@@ -0,0 +1,137 @@
+package extensions_test
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestACMEIdentifierMarshal(t *testing.T) {
+	t.Parallel()
+
+	var digest = bytes.Repeat([]byte{0xab}, 32)
+
+	var testcases = []struct {
+		name string
+		ext  extensions.ACMEIdentifier
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext:  extensions.ACMEIdentifier{Critical: true, Digest: digest},
+			want: pkix.Extension{
+				Id:       pgasn1.OIDACMEIdentifier,
+				Critical: true,
+				Value:    append([]byte{asn1.TagOctetString, 32}, digest...),
+			},
+		},
+		{
+			name: "NotCritical",
+			ext:  extensions.ACMEIdentifier{Digest: digest},
+			want: pkix.Extension{},
+			err:  errors.New("not critical"),
+		},
+		{
+			name: "ShortDigest",
+			ext:  extensions.ACMEIdentifier{Critical: true, Digest: digest[:31]},
+			want: pkix.Extension{},
+			err:  errors.New("short digest"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestACMEIdentifierUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var digest = bytes.Repeat([]byte{0xab}, 32)
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.ACMEIdentifier
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDACMEIdentifier,
+				Critical: true,
+				Value:    append([]byte{asn1.TagOctetString, 32}, digest...),
+			},
+			want: extensions.ACMEIdentifier{Critical: true, Digest: digest},
+		},
+		{
+			name: "NotCritical",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDACMEIdentifier,
+				Value: append([]byte{asn1.TagOctetString, 32}, digest...),
+			},
+			want: extensions.ACMEIdentifier{},
+			err:  errors.New("not critical"),
+		},
+		{
+			name: "LongDigest",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDACMEIdentifier,
+				Critical: true,
+				Value:    append([]byte{asn1.TagOctetString, 33, 0}, digest...),
+			},
+			want: extensions.ACMEIdentifier{},
+			err:  errors.New("long digest"),
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDTLSFeature,
+				Critical: true,
+				Value:    append([]byte{asn1.TagOctetString, 32}, digest...),
+			},
+			want: extensions.ACMEIdentifier{},
+			err:  errors.New("bad OID"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.ACMEIdentifier
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -1,38 +1,63 @@
 package extensions
 
 import (
+	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/big"
 
-	"encoding/asn1"
-
 	pgasn1 "github.com/paulgriffiths/pki/asn1"
 )
 
 // AuthorityKeyIdentifier represents an X509 authority key identifier extension
-// as defined in RFC 5280 section 4.2.1.1.
+// as defined in RFC 5280 section 4.2.1.1. Either ID, or both Issuer and
+// SerialNumber, or all three must be present. Issuer and SerialNumber
+// identify the issuer's issuer and the serial number of the issuer's
+// certificate. ID is absent if it is nil.
 type AuthorityKeyIdentifier struct {
 	Critical     bool
 	ID           []byte
-	Issuer       asn1.RawValue
+	Issuer       pgasn1.GeneralNames
 	SerialNumber *big.Int
 }
 
+// NewAuthorityKeyIdentifier returns an authority key identifier extension
+// for certificates issued by the specified issuer. The key identifier is
+// the issuer's subject key identifier, or is computed from the issuer's
+// public key using the specified method if the issuer certificate has no
+// subject key identifier.
+func NewAuthorityKeyIdentifier(issuer *x509.Certificate, method KeyIDMethod) (AuthorityKeyIdentifier, error) {
+	if issuer == nil {
+		return AuthorityKeyIdentifier{}, errors.New("no issuer certificate specified")
+	}
+
+	if len(issuer.SubjectKeyId) != 0 {
+		return AuthorityKeyIdentifier{
+			ID: append([]byte{}, issuer.SubjectKeyId...),
+		}, nil
+	}
+
+	id, err := MakeKeyIdentifier(issuer.PublicKey, method)
+	if err != nil {
+		return AuthorityKeyIdentifier{}, err
+	}
+
+	return AuthorityKeyIdentifier{ID: id}, nil
+}
+
 // Marshal returns a pkix.Extension.
 func (e AuthorityKeyIdentifier) Marshal() (pkix.Extension, error) {
-	if len(e.ID) == 0 {
+	if e.ID == nil && e.Issuer.IsEmpty() && e.SerialNumber == nil {
 		return pkix.Extension{}, errors.New("no identifier specified")
 	}
 
-	var ae = pgasn1.AuthorityKeyIdentifier{
+	der, err := pgasn1.AuthorityKeyIdentifier{
 		ID:           e.ID,
 		Issuer:       e.Issuer,
 		SerialNumber: e.SerialNumber,
-	}
-
-	der, err := asn1.Marshal(ae)
+	}.Marshal()
 	if err != nil {
 		return pkix.Extension{}, err
 	}
@@ -51,12 +76,8 @@ func (e *AuthorityKeyIdentifier) Unmarshal(ext pkix.Extension) error {
 	}
 
 	var ae pgasn1.AuthorityKeyIdentifier
-	if rest, err := asn1.Unmarshal(ext.Value, &ae); err != nil {
+	if err := ae.Unmarshal(ext.Value); err != nil {
 		return err
-	} else if len(rest) > 0 {
-		// We should never get here when unmarshalling to a slice of bytes,
-		// but we may as well retain it for good form.
-		return ErrTrailingBytes
 	}
 
 	*e = AuthorityKeyIdentifier{
@@ -68,3 +89,89 @@ func (e *AuthorityKeyIdentifier) Unmarshal(ext pkix.Extension) error {
 
 	return nil
 }
+
+// authorityKeyIdentifierJSON is the JSON representation of an
+// AuthorityKeyIdentifier.
+type authorityKeyIdentifierJSON struct {
+	Critical      bool                 `json:"critical"`
+	KeyIdentifier *hexBytes            `json:"keyIdentifier,omitempty"`
+	Issuer        *pgasn1.GeneralNames `json:"issuer,omitempty"`
+	SerialNumber  *big.Int             `json:"serialNumber,omitempty"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with a "critical" member and optional "keyIdentifier", "issuer" and
+// "serialNumber" members. The key identifier is a hex string, the issuer is
+// a list of general names as described by asn1.GeneralNames.MarshalJSON, and
+// the serial number is an integer.
+func (e AuthorityKeyIdentifier) MarshalJSON() ([]byte, error) {
+	var tmp = authorityKeyIdentifierJSON{
+		Critical:     e.Critical,
+		SerialNumber: e.SerialNumber,
+	}
+
+	if e.ID != nil {
+		id := hexBytes(e.ID)
+		tmp.KeyIdentifier = &id
+	}
+
+	if !e.Issuer.IsEmpty() {
+		tmp.Issuer = &e.Issuer
+	}
+
+	return json.Marshal(tmp)
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *AuthorityKeyIdentifier) UnmarshalJSON(b []byte) error {
+	var tmp authorityKeyIdentifierJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	var aki = AuthorityKeyIdentifier{
+		Critical:     tmp.Critical,
+		SerialNumber: tmp.SerialNumber,
+	}
+
+	if tmp.KeyIdentifier != nil {
+		aki.ID = *tmp.KeyIdentifier
+	}
+
+	if tmp.Issuer != nil {
+		aki.Issuer = *tmp.Issuer
+	}
+
+	*e = aki
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e AuthorityKeyIdentifier) String() string {
+	var lines []string
+
+	if e.ID != nil {
+		lines = append(lines, "keyid:"+hexText(e.ID))
+	}
+
+	lines = append(lines, generalNamesText(e.Issuer)...)
+
+	if e.SerialNumber != nil {
+		lines = append(lines, "serial:"+serialText(e.SerialNumber))
+	}
+
+	return text(pgasn1.OIDAuthorityKeyIdentifier, e.Critical, lines...)
+}
+
+// serialText returns the text representation of a serial number, which is
+// the colon-separated hex encoding of its magnitude.
+func serialText(n *big.Int) string {
+	var b = n.Bytes()
+	if len(b) == 0 {
+		b = []byte{0}
+	}
+
+	return hexText(b)
+}
@@ -1,6 +1,10 @@
 package extensions_test
 
 import (
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
 	"crypto/x509/pkix"
 	"errors"
 	"math/big"
@@ -37,21 +41,65 @@ func TestAuthorityKeyIdentifierMarshal(t *testing.T) {
 			},
 		},
 		{
-			name: "OK/IDAndSerialNumber",
+			name: "OK/IDIssuerAndSerialNumber",
 			ext: extensions.AuthorityKeyIdentifier{
 				Critical:     true,
 				ID:           []byte{1, 2, 3, 4},
+				Issuer:       pgasn1.GeneralNames{{DNSName: "ca"}},
 				SerialNumber: big.NewInt(42),
 			},
 			want: pkix.Extension{
 				Id:       pgasn1.OIDAuthorityKeyIdentifier,
 				Critical: true,
-				Value: []byte{asn1.TagSequence | bit6, 9,
+				Value: []byte{asn1.TagSequence | bit6, 15,
 					asn1.ClassContextSpecific << 6, 4, 1, 2, 3, 4,
+					asn1.ClassContextSpecific<<6 | bit6 | 0x01, 4,
+					asn1.ClassContextSpecific<<6 | 0x02, 2, 'c', 'a',
 					asn1.ClassContextSpecific<<6 | 0x02, 1, 42,
 				},
 			},
 		},
+		{
+			name: "OK/IssuerAndSerialNumber",
+			ext: extensions.AuthorityKeyIdentifier{
+				Issuer:       pgasn1.GeneralNames{{DNSName: "ca"}},
+				SerialNumber: big.NewInt(42),
+			},
+			want: pkix.Extension{
+				Id: pgasn1.OIDAuthorityKeyIdentifier,
+				Value: []byte{asn1.TagSequence | bit6, 9,
+					asn1.ClassContextSpecific<<6 | bit6 | 0x01, 4,
+					asn1.ClassContextSpecific<<6 | 0x02, 2, 'c', 'a',
+					asn1.ClassContextSpecific<<6 | 0x02, 1, 42,
+				},
+			},
+		},
+		{
+			name: "OK/EmptyID",
+			ext:  extensions.AuthorityKeyIdentifier{ID: []byte{}},
+			want: pkix.Extension{
+				Id:    pgasn1.OIDAuthorityKeyIdentifier,
+				Value: []byte{asn1.TagSequence | bit6, 2, asn1.ClassContextSpecific << 6, 0},
+			},
+		},
+		{
+			name: "SerialNumberWithoutIssuer",
+			ext: extensions.AuthorityKeyIdentifier{
+				ID:           []byte{1, 2, 3, 4},
+				SerialNumber: big.NewInt(42),
+			},
+			want: pkix.Extension{},
+			err:  errors.New("serial number without issuer"),
+		},
+		{
+			name: "IssuerWithoutSerialNumber",
+			ext: extensions.AuthorityKeyIdentifier{
+				ID:     []byte{1, 2, 3, 4},
+				Issuer: pgasn1.GeneralNames{{DNSName: "ca"}},
+			},
+			want: pkix.Extension{},
+			err:  errors.New("issuer without serial number"),
+		},
 		{
 			name: "Empty",
 			ext: extensions.AuthorityKeyIdentifier{
@@ -94,17 +142,72 @@ func TestAuthorityKeyIdentifierUnmarshal(t *testing.T) {
 			ext: pkix.Extension{
 				Id:       pgasn1.OIDAuthorityKeyIdentifier,
 				Critical: true,
-				Value: []byte{asn1.TagSequence | bit6, 9,
+				Value: []byte{asn1.TagSequence | bit6, 15,
 					asn1.ClassContextSpecific << 6, 4, 1, 2, 3, 4,
+					asn1.ClassContextSpecific<<6 | bit6 | 0x01, 4,
+					asn1.ClassContextSpecific<<6 | 0x02, 2, 'c', 'a',
 					asn1.ClassContextSpecific<<6 | 0x02, 1, 42,
 				},
 			},
 			want: extensions.AuthorityKeyIdentifier{
 				Critical:     true,
 				ID:           []byte{1, 2, 3, 4},
+				Issuer:       pgasn1.GeneralNames{{DNSName: "ca"}},
 				SerialNumber: big.NewInt(42),
 			},
 		},
+		{
+			name: "OK/IssuerAndSerialNumber",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDAuthorityKeyIdentifier,
+				Value: []byte{asn1.TagSequence | bit6, 9,
+					asn1.ClassContextSpecific<<6 | bit6 | 0x01, 4,
+					asn1.ClassContextSpecific<<6 | 0x02, 2, 'c', 'a',
+					asn1.ClassContextSpecific<<6 | 0x02, 1, 42,
+				},
+			},
+			want: extensions.AuthorityKeyIdentifier{
+				Issuer:       pgasn1.GeneralNames{{DNSName: "ca"}},
+				SerialNumber: big.NewInt(42),
+			},
+		},
+		{
+			name: "SerialNumberWithoutIssuer",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDAuthorityKeyIdentifier,
+				Value: []byte{asn1.TagSequence | bit6, 9,
+					asn1.ClassContextSpecific << 6, 4, 1, 2, 3, 4,
+					asn1.ClassContextSpecific<<6 | 0x02, 1, 42,
+				},
+			},
+			want: extensions.AuthorityKeyIdentifier{},
+			err:  errors.New("serial number without issuer"),
+		},
+		{
+			name: "IssuerWithoutSerialNumber",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDAuthorityKeyIdentifier,
+				Value: []byte{asn1.TagSequence | bit6, 12,
+					asn1.ClassContextSpecific << 6, 4, 1, 2, 3, 4,
+					asn1.ClassContextSpecific<<6 | bit6 | 0x01, 4,
+					asn1.ClassContextSpecific<<6 | 0x02, 2, 'c', 'a',
+				},
+			},
+			want: extensions.AuthorityKeyIdentifier{},
+			err:  errors.New("issuer without serial number"),
+		},
+		{
+			name: "OutOfOrder",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDAuthorityKeyIdentifier,
+				Value: []byte{asn1.TagSequence | bit6, 9,
+					asn1.ClassContextSpecific<<6 | 0x02, 1, 42,
+					asn1.ClassContextSpecific << 6, 4, 1, 2, 3, 4,
+				},
+			},
+			want: extensions.AuthorityKeyIdentifier{},
+			err:  errors.New("elements out of order"),
+		},
 		{
 			name: "BadOID",
 			ext: pkix.Extension{
@@ -158,3 +261,81 @@ func TestAuthorityKeyIdentifierUnmarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestNewAuthorityKeyIdentifier(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("couldn't generate key: %v", err)
+	}
+
+	sha1ID, err := extensions.MakePublicKeyIdentifier(key.Public())
+	if err != nil {
+		t.Fatalf("couldn't make public key identifier: %v", err)
+	}
+
+	sha256ID, err := extensions.MakeKeyIdentifier(key.Public(), extensions.KeyIDMethodRFC7093Method1)
+	if err != nil {
+		t.Fatalf("couldn't make key identifier: %v", err)
+	}
+
+	var testcases = []struct {
+		name   string
+		issuer *x509.Certificate
+		method extensions.KeyIDMethod
+		want   extensions.AuthorityKeyIdentifier
+		err    error
+	}{
+		{
+			name:   "SubjectKeyIdentifier",
+			issuer: &x509.Certificate{PublicKey: key.Public(), SubjectKeyId: []byte{1, 2, 3, 4}},
+			method: extensions.KeyIDMethodRFC7093Method1,
+			want:   extensions.AuthorityKeyIdentifier{ID: []byte{1, 2, 3, 4}},
+		},
+		{
+			name:   "PublicKey/SPKISHA1",
+			issuer: &x509.Certificate{PublicKey: key.Public()},
+			method: extensions.KeyIDMethodSPKISHA1,
+			want:   extensions.AuthorityKeyIdentifier{ID: sha1ID},
+		},
+		{
+			name:   "PublicKey/RFC7093Method1",
+			issuer: &x509.Certificate{PublicKey: key.Public()},
+			method: extensions.KeyIDMethodRFC7093Method1,
+			want:   extensions.AuthorityKeyIdentifier{ID: sha256ID},
+		},
+		{
+			name:   "UnknownMethod",
+			issuer: &x509.Certificate{PublicKey: key.Public()},
+			method: extensions.KeyIDMethod(99),
+			err:    errors.New("unknown method"),
+		},
+		{
+			name:   "BadPublicKey",
+			issuer: &x509.Certificate{PublicKey: "not a key"},
+			err:    errors.New("bad public key"),
+		},
+		{
+			name: "NoIssuer",
+			err:  errors.New("no issuer"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := extensions.NewAuthorityKeyIdentifier(tc.issuer, tc.method)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,165 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+
+	"github.com/paulgriffiths/pki/asn1"
+)
+
+// ASIdentifiers represents an autonomous system identifier delegation
+// extension as defined in RFC 3779 section 3.2.
+type ASIdentifiers struct {
+	Critical bool
+	ASNum    asn1.ASIdentifierChoice
+	RDI      asn1.ASIdentifierChoice
+}
+
+// Marshal returns a pkix.Extension.
+func (e ASIdentifiers) Marshal() (pkix.Extension, error) {
+	der, err := e.value().Marshal()
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       asn1.OIDAutonomousSysIDs,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *ASIdentifiers) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(asn1.OIDAutonomousSysIDs) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var ae asn1.ASIdentifiers
+	if err := ae.Unmarshal(ext.Value); err != nil {
+		return err
+	}
+
+	*e = ASIdentifiers{
+		Critical: ext.Critical,
+		ASNum:    ae.ASNum,
+		RDI:      ae.RDI,
+	}
+
+	return nil
+}
+
+// Contains returns true if every AS identifier resource in child is also
+// among the resources in the extension. See asn1.ASIdentifiers.Contains.
+func (e ASIdentifiers) Contains(child ASIdentifiers) bool {
+	return e.value().Contains(child.value())
+}
+
+// Resolve returns a copy of the extension in which any inherited resources
+// are replaced with the resources of the same type from issuer. See
+// asn1.ASIdentifiers.Resolve.
+func (e ASIdentifiers) Resolve(issuer ASIdentifiers) (ASIdentifiers, error) {
+	ae, err := e.value().Resolve(issuer.value())
+	if err != nil {
+		return ASIdentifiers{}, err
+	}
+
+	return ASIdentifiers{
+		Critical: e.Critical,
+		ASNum:    ae.ASNum,
+		RDI:      ae.RDI,
+	}, nil
+}
+
+// value returns the extension value.
+func (e ASIdentifiers) value() asn1.ASIdentifiers {
+	return asn1.ASIdentifiers{
+		ASNum: e.ASNum,
+		RDI:   e.RDI,
+	}
+}
+
+// asIdentifiersJSON is the JSON representation of an ASIdentifiers.
+type asIdentifiersJSON struct {
+	Critical bool                     `json:"critical"`
+	ASNum    *asn1.ASIdentifierChoice `json:"asnum,omitempty"`
+	RDI      *asn1.ASIdentifierChoice `json:"rdi,omitempty"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with a "critical" member and optional "asnum" and "rdi" members. See
+// asn1.ASIdentifierChoice.MarshalJSON.
+func (e ASIdentifiers) MarshalJSON() ([]byte, error) {
+	var tmp = asIdentifiersJSON{Critical: e.Critical}
+
+	if !e.ASNum.IsEmpty() {
+		tmp.ASNum = &e.ASNum
+	}
+
+	if !e.RDI.IsEmpty() {
+		tmp.RDI = &e.RDI
+	}
+
+	return json.Marshal(tmp)
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *ASIdentifiers) UnmarshalJSON(b []byte) error {
+	var tmp asIdentifiersJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	var ext = ASIdentifiers{Critical: tmp.Critical}
+
+	if tmp.ASNum != nil {
+		ext.ASNum = *tmp.ASNum
+	}
+
+	if tmp.RDI != nil {
+		ext.RDI = *tmp.RDI
+	}
+
+	*e = ext
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e ASIdentifiers) String() string {
+	var lines []string
+
+	if e.ASNum.Inherit || len(e.ASNum.IDs) != 0 {
+		lines = append(lines, "Autonomous System Numbers:")
+		lines = append(lines, indent(asIdentifierChoiceText(e.ASNum))...)
+	}
+
+	if e.RDI.Inherit || len(e.RDI.IDs) != 0 {
+		lines = append(lines, "Routing Domain Identifiers:")
+		lines = append(lines, indent(asIdentifierChoiceText(e.RDI))...)
+	}
+
+	return text(asn1.OIDAutonomousSysIDs, e.Critical, lines...)
+}
+
+// asIdentifierChoiceText returns the text representation of a set of AS
+// identifiers.
+func asIdentifierChoiceText(c asn1.ASIdentifierChoice) []string {
+	if c.Inherit {
+		return []string{"inherit"}
+	}
+
+	var lines []string
+
+	for _, id := range c.IDs {
+		if id.Min == id.Max {
+			lines = append(lines, fmt.Sprintf("%d", id.Min))
+		} else {
+			lines = append(lines, fmt.Sprintf("%d-%d", id.Min, id.Max))
+		}
+	}
+
+	return lines
+}
@@ -0,0 +1,162 @@
+package extensions_test
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestASIdentifiersMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.ASIdentifiers
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.ASIdentifiers{
+				Critical: true,
+				ASNum: pgasn1.ASIdentifierChoice{
+					IDs: []pgasn1.ASIDOrRange{{Min: 64496, Max: 64511}},
+				},
+			},
+			want: pkix.Extension{
+				Id:       pgasn1.OIDAutonomousSysIDs,
+				Critical: true,
+				Value: []byte{asn1.TagSequence | bit6, 16,
+					asn1.ClassContextSpecific<<6 | bit6 | 0, 14,
+					asn1.TagSequence | bit6, 12,
+					asn1.TagSequence | bit6, 10,
+					asn1.TagInteger, 3, 0x00, 0xfb, 0xf0,
+					asn1.TagInteger, 3, 0x00, 0xfb, 0xff,
+				},
+			},
+		},
+		{
+			name: "Empty",
+			ext:  extensions.ASIdentifiers{},
+			want: pkix.Extension{},
+			err:  errors.New("empty"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestASIdentifiersUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.ASIdentifiers
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDAutonomousSysIDs,
+				Critical: true,
+				Value: []byte{asn1.TagSequence | bit6, 4,
+					asn1.ClassContextSpecific<<6 | bit6 | 1, 2,
+					asn1.TagNull, 0,
+				},
+			},
+			want: extensions.ASIdentifiers{
+				Critical: true,
+				RDI:      pgasn1.ASIdentifierChoice{Inherit: true},
+			},
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDIPAddrBlocks,
+				Value: []byte{asn1.TagSequence | bit6, 4,
+					asn1.ClassContextSpecific<<6 | bit6 | 1, 2,
+					asn1.TagNull, 0,
+				},
+			},
+			want: extensions.ASIdentifiers{},
+			err:  errors.New("bad OID"),
+		},
+		{
+			name: "BadASN1",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDAutonomousSysIDs,
+				Value: []byte{0xff},
+			},
+			want: extensions.ASIdentifiers{},
+			err:  errors.New("bad ASN.1"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.ASIdentifiers
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestASIdentifiersResolveContains(t *testing.T) {
+	t.Parallel()
+
+	var issuer = extensions.ASIdentifiers{
+		ASNum: pgasn1.ASIdentifierChoice{
+			IDs: []pgasn1.ASIDOrRange{{Min: 64496, Max: 64511}},
+		},
+	}
+
+	var child = extensions.ASIdentifiers{
+		ASNum: pgasn1.ASIdentifierChoice{Inherit: true},
+	}
+
+	if !issuer.Contains(child) {
+		t.Fatalf("issuer does not contain child")
+	}
+
+	resolved, err := child.Resolve(issuer)
+	if err != nil {
+		t.Fatalf("couldn't resolve: %v", err)
+	}
+
+	if !reflect.DeepEqual(resolved, issuer) {
+		t.Errorf("got %v, want %v", resolved, issuer)
+	}
+}
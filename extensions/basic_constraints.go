@@ -2,6 +2,7 @@ package extensions
 
 import (
 	"crypto/x509/pkix"
+	"encoding/json"
 	"fmt"
 
 	"github.com/paulgriffiths/pki/asn1"
@@ -53,3 +54,64 @@ func (e *BasicConstraints) Unmarshal(ext pkix.Extension) error {
 
 	return nil
 }
+
+// basicConstraintsJSON is the JSON representation of a BasicConstraints.
+type basicConstraintsJSON struct {
+	Critical          bool `json:"critical"`
+	CA                bool `json:"ca"`
+	PathLenConstraint *int `json:"pathLenConstraint,omitempty"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "ca" members and an optional "pathLenConstraint"
+// integer, which is omitted if MaxPathLen is -1.
+func (e BasicConstraints) MarshalJSON() ([]byte, error) {
+	var tmp = basicConstraintsJSON{
+		Critical: e.Critical,
+		CA:       e.IsCA,
+	}
+
+	if e.MaxPathLen != -1 {
+		var n = e.MaxPathLen
+		tmp.PathLenConstraint = &n
+	}
+
+	return json.Marshal(tmp)
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *BasicConstraints) UnmarshalJSON(b []byte) error {
+	var tmp basicConstraintsJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	var ext = BasicConstraints{
+		Critical:   tmp.Critical,
+		IsCA:       tmp.CA,
+		MaxPathLen: -1,
+	}
+
+	if tmp.PathLenConstraint != nil {
+		ext.MaxPathLen = *tmp.PathLenConstraint
+	}
+
+	*e = ext
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e BasicConstraints) String() string {
+	var s = "CA:FALSE"
+	if e.IsCA {
+		s = "CA:TRUE"
+	}
+
+	if e.MaxPathLen >= 0 {
+		s += fmt.Sprintf(", pathlen:%d", e.MaxPathLen)
+	}
+
+	return text(asn1.OIDBasicConstraints, e.Critical, s)
+}
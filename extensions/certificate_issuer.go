@@ -0,0 +1,68 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/json"
+	"strings"
+
+	"github.com/paulgriffiths/pki/asn1"
+)
+
+// CertificateIssuer represents an X509 CRL entry certificate issuer extension
+// as defined in RFC 5280 section 5.3.3.
+type CertificateIssuer struct {
+	Critical bool
+	asn1.GeneralNames
+}
+
+// Marshal returns a pkix.Extension.
+func (e CertificateIssuer) Marshal() (pkix.Extension, error) {
+	return marshalGeneralNames(asn1.OIDCertificateIssuer, e.Critical, e.GeneralNames)
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *CertificateIssuer) Unmarshal(ext pkix.Extension) error {
+	names, err := unmarshalGeneralNames(asn1.OIDCertificateIssuer, ext)
+	if err != nil {
+		return err
+	}
+
+	*e = CertificateIssuer{
+		Critical:     ext.Critical,
+		GeneralNames: names,
+	}
+
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "names" members. See asn1.GeneralNames.MarshalJSON.
+func (e CertificateIssuer) MarshalJSON() ([]byte, error) {
+	return json.Marshal(generalNamesJSON{
+		Critical: e.Critical,
+		Names:    e.GeneralNames,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *CertificateIssuer) UnmarshalJSON(b []byte) error {
+	var tmp generalNamesJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = CertificateIssuer{
+		Critical:     tmp.Critical,
+		GeneralNames: tmp.Names,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e CertificateIssuer) String() string {
+	var names = generalNamesText(e.GeneralNames)
+
+	return text(asn1.OIDCertificateIssuer, e.Critical, strings.Join(names, ", "))
+}
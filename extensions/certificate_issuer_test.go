@@ -0,0 +1,131 @@
+package extensions_test
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestCertificateIssuerMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.CertificateIssuer
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.CertificateIssuer{
+				Critical: true,
+				GeneralNames: pgasn1.GeneralNames{
+					{DirectoryName: pkix.RDNSequence{{{Type: asn1.ObjectIdentifier{2, 5, 4, 3}, Value: "CA"}}}},
+				},
+			},
+			want: pkix.Extension{
+				Id:       pgasn1.OIDCertificateIssuer,
+				Critical: true,
+				Value: []byte{asn1.TagSequence | bit6, 17,
+					nameTagDirectoryName | asn1.ClassContextSpecific<<6 | bit6, 15,
+					asn1.TagSequence | bit6, 13,
+					asn1.TagSet | bit6, 11,
+					asn1.TagSequence | bit6, 9,
+					asn1.TagOID, 3, 0x55, 0x04, 0x03,
+					asn1.TagPrintableString, 2, 'C', 'A',
+				},
+			},
+		},
+		{
+			name: "Empty",
+			ext:  extensions.CertificateIssuer{Critical: true},
+			want: pkix.Extension{},
+			err:  errors.New("no names"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCertificateIssuerUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.CertificateIssuer
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDCertificateIssuer,
+				Critical: true,
+				Value: []byte{asn1.TagSequence | bit6, 17,
+					nameTagDirectoryName | asn1.ClassContextSpecific<<6 | bit6, 15,
+					asn1.TagSequence | bit6, 13,
+					asn1.TagSet | bit6, 11,
+					asn1.TagSequence | bit6, 9,
+					asn1.TagOID, 3, 0x55, 0x04, 0x03,
+					asn1.TagPrintableString, 2, 'C', 'A',
+				},
+			},
+			want: extensions.CertificateIssuer{
+				Critical: true,
+				GeneralNames: pgasn1.GeneralNames{
+					{DirectoryName: pkix.RDNSequence{{{Type: asn1.ObjectIdentifier{2, 5, 4, 3}, Value: "CA"}}}},
+				},
+			},
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDIssuerAltName,
+				Critical: true,
+				Value:    []byte{asn1.TagSequence | bit6, 0},
+			},
+			want: extensions.CertificateIssuer{},
+			err:  errors.New("bad OID"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.CertificateIssuer
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,86 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+
+	"github.com/paulgriffiths/pki/asn1"
+)
+
+// CertificatePolicies represents an X509 certificate policies extension as
+// defined in RFC 5280 section 4.2.1.4.
+type CertificatePolicies struct {
+	Critical bool
+	Policies []asn1.PolicyInformation
+}
+
+// Marshal returns a pkix.Extension.
+func (e CertificatePolicies) Marshal() (pkix.Extension, error) {
+	der, err := asn1.CertificatePolicies(e.Policies).Marshal()
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       asn1.OIDCertificatePolicies,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *CertificatePolicies) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(asn1.OIDCertificatePolicies) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var ae asn1.CertificatePolicies
+	if err := ae.Unmarshal(ext.Value); err != nil {
+		return err
+	}
+
+	*e = CertificatePolicies{
+		Critical: ext.Critical,
+		Policies: ae,
+	}
+
+	return nil
+}
+
+// certificatePoliciesJSON is the JSON representation of a CertificatePolicies.
+type certificatePoliciesJSON struct {
+	Critical bool                     `json:"critical"`
+	Policies []asn1.PolicyInformation `json:"policies"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "policies" members. See
+// asn1.PolicyInformation.MarshalJSON.
+func (e CertificatePolicies) MarshalJSON() ([]byte, error) {
+	return json.Marshal(certificatePoliciesJSON{
+		Critical: e.Critical,
+		Policies: e.Policies,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *CertificatePolicies) UnmarshalJSON(b []byte) error {
+	var tmp certificatePoliciesJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = CertificatePolicies{
+		Critical: tmp.Critical,
+		Policies: tmp.Policies,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e CertificatePolicies) String() string {
+	return text(asn1.OIDCertificatePolicies, e.Critical, policiesText(e.Policies)...)
+}
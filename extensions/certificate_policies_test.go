@@ -0,0 +1,145 @@
+package extensions_test
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestCertificatePoliciesMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.CertificatePolicies
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.CertificatePolicies{
+				Policies: []pgasn1.PolicyInformation{
+					{
+						Policy: asn1.ObjectIdentifier{1, 2, 3, 4},
+						Qualifiers: []pgasn1.PolicyQualifierInfo{
+							{ID: pgasn1.OIDPolicyQualifierCPS, CPSURI: "http://c/"},
+						},
+					},
+				},
+			},
+			want: pkix.Extension{
+				Id: pgasn1.OIDCertificatePolicies,
+				Value: []byte{asn1.TagSequence | bit6, 32,
+					asn1.TagSequence | bit6, 30,
+					asn1.TagOID, 3, 0x2a, 0x03, 0x04,
+					asn1.TagSequence | bit6, 23,
+					asn1.TagSequence | bit6, 21,
+					asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x02, 0x01,
+					asn1.TagIA5String, 9, 'h', 't', 't', 'p', ':', '/', '/', 'c', '/',
+				},
+			},
+		},
+		{
+			name: "Empty",
+			ext:  extensions.CertificatePolicies{},
+			want: pkix.Extension{},
+			err:  errors.New("no policies"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCertificatePoliciesUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.CertificatePolicies
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDCertificatePolicies,
+				Value: []byte{asn1.TagSequence | bit6, 32,
+					asn1.TagSequence | bit6, 30,
+					asn1.TagOID, 3, 0x2a, 0x03, 0x04,
+					asn1.TagSequence | bit6, 23,
+					asn1.TagSequence | bit6, 21,
+					asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x02, 0x01,
+					asn1.TagIA5String, 9, 'h', 't', 't', 'p', ':', '/', '/', 'c', '/',
+				},
+			},
+			want: extensions.CertificatePolicies{
+				Policies: []pgasn1.PolicyInformation{
+					{
+						Policy: asn1.ObjectIdentifier{1, 2, 3, 4},
+						Qualifiers: []pgasn1.PolicyQualifierInfo{
+							{ID: pgasn1.OIDPolicyQualifierCPS, CPSURI: "http://c/"},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDKeyUsage,
+				Value: []byte{asn1.TagSequence | bit6, 0},
+			},
+			want: extensions.CertificatePolicies{},
+			err:  errors.New("bad OID"),
+		},
+		{
+			name: "BadASN1",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDCertificatePolicies,
+				Value: []byte{0xff},
+			},
+			want: extensions.CertificatePolicies{},
+			err:  errors.New("bad ASN.1"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.CertificatePolicies
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,89 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+
+	"github.com/paulgriffiths/pki/asn1"
+)
+
+// CRLDistributionPoints represents an X509 CRL distribution points extension
+// as defined in RFC 5280 section 4.2.1.13.
+type CRLDistributionPoints struct {
+	Critical           bool
+	DistributionPoints []asn1.DistributionPoint
+}
+
+// Marshal returns a pkix.Extension.
+func (e CRLDistributionPoints) Marshal() (pkix.Extension, error) {
+	der, err := asn1.CRLDistributionPoints(e.DistributionPoints).Marshal()
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       asn1.OIDCRLDistributionPoints,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *CRLDistributionPoints) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(asn1.OIDCRLDistributionPoints) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var ae asn1.CRLDistributionPoints
+	if err := ae.Unmarshal(ext.Value); err != nil {
+		return err
+	}
+
+	*e = CRLDistributionPoints{
+		Critical:           ext.Critical,
+		DistributionPoints: ae,
+	}
+
+	return nil
+}
+
+// crlDistributionPointsJSON is the JSON representation of a
+// CRLDistributionPoints.
+type crlDistributionPointsJSON struct {
+	Critical           bool                     `json:"critical"`
+	DistributionPoints []asn1.DistributionPoint `json:"distributionPoints"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "distributionPoints" members. See
+// asn1.DistributionPoint.MarshalJSON.
+func (e CRLDistributionPoints) MarshalJSON() ([]byte, error) {
+	return json.Marshal(crlDistributionPointsJSON{
+		Critical:           e.Critical,
+		DistributionPoints: e.DistributionPoints,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *CRLDistributionPoints) UnmarshalJSON(b []byte) error {
+	var tmp crlDistributionPointsJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = CRLDistributionPoints{
+		Critical:           tmp.Critical,
+		DistributionPoints: tmp.DistributionPoints,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e CRLDistributionPoints) String() string {
+	var lines = distributionPointsText(e.DistributionPoints)
+
+	return text(asn1.OIDCRLDistributionPoints, e.Critical, lines...)
+}
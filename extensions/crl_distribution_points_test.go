@@ -0,0 +1,157 @@
+package extensions_test
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestCRLDistributionPointsMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.CRLDistributionPoints
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.CRLDistributionPoints{
+				DistributionPoints: []pgasn1.DistributionPoint{
+					{
+						Name: pgasn1.DistributionPointName{
+							FullName: pgasn1.GeneralNames{
+								{URI: mustParseURI(t, "http://c/x")},
+							},
+						},
+					},
+				},
+			},
+			want: pkix.Extension{
+				Id: pgasn1.OIDCRLDistributionPoints,
+				Value: []byte{asn1.TagSequence | bit6, 18,
+					asn1.TagSequence | bit6, 16,
+					asn1.ClassContextSpecific<<6 | bit6 | 0, 14,
+					asn1.ClassContextSpecific<<6 | bit6 | 0, 12,
+					nameTagURI | asn1.ClassContextSpecific<<6, 10,
+					'h', 't', 't', 'p', ':', '/', '/', 'c', '/', 'x',
+				},
+			},
+		},
+		{
+			name: "Empty",
+			ext:  extensions.CRLDistributionPoints{},
+			want: pkix.Extension{},
+			err:  errors.New("no distribution points"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCRLDistributionPointsUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.CRLDistributionPoints
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDCRLDistributionPoints,
+				Value: []byte{asn1.TagSequence | bit6, 18,
+					asn1.TagSequence | bit6, 16,
+					asn1.ClassContextSpecific<<6 | bit6 | 0, 14,
+					asn1.ClassContextSpecific<<6 | bit6 | 0, 12,
+					nameTagURI | asn1.ClassContextSpecific<<6, 10,
+					'h', 't', 't', 'p', ':', '/', '/', 'c', '/', 'x',
+				},
+			},
+			want: extensions.CRLDistributionPoints{
+				DistributionPoints: []pgasn1.DistributionPoint{
+					{
+						Name: pgasn1.DistributionPointName{
+							FullName: pgasn1.GeneralNames{
+								{URI: mustParseURI(t, "http://c/x")},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDSubjectAltName,
+				Value: []byte{asn1.TagSequence | bit6, 0},
+			},
+			want: extensions.CRLDistributionPoints{},
+			err:  errors.New("bad OID"),
+		},
+		{
+			name: "BadASN1",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDCRLDistributionPoints,
+				Value: []byte{0xff},
+			},
+			want: extensions.CRLDistributionPoints{},
+			err:  errors.New("bad ASN.1"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.CRLDistributionPoints
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func mustParseURI(t *testing.T, s string) *url.URL {
+	t.Helper()
+
+	uri, err := url.Parse(s)
+	if err != nil {
+		t.Fatalf("couldn't parse URL: %v", err)
+	}
+
+	return uri
+}
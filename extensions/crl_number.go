@@ -0,0 +1,204 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math/big"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+// maxCRLNumberBits is the maximum bit length of a CRL number. Conforming CRL
+// issuers MUST NOT use CRL numbers longer than 20 octets, and a DER-encoded
+// non-negative INTEGER uses a leading zero octet if its high bit is set. See
+// RFC 5280 section 5.2.3.
+const maxCRLNumberBits = 20*8 - 1
+
+// CRLNumber represents an X509 CRL number extension as defined in RFC 5280
+// section 5.2.3.
+type CRLNumber struct {
+	Critical bool
+	Number   *big.Int
+}
+
+// DeltaCRLIndicator represents an X509 delta CRL indicator extension as
+// defined in RFC 5280 section 5.2.4. BaseCRLNumber is the CRL number of the
+// complete CRL used as the starting point for the delta CRL.
+type DeltaCRLIndicator struct {
+	Critical      bool
+	BaseCRLNumber *big.Int
+}
+
+// Marshal returns a pkix.Extension.
+func (e CRLNumber) Marshal() (pkix.Extension, error) {
+	return marshalCRLNumber(pgasn1.OIDCRLNumber, e.Critical, e.Number)
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *CRLNumber) Unmarshal(ext pkix.Extension) error {
+	n, err := unmarshalCRLNumber(pgasn1.OIDCRLNumber, ext)
+	if err != nil {
+		return err
+	}
+
+	*e = CRLNumber{
+		Critical: ext.Critical,
+		Number:   n,
+	}
+
+	return nil
+}
+
+// Marshal returns a pkix.Extension.
+func (e DeltaCRLIndicator) Marshal() (pkix.Extension, error) {
+	return marshalCRLNumber(pgasn1.OIDDeltaCRLIndicator, e.Critical, e.BaseCRLNumber)
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *DeltaCRLIndicator) Unmarshal(ext pkix.Extension) error {
+	n, err := unmarshalCRLNumber(pgasn1.OIDDeltaCRLIndicator, ext)
+	if err != nil {
+		return err
+	}
+
+	*e = DeltaCRLIndicator{
+		Critical:      ext.Critical,
+		BaseCRLNumber: n,
+	}
+
+	return nil
+}
+
+// marshalCRLNumber returns a pkix.Extension with the specified OID containing
+// a CRL number.
+func marshalCRLNumber(oid asn1.ObjectIdentifier, critical bool, n *big.Int) (pkix.Extension, error) {
+	if err := checkCRLNumber(n); err != nil {
+		return pkix.Extension{}, err
+	}
+
+	der, err := asn1.Marshal(n)
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       oid,
+		Critical: critical,
+		Value:    der,
+	}, nil
+}
+
+// unmarshalCRLNumber parses a CRL number from a pkix.Extension with the
+// specified OID.
+func unmarshalCRLNumber(oid asn1.ObjectIdentifier, ext pkix.Extension) (*big.Int, error) {
+	if !ext.Id.Equal(oid) {
+		return nil, fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var n *big.Int
+	if rest, err := asn1.Unmarshal(ext.Value, &n); err != nil {
+		return nil, err
+	} else if len(rest) > 0 {
+		return nil, ErrTrailingBytes
+	}
+
+	if err := checkCRLNumber(n); err != nil {
+		return nil, err
+	}
+
+	return n, nil
+}
+
+// checkCRLNumber returns an error if a CRL number is absent, negative, or
+// longer than 20 octets.
+func checkCRLNumber(n *big.Int) error {
+	if n == nil {
+		return errors.New("no CRL number specified")
+	}
+
+	if n.Sign() < 0 {
+		return fmt.Errorf("negative CRL number: %v", n)
+	}
+
+	if n.BitLen() > maxCRLNumberBits {
+		return fmt.Errorf("CRL number longer than 20 octets: %v", n)
+	}
+
+	return nil
+}
+
+// crlNumberJSON is the JSON representation of a CRLNumber.
+type crlNumberJSON struct {
+	Critical bool     `json:"critical"`
+	Number   *big.Int `json:"number"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "number" members. The CRL number is an integer.
+func (e CRLNumber) MarshalJSON() ([]byte, error) {
+	return json.Marshal(crlNumberJSON{
+		Critical: e.Critical,
+		Number:   e.Number,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *CRLNumber) UnmarshalJSON(b []byte) error {
+	var tmp crlNumberJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = CRLNumber{
+		Critical: tmp.Critical,
+		Number:   tmp.Number,
+	}
+
+	return nil
+}
+
+// deltaCRLIndicatorJSON is the JSON representation of a DeltaCRLIndicator.
+type deltaCRLIndicatorJSON struct {
+	Critical      bool     `json:"critical"`
+	BaseCRLNumber *big.Int `json:"baseCRLNumber"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "baseCRLNumber" members. The base CRL number is an
+// integer.
+func (e DeltaCRLIndicator) MarshalJSON() ([]byte, error) {
+	return json.Marshal(deltaCRLIndicatorJSON{
+		Critical:      e.Critical,
+		BaseCRLNumber: e.BaseCRLNumber,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *DeltaCRLIndicator) UnmarshalJSON(b []byte) error {
+	var tmp deltaCRLIndicatorJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = DeltaCRLIndicator{
+		Critical:      tmp.Critical,
+		BaseCRLNumber: tmp.BaseCRLNumber,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e CRLNumber) String() string {
+	return text(pgasn1.OIDCRLNumber, e.Critical, e.Number.String())
+}
+
+// String returns a text representation of the extension. See Text.
+func (e DeltaCRLIndicator) String() string {
+	return text(pgasn1.OIDDeltaCRLIndicator, e.Critical, e.BaseCRLNumber.String())
+}
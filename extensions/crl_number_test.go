@@ -0,0 +1,245 @@
+package extensions_test
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestCRLNumberMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.CRLNumber
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext:  extensions.CRLNumber{Number: big.NewInt(0x80)},
+			want: pkix.Extension{
+				Id:    pgasn1.OIDCRLNumber,
+				Value: []byte{asn1.TagInteger, 2, 0x00, 0x80},
+			},
+		},
+		{
+			name: "TwentyOctets",
+			ext: extensions.CRLNumber{
+				Number: new(big.Int).SetBytes(append([]byte{0x7f}, bytes.Repeat([]byte{0xff}, 19)...)),
+			},
+			want: pkix.Extension{
+				Id:    pgasn1.OIDCRLNumber,
+				Value: append([]byte{asn1.TagInteger, 20, 0x7f}, bytes.Repeat([]byte{0xff}, 19)...),
+			},
+		},
+		{
+			name: "TooLong",
+			ext:  extensions.CRLNumber{Number: new(big.Int).Lsh(big.NewInt(1), 159)},
+			want: pkix.Extension{},
+			err:  errors.New("longer than 20 octets"),
+		},
+		{
+			name: "Negative",
+			ext:  extensions.CRLNumber{Number: big.NewInt(-1)},
+			want: pkix.Extension{},
+			err:  errors.New("negative"),
+		},
+		{
+			name: "Missing",
+			ext:  extensions.CRLNumber{},
+			want: pkix.Extension{},
+			err:  errors.New("missing"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCRLNumberUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.CRLNumber
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDCRLNumber,
+				Value: []byte{asn1.TagInteger, 2, 0x01, 0x00},
+			},
+			want: extensions.CRLNumber{Number: big.NewInt(256)},
+		},
+		{
+			name: "Negative",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDCRLNumber,
+				Value: []byte{asn1.TagInteger, 1, 0xff},
+			},
+			want: extensions.CRLNumber{},
+			err:  errors.New("negative"),
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDDeltaCRLIndicator,
+				Value: []byte{asn1.TagInteger, 1, 0x01},
+			},
+			want: extensions.CRLNumber{},
+			err:  errors.New("bad OID"),
+		},
+		{
+			name: "TrailingBytes",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDCRLNumber,
+				Value: []byte{asn1.TagInteger, 1, 0x01, 0x00},
+			},
+			want: extensions.CRLNumber{},
+			err:  errors.New("trailing bytes"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.CRLNumber
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDeltaCRLIndicatorMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.DeltaCRLIndicator
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.DeltaCRLIndicator{
+				Critical:      true,
+				BaseCRLNumber: big.NewInt(42),
+			},
+			want: pkix.Extension{
+				Id:       pgasn1.OIDDeltaCRLIndicator,
+				Critical: true,
+				Value:    []byte{asn1.TagInteger, 1, 42},
+			},
+		},
+		{
+			name: "Missing",
+			ext:  extensions.DeltaCRLIndicator{Critical: true},
+			want: pkix.Extension{},
+			err:  errors.New("missing"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestDeltaCRLIndicatorUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.DeltaCRLIndicator
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDDeltaCRLIndicator,
+				Critical: true,
+				Value:    []byte{asn1.TagInteger, 1, 42},
+			},
+			want: extensions.DeltaCRLIndicator{
+				Critical:      true,
+				BaseCRLNumber: big.NewInt(42),
+			},
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDCRLNumber,
+				Value: []byte{asn1.TagInteger, 1, 42},
+			},
+			want: extensions.DeltaCRLIndicator{},
+			err:  errors.New("bad OID"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.DeltaCRLIndicator
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
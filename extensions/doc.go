@@ -1,4 +1,38 @@
 /*
 Package extensions provides types representing X509 certificate extensions.
+
+# JSON encoding
+
+Every extension type implements json.Marshaler and json.Unmarshaler. An
+extension is encoded as a JSON object with a "critical" boolean member and
+members specific to the type of extension, which are described by its
+MarshalJSON method. The following conventions apply throughout:
+
+  - OIDs are strings containing either a name, such as "serverAuth", or the
+    dotted decimal representation of the OID. See asn1.OIDName.
+
+  - Key identifiers, nonces and other octet strings are hex strings.
+
+  - IP addresses, IP prefixes and URIs are strings.
+
+  - Values which have no other representation, such as the parameters of an
+    S/MIME capability, are hex strings containing their DER-encoding.
+
+  - Optional members are omitted when absent.
+
+Decoding the JSON encoding of an extension and marshalling the result
+produces the same DER-encoding as marshalling the original extension.
+
+# Text representation
+
+Every extension type implements fmt.Stringer, returning a human-readable
+representation in the style of the OpenSSL x509 -text command, such as:
+
+	X509v3 Key Usage: critical
+	    Digital Signature, Certificate Sign
+
+Extensions of unregistered types are shown with their value as an ASN.1
+dump in the style of the OpenSSL asn1parse command. See Text and
+Set.String.
 */
 package extensions
@@ -3,6 +3,7 @@ package extensions
 import (
 	"crypto/x509/pkix"
 	"encoding/asn1"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -54,3 +55,54 @@ func (e *ExtendedKeyUsage) Unmarshal(ext pkix.Extension) error {
 
 	return nil
 }
+
+// extendedKeyUsageJSON is the JSON representation of an ExtendedKeyUsage.
+type extendedKeyUsageJSON struct {
+	Critical bool     `json:"critical"`
+	Usages   []string `json:"usages"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "usages" members. The usages are a list of named or
+// dotted OIDs, such as "serverAuth".
+func (e ExtendedKeyUsage) MarshalJSON() ([]byte, error) {
+	var tmp = extendedKeyUsageJSON{
+		Critical: e.Critical,
+		Usages:   []string{},
+	}
+
+	for _, oid := range e.OIDs {
+		tmp.Usages = append(tmp.Usages, pgasn1.OIDName(oid))
+	}
+
+	return json.Marshal(tmp)
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *ExtendedKeyUsage) UnmarshalJSON(b []byte) error {
+	var tmp extendedKeyUsageJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	var ext = ExtendedKeyUsage{Critical: tmp.Critical}
+
+	for _, name := range tmp.Usages {
+		oid, err := pgasn1.ParseOIDName(name)
+		if err != nil {
+			return err
+		}
+
+		ext.OIDs = append(ext.OIDs, oid)
+	}
+
+	*e = ext
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e ExtendedKeyUsage) String() string {
+	return text(pgasn1.OIDExtendedKeyUsage, e.Critical, oidsText(e.OIDs))
+}
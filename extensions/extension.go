@@ -0,0 +1,91 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+// Extension is implemented by a pointer to each of the extension types in
+// this package.
+type Extension interface {
+	Marshal() (pkix.Extension, error)
+	Unmarshal(pkix.Extension) error
+}
+
+// Raw represents an extension of a type which is not registered. Value
+// contains the DER-encoded extension value.
+type Raw struct {
+	ID       asn1.ObjectIdentifier
+	Critical bool
+	Value    []byte
+}
+
+// Marshal returns a pkix.Extension.
+func (e Raw) Marshal() (pkix.Extension, error) {
+	return pkix.Extension{
+		Id:       e.ID,
+		Critical: e.Critical,
+		Value:    e.Value,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *Raw) Unmarshal(ext pkix.Extension) error {
+	*e = Raw{
+		ID:       ext.Id,
+		Critical: ext.Critical,
+		Value:    ext.Value,
+	}
+
+	return nil
+}
+
+// rawJSON is the JSON representation of a Raw.
+type rawJSON struct {
+	ID       string   `json:"id"`
+	Critical bool     `json:"critical"`
+	Value    hexBytes `json:"value"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "id", "critical" and "value" members. The ID is a named or dotted
+// OID, and the value is a hex string containing the DER-encoded extension
+// value.
+func (e Raw) MarshalJSON() ([]byte, error) {
+	return json.Marshal(rawJSON{
+		ID:       pgasn1.OIDName(e.ID),
+		Critical: e.Critical,
+		Value:    e.Value,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *Raw) UnmarshalJSON(b []byte) error {
+	var tmp rawJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	oid, err := pgasn1.ParseOIDName(tmp.ID)
+	if err != nil {
+		return err
+	}
+
+	*e = Raw{
+		ID:       oid,
+		Critical: tmp.Critical,
+		Value:    tmp.Value,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension, in which the value
+// is shown as an ASN.1 dump. See Text.
+func (e Raw) String() string {
+	return text(e.ID, e.Critical, dumpText(e.Value)...)
+}
@@ -0,0 +1,89 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+
+	"github.com/paulgriffiths/pki/asn1"
+)
+
+// FreshestCRL represents an X509 freshest CRL extension as defined in RFC
+// 5280 section 4.2.1.15. It uses the same syntax as the CRL distribution
+// points extension, and identifies how delta CRL information is obtained.
+type FreshestCRL struct {
+	Critical           bool
+	DistributionPoints []asn1.DistributionPoint
+}
+
+// Marshal returns a pkix.Extension.
+func (e FreshestCRL) Marshal() (pkix.Extension, error) {
+	der, err := asn1.CRLDistributionPoints(e.DistributionPoints).Marshal()
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       asn1.OIDFreshestCRL,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *FreshestCRL) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(asn1.OIDFreshestCRL) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var ae asn1.CRLDistributionPoints
+	if err := ae.Unmarshal(ext.Value); err != nil {
+		return err
+	}
+
+	*e = FreshestCRL{
+		Critical:           ext.Critical,
+		DistributionPoints: ae,
+	}
+
+	return nil
+}
+
+// freshestCRLJSON is the JSON representation of a FreshestCRL.
+type freshestCRLJSON struct {
+	Critical           bool                     `json:"critical"`
+	DistributionPoints []asn1.DistributionPoint `json:"distributionPoints"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "distributionPoints" members. See
+// asn1.DistributionPoint.MarshalJSON.
+func (e FreshestCRL) MarshalJSON() ([]byte, error) {
+	return json.Marshal(freshestCRLJSON{
+		Critical:           e.Critical,
+		DistributionPoints: e.DistributionPoints,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *FreshestCRL) UnmarshalJSON(b []byte) error {
+	var tmp freshestCRLJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = FreshestCRL{
+		Critical:           tmp.Critical,
+		DistributionPoints: tmp.DistributionPoints,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e FreshestCRL) String() string {
+	var lines = distributionPointsText(e.DistributionPoints)
+
+	return text(asn1.OIDFreshestCRL, e.Critical, lines...)
+}
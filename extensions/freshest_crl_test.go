@@ -0,0 +1,136 @@
+package extensions_test
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestFreshestCRLMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.FreshestCRL
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.FreshestCRL{
+				DistributionPoints: []pgasn1.DistributionPoint{
+					{
+						Name: pgasn1.DistributionPointName{
+							FullName: pgasn1.GeneralNames{
+								{URI: mustParseURI(t, "http://c/d")},
+							},
+						},
+					},
+				},
+			},
+			want: pkix.Extension{
+				Id: pgasn1.OIDFreshestCRL,
+				Value: []byte{asn1.TagSequence | bit6, 18,
+					asn1.TagSequence | bit6, 16,
+					asn1.ClassContextSpecific<<6 | bit6 | 0, 14,
+					asn1.ClassContextSpecific<<6 | bit6 | 0, 12,
+					nameTagURI | asn1.ClassContextSpecific<<6, 10,
+					'h', 't', 't', 'p', ':', '/', '/', 'c', '/', 'd',
+				},
+			},
+		},
+		{
+			name: "Empty",
+			ext:  extensions.FreshestCRL{},
+			want: pkix.Extension{},
+			err:  errors.New("no distribution points"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestFreshestCRLUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.FreshestCRL
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDFreshestCRL,
+				Value: []byte{asn1.TagSequence | bit6, 18,
+					asn1.TagSequence | bit6, 16,
+					asn1.ClassContextSpecific<<6 | bit6 | 0, 14,
+					asn1.ClassContextSpecific<<6 | bit6 | 0, 12,
+					nameTagURI | asn1.ClassContextSpecific<<6, 10,
+					'h', 't', 't', 'p', ':', '/', '/', 'c', '/', 'd',
+				},
+			},
+			want: extensions.FreshestCRL{
+				DistributionPoints: []pgasn1.DistributionPoint{
+					{
+						Name: pgasn1.DistributionPointName{
+							FullName: pgasn1.GeneralNames{
+								{URI: mustParseURI(t, "http://c/d")},
+							},
+						},
+					},
+				},
+			},
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDCRLDistributionPoints,
+				Value: []byte{asn1.TagSequence | bit6, 0},
+			},
+			want: extensions.FreshestCRL{},
+			err:  errors.New("bad OID"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.FreshestCRL
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
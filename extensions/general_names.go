@@ -0,0 +1,56 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"fmt"
+
+	goasn1 "encoding/asn1"
+
+	"github.com/paulgriffiths/pki/asn1"
+)
+
+// marshalGeneralNames returns a pkix.Extension with the specified OID whose
+// value is a GeneralNames sequence.
+func marshalGeneralNames(
+	oid goasn1.ObjectIdentifier,
+	critical bool,
+	names asn1.GeneralNames,
+) (pkix.Extension, error) {
+	if names.IsEmpty() {
+		return pkix.Extension{}, errors.New("no names specified")
+	}
+
+	der, err := names.Marshal()
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       oid,
+		Critical: critical,
+		Value:    der,
+	}, nil
+}
+
+// unmarshalGeneralNames parses a pkix.Extension with the specified OID whose
+// value is a GeneralNames sequence.
+func unmarshalGeneralNames(oid goasn1.ObjectIdentifier, ext pkix.Extension) (asn1.GeneralNames, error) {
+	if !ext.Id.Equal(oid) {
+		return asn1.GeneralNames{}, fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var names asn1.GeneralNames
+	if err := names.Unmarshal(ext.Value); err != nil {
+		return asn1.GeneralNames{}, err
+	}
+
+	return names, nil
+}
+
+// generalNamesJSON is the JSON representation of an extension whose value is
+// a GeneralNames sequence.
+type generalNamesJSON struct {
+	Critical bool              `json:"critical"`
+	Names    asn1.GeneralNames `json:"names"`
+}
@@ -0,0 +1,68 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/json"
+	"strings"
+
+	"github.com/paulgriffiths/pki/asn1"
+)
+
+// IssuerAltName represents an issuer alternative name extension as defined
+// in RFC5280 section 4.2.1.7.
+type IssuerAltName struct {
+	Critical bool
+	asn1.GeneralNames
+}
+
+// Marshal returns a pkix.Extension.
+func (e IssuerAltName) Marshal() (pkix.Extension, error) {
+	return marshalGeneralNames(asn1.OIDIssuerAltName, e.Critical, e.GeneralNames)
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *IssuerAltName) Unmarshal(ext pkix.Extension) error {
+	names, err := unmarshalGeneralNames(asn1.OIDIssuerAltName, ext)
+	if err != nil {
+		return err
+	}
+
+	*e = IssuerAltName{
+		Critical:     ext.Critical,
+		GeneralNames: names,
+	}
+
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "names" members. See asn1.GeneralNames.MarshalJSON.
+func (e IssuerAltName) MarshalJSON() ([]byte, error) {
+	return json.Marshal(generalNamesJSON{
+		Critical: e.Critical,
+		Names:    e.GeneralNames,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *IssuerAltName) UnmarshalJSON(b []byte) error {
+	var tmp generalNamesJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = IssuerAltName{
+		Critical:     tmp.Critical,
+		GeneralNames: tmp.Names,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e IssuerAltName) String() string {
+	var names = generalNamesText(e.GeneralNames)
+
+	return text(asn1.OIDIssuerAltName, e.Critical, strings.Join(names, ", "))
+}
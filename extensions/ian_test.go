@@ -0,0 +1,134 @@
+package extensions_test
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestIssuerAltNameMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.IssuerAltName
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.IssuerAltName{
+				Critical: true,
+				GeneralNames: pgasn1.GeneralNames{
+					{IPAddress: net.ParseIP("10.0.0.2")},
+				},
+			},
+			want: pkix.Extension{
+				Id:       pgasn1.OIDIssuerAltName,
+				Critical: true,
+				Value: []byte{asn1.TagSequence | bit6, 6,
+					nameTagIPAddress | asn1.ClassContextSpecific<<6, 4, 10, 0, 0, 2,
+				},
+			},
+		},
+		{
+			name: "Empty",
+			ext:  extensions.IssuerAltName{},
+			want: pkix.Extension{},
+			err:  errors.New("no names"),
+		},
+		{
+			name: "BadName",
+			ext: extensions.IssuerAltName{
+				GeneralNames: pgasn1.GeneralNames{
+					{DNSName: "..."},
+				},
+			},
+			want: pkix.Extension{},
+			err:  errors.New("bad name"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIssuerAltNameUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.IssuerAltName
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDIssuerAltName,
+				Critical: true,
+				Value: []byte{asn1.TagSequence | bit6, 6,
+					nameTagIPAddress | asn1.ClassContextSpecific<<6, 4, 10, 0, 0, 2,
+				},
+			},
+			want: extensions.IssuerAltName{
+				Critical: true,
+				GeneralNames: pgasn1.GeneralNames{
+					{IPAddress: net.ParseIP("10.0.0.2").To4()},
+				},
+			},
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDSubjectAltName,
+				Critical: true,
+				Value: []byte{asn1.TagSequence | bit6, 6,
+					nameTagIPAddress | asn1.ClassContextSpecific<<6, 4, 10, 0, 0, 2,
+				},
+			},
+			want: extensions.IssuerAltName{},
+			err:  errors.New("bad OID"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.IssuerAltName
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
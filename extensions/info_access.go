@@ -0,0 +1,221 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+	"net/url"
+
+	goasn1 "encoding/asn1"
+
+	"github.com/paulgriffiths/pki/asn1"
+)
+
+// AuthorityInfoAccess represents an X509 authority information access
+// extension as defined in RFC 5280 section 4.2.2.1.
+type AuthorityInfoAccess struct {
+	Critical     bool
+	Descriptions []asn1.AccessDescription
+}
+
+// SubjectInfoAccess represents an X509 subject information access extension
+// as defined in RFC 5280 section 4.2.2.2.
+type SubjectInfoAccess struct {
+	Critical     bool
+	Descriptions []asn1.AccessDescription
+}
+
+// Marshal returns a pkix.Extension.
+func (e AuthorityInfoAccess) Marshal() (pkix.Extension, error) {
+	return marshalInfoAccess(asn1.OIDAuthorityInfoAccess, e.Critical, e.Descriptions)
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *AuthorityInfoAccess) Unmarshal(ext pkix.Extension) error {
+	descs, err := unmarshalInfoAccess(asn1.OIDAuthorityInfoAccess, ext)
+	if err != nil {
+		return err
+	}
+
+	*e = AuthorityInfoAccess{
+		Critical:     ext.Critical,
+		Descriptions: descs,
+	}
+
+	return nil
+}
+
+// OCSPURLs returns the URIs of all OCSP responder access descriptions.
+func (e AuthorityInfoAccess) OCSPURLs() []*url.URL {
+	return accessURLs(e.Descriptions, asn1.OIDAccessMethodOCSP)
+}
+
+// IssuerURLs returns the URIs of all CA issuers access descriptions.
+func (e AuthorityInfoAccess) IssuerURLs() []*url.URL {
+	return accessURLs(e.Descriptions, asn1.OIDAccessMethodCAIssuers)
+}
+
+// Marshal returns a pkix.Extension.
+func (e SubjectInfoAccess) Marshal() (pkix.Extension, error) {
+	return marshalInfoAccess(asn1.OIDSubjectInfoAccess, e.Critical, e.Descriptions)
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *SubjectInfoAccess) Unmarshal(ext pkix.Extension) error {
+	descs, err := unmarshalInfoAccess(asn1.OIDSubjectInfoAccess, ext)
+	if err != nil {
+		return err
+	}
+
+	*e = SubjectInfoAccess{
+		Critical:     ext.Critical,
+		Descriptions: descs,
+	}
+
+	return nil
+}
+
+// CARepositoryURLs returns the URIs of all CA repository access descriptions.
+func (e SubjectInfoAccess) CARepositoryURLs() []*url.URL {
+	return accessURLs(e.Descriptions, asn1.OIDAccessMethodCARepository)
+}
+
+// TimeStampingURLs returns the URIs of all time stamping access descriptions.
+func (e SubjectInfoAccess) TimeStampingURLs() []*url.URL {
+	return accessURLs(e.Descriptions, asn1.OIDAccessMethodTimeStamping)
+}
+
+// marshalInfoAccess returns a pkix.Extension containing an information
+// access extension with the specified OID.
+func marshalInfoAccess(
+	oid goasn1.ObjectIdentifier,
+	critical bool,
+	descs []asn1.AccessDescription,
+) (pkix.Extension, error) {
+	der, err := asn1.InfoAccess(descs).Marshal()
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       oid,
+		Critical: critical,
+		Value:    der,
+	}, nil
+}
+
+// unmarshalInfoAccess parses the access descriptions from an information
+// access extension with the specified OID.
+func unmarshalInfoAccess(oid goasn1.ObjectIdentifier, ext pkix.Extension) ([]asn1.AccessDescription, error) {
+	if !ext.Id.Equal(oid) {
+		return nil, fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var ae asn1.InfoAccess
+	if err := ae.Unmarshal(ext.Value); err != nil {
+		return nil, err
+	}
+
+	return ae, nil
+}
+
+// accessURLs returns the URIs of all access descriptions with the specified
+// access method.
+func accessURLs(descs []asn1.AccessDescription, method goasn1.ObjectIdentifier) []*url.URL {
+	var urls []*url.URL
+
+	for _, desc := range descs {
+		if desc.Method.Equal(method) && desc.Location.URI != nil {
+			urls = append(urls, desc.Location.URI)
+		}
+	}
+
+	return urls
+}
+
+// authorityInfoAccessJSON is the JSON representation of an
+// AuthorityInfoAccess.
+type authorityInfoAccessJSON struct {
+	Critical     bool                     `json:"critical"`
+	Descriptions []asn1.AccessDescription `json:"descriptions"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "descriptions" members. See
+// asn1.AccessDescription.MarshalJSON.
+func (e AuthorityInfoAccess) MarshalJSON() ([]byte, error) {
+	return json.Marshal(authorityInfoAccessJSON{
+		Critical:     e.Critical,
+		Descriptions: e.Descriptions,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *AuthorityInfoAccess) UnmarshalJSON(b []byte) error {
+	var tmp authorityInfoAccessJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = AuthorityInfoAccess{
+		Critical:     tmp.Critical,
+		Descriptions: tmp.Descriptions,
+	}
+
+	return nil
+}
+
+// subjectInfoAccessJSON is the JSON representation of a SubjectInfoAccess.
+type subjectInfoAccessJSON struct {
+	Critical     bool                     `json:"critical"`
+	Descriptions []asn1.AccessDescription `json:"descriptions"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "descriptions" members. See
+// asn1.AccessDescription.MarshalJSON.
+func (e SubjectInfoAccess) MarshalJSON() ([]byte, error) {
+	return json.Marshal(subjectInfoAccessJSON{
+		Critical:     e.Critical,
+		Descriptions: e.Descriptions,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *SubjectInfoAccess) UnmarshalJSON(b []byte) error {
+	var tmp subjectInfoAccessJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = SubjectInfoAccess{
+		Critical:     tmp.Critical,
+		Descriptions: tmp.Descriptions,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e AuthorityInfoAccess) String() string {
+	return text(asn1.OIDAuthorityInfoAccess, e.Critical, accessDescriptionsText(e.Descriptions)...)
+}
+
+// String returns a text representation of the extension. See Text.
+func (e SubjectInfoAccess) String() string {
+	return text(asn1.OIDSubjectInfoAccess, e.Critical, accessDescriptionsText(e.Descriptions)...)
+}
+
+// accessDescriptionsText returns the text representation of a list of
+// access descriptions.
+func accessDescriptionsText(descs []asn1.AccessDescription) []string {
+	var lines []string
+
+	for _, d := range descs {
+		lines = append(lines, asn1.OIDLongName(d.Method)+" - "+generalNameText(d.Location))
+	}
+
+	return lines
+}
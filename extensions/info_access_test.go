@@ -0,0 +1,303 @@
+package extensions_test
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"net/url"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestAuthorityInfoAccessMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.AuthorityInfoAccess
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.AuthorityInfoAccess{
+				Descriptions: []pgasn1.AccessDescription{
+					{
+						Method:   pgasn1.OIDAccessMethodOCSP,
+						Location: pgasn1.GeneralName{URI: mustParseURI(t, "http://o/")},
+					},
+				},
+			},
+			want: pkix.Extension{
+				Id: pgasn1.OIDAuthorityInfoAccess,
+				Value: []byte{asn1.TagSequence | bit6, 23,
+					asn1.TagSequence | bit6, 21,
+					asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x30, 0x01,
+					nameTagURI | asn1.ClassContextSpecific<<6, 9,
+					'h', 't', 't', 'p', ':', '/', '/', 'o', '/',
+				},
+			},
+		},
+		{
+			name: "Empty",
+			ext:  extensions.AuthorityInfoAccess{},
+			want: pkix.Extension{},
+			err:  errors.New("no descriptions"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAuthorityInfoAccessUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.AuthorityInfoAccess
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDAuthorityInfoAccess,
+				Value: []byte{asn1.TagSequence | bit6, 23,
+					asn1.TagSequence | bit6, 21,
+					asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x30, 0x01,
+					nameTagURI | asn1.ClassContextSpecific<<6, 9,
+					'h', 't', 't', 'p', ':', '/', '/', 'o', '/',
+				},
+			},
+			want: extensions.AuthorityInfoAccess{
+				Descriptions: []pgasn1.AccessDescription{
+					{
+						Method:   pgasn1.OIDAccessMethodOCSP,
+						Location: pgasn1.GeneralName{URI: mustParseURI(t, "http://o/")},
+					},
+				},
+			},
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDSubjectInfoAccess,
+				Value: []byte{asn1.TagSequence | bit6, 23,
+					asn1.TagSequence | bit6, 21,
+					asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x30, 0x01,
+					nameTagURI | asn1.ClassContextSpecific<<6, 9,
+					'h', 't', 't', 'p', ':', '/', '/', 'o', '/',
+				},
+			},
+			want: extensions.AuthorityInfoAccess{},
+			err:  errors.New("bad OID"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.AuthorityInfoAccess
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestAuthorityInfoAccessURLs(t *testing.T) {
+	t.Parallel()
+
+	var ext = extensions.AuthorityInfoAccess{
+		Descriptions: []pgasn1.AccessDescription{
+			{
+				Method:   pgasn1.OIDAccessMethodOCSP,
+				Location: pgasn1.GeneralName{URI: mustParseURI(t, "http://o1/")},
+			},
+			{
+				Method:   pgasn1.OIDAccessMethodCAIssuers,
+				Location: pgasn1.GeneralName{URI: mustParseURI(t, "http://c/x")},
+			},
+			{
+				Method:   pgasn1.OIDAccessMethodOCSP,
+				Location: pgasn1.GeneralName{DNSName: "o.com"},
+			},
+			{
+				Method:   pgasn1.OIDAccessMethodOCSP,
+				Location: pgasn1.GeneralName{URI: mustParseURI(t, "http://o2/")},
+			},
+		},
+	}
+
+	var testcases = []struct {
+		name string
+		got  []*url.URL
+		want []*url.URL
+	}{
+		{
+			name: "OCSP",
+			got:  ext.OCSPURLs(),
+			want: []*url.URL{mustParseURI(t, "http://o1/"), mustParseURI(t, "http://o2/")},
+		},
+		{
+			name: "Issuer",
+			got:  ext.IssuerURLs(),
+			want: []*url.URL{mustParseURI(t, "http://c/x")},
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if !reflect.DeepEqual(tc.got, tc.want) {
+				t.Errorf("got %v, want %v", tc.got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSubjectInfoAccessMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.SubjectInfoAccess
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.SubjectInfoAccess{
+				Descriptions: []pgasn1.AccessDescription{
+					{
+						Method:   pgasn1.OIDAccessMethodCARepository,
+						Location: pgasn1.GeneralName{URI: mustParseURI(t, "rsync://r/")},
+					},
+				},
+			},
+			want: pkix.Extension{
+				Id: pgasn1.OIDSubjectInfoAccess,
+				Value: []byte{asn1.TagSequence | bit6, 24,
+					asn1.TagSequence | bit6, 22,
+					asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x30, 0x05,
+					nameTagURI | asn1.ClassContextSpecific<<6, 10,
+					'r', 's', 'y', 'n', 'c', ':', '/', '/', 'r', '/',
+				},
+			},
+		},
+		{
+			name: "Empty",
+			ext:  extensions.SubjectInfoAccess{},
+			want: pkix.Extension{},
+			err:  errors.New("no descriptions"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSubjectInfoAccessUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.SubjectInfoAccess
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDSubjectInfoAccess,
+				Value: []byte{asn1.TagSequence | bit6, 24,
+					asn1.TagSequence | bit6, 22,
+					asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x30, 0x05,
+					nameTagURI | asn1.ClassContextSpecific<<6, 10,
+					'r', 's', 'y', 'n', 'c', ':', '/', '/', 'r', '/',
+				},
+			},
+			want: extensions.SubjectInfoAccess{
+				Descriptions: []pgasn1.AccessDescription{
+					{
+						Method:   pgasn1.OIDAccessMethodCARepository,
+						Location: pgasn1.GeneralName{URI: mustParseURI(t, "rsync://r/")},
+					},
+				},
+			},
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDAuthorityInfoAccess,
+				Value: []byte{asn1.TagSequence | bit6, 0},
+			},
+			want: extensions.SubjectInfoAccess{},
+			err:  errors.New("bad OID"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.SubjectInfoAccess
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
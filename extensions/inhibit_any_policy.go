@@ -0,0 +1,96 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"fmt"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+// InhibitAnyPolicy represents an X509 inhibit anyPolicy extension as defined
+// in RFC 5280 section 4.2.1.14.
+type InhibitAnyPolicy struct {
+	Critical  bool
+	SkipCerts int
+}
+
+// Marshal returns a pkix.Extension.
+func (e InhibitAnyPolicy) Marshal() (pkix.Extension, error) {
+	if e.SkipCerts < 0 {
+		return pkix.Extension{}, fmt.Errorf("invalid skip certs value: %d", e.SkipCerts)
+	}
+
+	der, err := asn1.Marshal(e.SkipCerts)
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       pgasn1.OIDInhibitAnyPolicy,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *InhibitAnyPolicy) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(pgasn1.OIDInhibitAnyPolicy) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var skip int
+	if rest, err := asn1.Unmarshal(ext.Value, &skip); err != nil {
+		return err
+	} else if len(rest) > 0 {
+		return ErrTrailingBytes
+	}
+
+	if skip < 0 {
+		return fmt.Errorf("invalid skip certs value: %d", skip)
+	}
+
+	*e = InhibitAnyPolicy{
+		Critical:  ext.Critical,
+		SkipCerts: skip,
+	}
+
+	return nil
+}
+
+// inhibitAnyPolicyJSON is the JSON representation of an InhibitAnyPolicy.
+type inhibitAnyPolicyJSON struct {
+	Critical  bool `json:"critical"`
+	SkipCerts int  `json:"skipCerts"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "skipCerts" members.
+func (e InhibitAnyPolicy) MarshalJSON() ([]byte, error) {
+	return json.Marshal(inhibitAnyPolicyJSON{
+		Critical:  e.Critical,
+		SkipCerts: e.SkipCerts,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *InhibitAnyPolicy) UnmarshalJSON(b []byte) error {
+	var tmp inhibitAnyPolicyJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = InhibitAnyPolicy{
+		Critical:  tmp.Critical,
+		SkipCerts: tmp.SkipCerts,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e InhibitAnyPolicy) String() string {
+	return text(pgasn1.OIDInhibitAnyPolicy, e.Critical, fmt.Sprintf("%d", e.SkipCerts))
+}
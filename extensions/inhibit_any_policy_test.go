@@ -0,0 +1,127 @@
+package extensions_test
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestInhibitAnyPolicyMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.InhibitAnyPolicy
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext:  extensions.InhibitAnyPolicy{Critical: true, SkipCerts: 2},
+			want: pkix.Extension{
+				Id:       pgasn1.OIDInhibitAnyPolicy,
+				Critical: true,
+				Value:    []byte{asn1.TagInteger, 1, 2},
+			},
+		},
+		{
+			name: "Negative",
+			ext:  extensions.InhibitAnyPolicy{Critical: true, SkipCerts: -1},
+			want: pkix.Extension{},
+			err:  errors.New("negative"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInhibitAnyPolicyUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.InhibitAnyPolicy
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDInhibitAnyPolicy,
+				Critical: true,
+				Value:    []byte{asn1.TagInteger, 1, 0},
+			},
+			want: extensions.InhibitAnyPolicy{Critical: true, SkipCerts: 0},
+		},
+		{
+			name: "Negative",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDInhibitAnyPolicy,
+				Critical: true,
+				Value:    []byte{asn1.TagInteger, 1, 0xff},
+			},
+			want: extensions.InhibitAnyPolicy{},
+			err:  errors.New("negative"),
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDPolicyConstraints,
+				Critical: true,
+				Value:    []byte{asn1.TagInteger, 1, 0},
+			},
+			want: extensions.InhibitAnyPolicy{},
+			err:  errors.New("bad OID"),
+		},
+		{
+			name: "TrailingBytes",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDInhibitAnyPolicy,
+				Critical: true,
+				Value:    []byte{asn1.TagInteger, 1, 0, 0xff},
+			},
+			want: extensions.InhibitAnyPolicy{},
+			err:  errors.New("trailing bytes"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.InhibitAnyPolicy
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
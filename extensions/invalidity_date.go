@@ -0,0 +1,105 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+// InvalidityDate represents an X509 CRL entry invalidity date extension as
+// defined in RFC 5280 section 5.3.2.
+type InvalidityDate struct {
+	Critical bool
+	Time     time.Time
+}
+
+// Marshal returns a pkix.Extension.
+func (e InvalidityDate) Marshal() (pkix.Extension, error) {
+	if e.Time.IsZero() {
+		return pkix.Extension{}, errors.New("no invalidity date specified")
+	}
+
+	der, err := asn1.MarshalWithParams(e.Time.UTC(), "generalized")
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       pgasn1.OIDInvalidityDate,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *InvalidityDate) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(pgasn1.OIDInvalidityDate) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var raw asn1.RawValue
+	if rest, err := asn1.Unmarshal(ext.Value, &raw); err != nil {
+		return err
+	} else if len(rest) > 0 {
+		return ErrTrailingBytes
+	}
+
+	// encoding/asn1 accepts either time type when decoding into a time.Time,
+	// but RFC 5280 section 5.3.2 requires a GeneralizedTime.
+	if raw.Class != asn1.ClassUniversal || raw.Tag != asn1.TagGeneralizedTime {
+		return fmt.Errorf("unexpected invalidity date tag: %d", raw.Tag)
+	}
+
+	var t time.Time
+	if _, err := asn1.UnmarshalWithParams(raw.FullBytes, &t, "generalized"); err != nil {
+		return err
+	}
+
+	*e = InvalidityDate{
+		Critical: ext.Critical,
+		Time:     t,
+	}
+
+	return nil
+}
+
+// invalidityDateJSON is the JSON representation of an InvalidityDate.
+type invalidityDateJSON struct {
+	Critical bool      `json:"critical"`
+	Time     time.Time `json:"time"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "time" members. The time is an RFC 3339 string.
+func (e InvalidityDate) MarshalJSON() ([]byte, error) {
+	return json.Marshal(invalidityDateJSON{
+		Critical: e.Critical,
+		Time:     e.Time,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *InvalidityDate) UnmarshalJSON(b []byte) error {
+	var tmp invalidityDateJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = InvalidityDate{
+		Critical: tmp.Critical,
+		Time:     tmp.Time,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e InvalidityDate) String() string {
+	return text(pgasn1.OIDInvalidityDate, e.Critical, timeText(e.Time))
+}
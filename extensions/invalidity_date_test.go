@@ -0,0 +1,132 @@
+package extensions_test
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestInvalidityDateMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.InvalidityDate
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.InvalidityDate{
+				Time: time.Date(2020, 1, 2, 3, 4, 5, 0, time.FixedZone("CET", 3600)),
+			},
+			want: pkix.Extension{
+				Id: pgasn1.OIDInvalidityDate,
+				Value: []byte{asn1.TagGeneralizedTime, 15,
+					'2', '0', '2', '0', '0', '1', '0', '2', '0', '2', '0', '4', '0', '5', 'Z'},
+			},
+		},
+		{
+			name: "Empty",
+			ext:  extensions.InvalidityDate{},
+			want: pkix.Extension{},
+			err:  errors.New("no time"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestInvalidityDateUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.InvalidityDate
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDInvalidityDate,
+				Value: []byte{asn1.TagGeneralizedTime, 15,
+					'2', '0', '2', '0', '0', '1', '0', '2', '0', '3', '0', '4', '0', '5', 'Z'},
+			},
+			want: extensions.InvalidityDate{
+				Time: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			},
+		},
+		{
+			name: "UTCTime",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDInvalidityDate,
+				Value: []byte{asn1.TagUTCTime, 13,
+					'2', '0', '0', '1', '0', '2', '0', '3', '0', '4', '0', '5', 'Z'},
+			},
+			want: extensions.InvalidityDate{},
+			err:  errors.New("UTCTime"),
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDReasonCode,
+				Value: []byte{asn1.TagGeneralizedTime, 15,
+					'2', '0', '2', '0', '0', '1', '0', '2', '0', '3', '0', '4', '0', '5', 'Z'},
+			},
+			want: extensions.InvalidityDate{},
+			err:  errors.New("bad OID"),
+		},
+		{
+			name: "TrailingBytes",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDInvalidityDate,
+				Value: []byte{asn1.TagGeneralizedTime, 15,
+					'2', '0', '2', '0', '0', '1', '0', '2', '0', '3', '0', '4', '0', '5', 'Z', 0xff},
+			},
+			want: extensions.InvalidityDate{},
+			err:  errors.New("trailing bytes"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.InvalidityDate
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,161 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+
+	"github.com/paulgriffiths/pki/asn1"
+)
+
+// IPAddrBlocks represents an IP address delegation extension as defined in
+// RFC 3779 section 2.2.
+type IPAddrBlocks struct {
+	Critical bool
+	Families []asn1.IPAddressFamily
+}
+
+// Marshal returns a pkix.Extension.
+func (e IPAddrBlocks) Marshal() (pkix.Extension, error) {
+	der, err := asn1.IPAddrBlocks(e.Families).Marshal()
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       asn1.OIDIPAddrBlocks,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *IPAddrBlocks) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(asn1.OIDIPAddrBlocks) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var ae asn1.IPAddrBlocks
+	if err := ae.Unmarshal(ext.Value); err != nil {
+		return err
+	}
+
+	*e = IPAddrBlocks{
+		Critical: ext.Critical,
+		Families: ae,
+	}
+
+	return nil
+}
+
+// Contains returns true if every IP address resource in child is also among
+// the resources in the extension. See asn1.IPAddrBlocks.Contains.
+func (e IPAddrBlocks) Contains(child IPAddrBlocks) bool {
+	return asn1.IPAddrBlocks(e.Families).Contains(child.Families)
+}
+
+// Resolve returns a copy of the extension in which each inherited address
+// family is replaced with the same address family from issuer. See
+// asn1.IPAddrBlocks.Resolve.
+func (e IPAddrBlocks) Resolve(issuer IPAddrBlocks) (IPAddrBlocks, error) {
+	families, err := asn1.IPAddrBlocks(e.Families).Resolve(issuer.Families)
+	if err != nil {
+		return IPAddrBlocks{}, err
+	}
+
+	return IPAddrBlocks{
+		Critical: e.Critical,
+		Families: families,
+	}, nil
+}
+
+// ipAddrBlocksJSON is the JSON representation of an IPAddrBlocks.
+type ipAddrBlocksJSON struct {
+	Critical bool                   `json:"critical"`
+	Families []asn1.IPAddressFamily `json:"families"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "families" members. See
+// asn1.IPAddressFamily.MarshalJSON.
+func (e IPAddrBlocks) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ipAddrBlocksJSON{
+		Critical: e.Critical,
+		Families: e.Families,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *IPAddrBlocks) UnmarshalJSON(b []byte) error {
+	var tmp ipAddrBlocksJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = IPAddrBlocks{
+		Critical: tmp.Critical,
+		Families: tmp.Families,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e IPAddrBlocks) String() string {
+	var lines []string
+
+	for _, f := range e.Families {
+		var name = ipAddressFamilyText(f)
+
+		if f.Inherit {
+			lines = append(lines, name+": inherit")
+			continue
+		}
+
+		lines = append(lines, name+":")
+
+		for _, a := range f.Addresses {
+			if a.Prefix != nil {
+				lines = append(lines, nestedIndent+a.Prefix.String())
+			} else {
+				lines = append(lines, nestedIndent+a.Min.String()+"-"+a.Max.String())
+			}
+		}
+	}
+
+	return text(asn1.OIDIPAddrBlocks, e.Critical, lines...)
+}
+
+// ipAddressFamilyText returns the name of the address family and
+// subsequent address family identifier of an IP address family, such as
+// "IPv4" or "IPv6 (Unicast)".
+func ipAddressFamilyText(f asn1.IPAddressFamily) string {
+	var name string
+
+	switch f.AFI {
+	case asn1.AFIIPv4:
+		name = "IPv4"
+
+	case asn1.AFIIPv6:
+		name = "IPv6"
+
+	default:
+		name = fmt.Sprintf("Unknown AFI %d", f.AFI)
+	}
+
+	switch f.SAFI {
+	case 0:
+
+	case 1:
+		name += " (Unicast)"
+
+	case 2:
+		name += " (Multicast)"
+
+	default:
+		name += fmt.Sprintf(" (Unknown SAFI %d)", f.SAFI)
+	}
+
+	return name
+}
@@ -0,0 +1,193 @@
+package extensions_test
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"net"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestIPAddrBlocksMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.IPAddrBlocks
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.IPAddrBlocks{
+				Critical: true,
+				Families: []pgasn1.IPAddressFamily{
+					{
+						AFI: pgasn1.AFIIPv4,
+						Addresses: []pgasn1.IPAddressOrRange{
+							{Min: net.IP{10, 0, 0, 0}, Max: net.IP{10, 0, 2, 255}},
+						},
+					},
+				},
+			},
+			want: pkix.Extension{
+				Id:       pgasn1.OIDIPAddrBlocks,
+				Critical: true,
+				Value: []byte{asn1.TagSequence | bit6, 20,
+					asn1.TagSequence | bit6, 18,
+					asn1.TagOctetString, 2, 0x00, 0x01,
+					asn1.TagSequence | bit6, 12,
+					asn1.TagSequence | bit6, 10,
+					asn1.TagBitString, 2, 1, 0x0a,
+					asn1.TagBitString, 4, 0, 0x0a, 0x00, 0x02,
+				},
+			},
+		},
+		{
+			name: "Empty",
+			ext:  extensions.IPAddrBlocks{},
+			want: pkix.Extension{},
+			err:  errors.New("no families"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIPAddrBlocksUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.IPAddrBlocks
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDIPAddrBlocks,
+				Critical: true,
+				Value: []byte{asn1.TagSequence | bit6, 8,
+					asn1.TagSequence | bit6, 6,
+					asn1.TagOctetString, 2, 0x00, 0x02,
+					asn1.TagNull, 0,
+				},
+			},
+			want: extensions.IPAddrBlocks{
+				Critical: true,
+				Families: []pgasn1.IPAddressFamily{
+					{AFI: pgasn1.AFIIPv6, Inherit: true},
+				},
+			},
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDAutonomousSysIDs,
+				Value: []byte{asn1.TagSequence | bit6, 8,
+					asn1.TagSequence | bit6, 6,
+					asn1.TagOctetString, 2, 0x00, 0x02,
+					asn1.TagNull, 0,
+				},
+			},
+			want: extensions.IPAddrBlocks{},
+			err:  errors.New("bad OID"),
+		},
+		{
+			name: "BadASN1",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDIPAddrBlocks,
+				Value: []byte{0xff},
+			},
+			want: extensions.IPAddrBlocks{},
+			err:  errors.New("bad ASN.1"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.IPAddrBlocks
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIPAddrBlocksResolveContains(t *testing.T) {
+	t.Parallel()
+
+	var issuer = extensions.IPAddrBlocks{
+		Critical: true,
+		Families: []pgasn1.IPAddressFamily{
+			{
+				AFI: pgasn1.AFIIPv4,
+				Addresses: []pgasn1.IPAddressOrRange{
+					{Prefix: mustParseCIDR(t, "10.0.0.0/8")},
+				},
+			},
+		},
+	}
+
+	var child = extensions.IPAddrBlocks{
+		Critical: true,
+		Families: []pgasn1.IPAddressFamily{
+			{AFI: pgasn1.AFIIPv4, Inherit: true},
+		},
+	}
+
+	if !issuer.Contains(child) {
+		t.Fatalf("issuer does not contain child")
+	}
+
+	resolved, err := child.Resolve(issuer)
+	if err != nil {
+		t.Fatalf("couldn't resolve: %v", err)
+	}
+
+	if !reflect.DeepEqual(resolved, issuer) {
+		t.Errorf("got %v, want %v", resolved, issuer)
+	}
+}
+
+func mustParseCIDR(t *testing.T, s string) *net.IPNet {
+	t.Helper()
+
+	_, ipnet, err := net.ParseCIDR(s)
+	if err != nil {
+		t.Fatalf("couldn't parse CIDR: %v", err)
+	}
+
+	return ipnet
+}
@@ -0,0 +1,157 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+
+	"github.com/paulgriffiths/pki/asn1"
+)
+
+// IssuingDistributionPoint represents an X509 CRL issuing distribution point
+// extension as defined in RFC 5280 section 5.2.5. At most one of the
+// OnlyContains fields may be set.
+type IssuingDistributionPoint struct {
+	Critical                   bool
+	Name                       asn1.DistributionPointName
+	OnlyContainsUserCerts      bool
+	OnlyContainsCACerts        bool
+	OnlySomeReasons            asn1.ReasonFlags
+	IndirectCRL                bool
+	OnlyContainsAttributeCerts bool
+}
+
+// Marshal returns a pkix.Extension.
+func (e IssuingDistributionPoint) Marshal() (pkix.Extension, error) {
+	var ae = asn1.IssuingDistributionPoint{
+		Name:                       e.Name,
+		OnlyContainsUserCerts:      e.OnlyContainsUserCerts,
+		OnlyContainsCACerts:        e.OnlyContainsCACerts,
+		OnlySomeReasons:            e.OnlySomeReasons,
+		IndirectCRL:                e.IndirectCRL,
+		OnlyContainsAttributeCerts: e.OnlyContainsAttributeCerts,
+	}
+
+	der, err := ae.Marshal()
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       asn1.OIDIssuingDistributionPoint,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *IssuingDistributionPoint) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(asn1.OIDIssuingDistributionPoint) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var ae asn1.IssuingDistributionPoint
+	if err := ae.Unmarshal(ext.Value); err != nil {
+		return err
+	}
+
+	*e = IssuingDistributionPoint{
+		Critical:                   ext.Critical,
+		Name:                       ae.Name,
+		OnlyContainsUserCerts:      ae.OnlyContainsUserCerts,
+		OnlyContainsCACerts:        ae.OnlyContainsCACerts,
+		OnlySomeReasons:            ae.OnlySomeReasons,
+		IndirectCRL:                ae.IndirectCRL,
+		OnlyContainsAttributeCerts: ae.OnlyContainsAttributeCerts,
+	}
+
+	return nil
+}
+
+// issuingDistributionPointJSON is the JSON representation of an
+// IssuingDistributionPoint.
+type issuingDistributionPointJSON struct {
+	Critical                   bool                        `json:"critical"`
+	Name                       *asn1.DistributionPointName `json:"name,omitempty"`
+	OnlyContainsUserCerts      bool                        `json:"onlyContainsUserCerts,omitempty"`
+	OnlyContainsCACerts        bool                        `json:"onlyContainsCACerts,omitempty"`
+	OnlySomeReasons            asn1.ReasonFlags            `json:"onlySomeReasons,omitempty"`
+	IndirectCRL                bool                        `json:"indirectCRL,omitempty"`
+	OnlyContainsAttributeCerts bool                        `json:"onlyContainsAttributeCerts,omitempty"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with a "critical" member, an optional "name" member, and optional
+// "onlyContainsUserCerts", "onlyContainsCACerts", "onlySomeReasons",
+// "indirectCRL" and "onlyContainsAttributeCerts" members which are omitted
+// if false or empty. See asn1.DistributionPointName.MarshalJSON and
+// asn1.ReasonFlags.MarshalJSON.
+func (e IssuingDistributionPoint) MarshalJSON() ([]byte, error) {
+	var tmp = issuingDistributionPointJSON{
+		Critical:                   e.Critical,
+		OnlyContainsUserCerts:      e.OnlyContainsUserCerts,
+		OnlyContainsCACerts:        e.OnlyContainsCACerts,
+		OnlySomeReasons:            e.OnlySomeReasons,
+		IndirectCRL:                e.IndirectCRL,
+		OnlyContainsAttributeCerts: e.OnlyContainsAttributeCerts,
+	}
+
+	if !e.Name.IsEmpty() {
+		tmp.Name = &e.Name
+	}
+
+	return json.Marshal(tmp)
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *IssuingDistributionPoint) UnmarshalJSON(b []byte) error {
+	var tmp issuingDistributionPointJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	var ext = IssuingDistributionPoint{
+		Critical:                   tmp.Critical,
+		OnlyContainsUserCerts:      tmp.OnlyContainsUserCerts,
+		OnlyContainsCACerts:        tmp.OnlyContainsCACerts,
+		OnlySomeReasons:            tmp.OnlySomeReasons,
+		IndirectCRL:                tmp.IndirectCRL,
+		OnlyContainsAttributeCerts: tmp.OnlyContainsAttributeCerts,
+	}
+
+	if tmp.Name != nil {
+		ext.Name = *tmp.Name
+	}
+
+	*e = ext
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e IssuingDistributionPoint) String() string {
+	var lines = distributionPointNameText(e.Name)
+
+	if e.OnlyContainsUserCerts {
+		lines = append(lines, "Only User Certificates")
+	}
+
+	if e.OnlyContainsCACerts {
+		lines = append(lines, "Only CA Certificates")
+	}
+
+	if e.OnlyContainsAttributeCerts {
+		lines = append(lines, "Only Attribute Certificates")
+	}
+
+	if e.IndirectCRL {
+		lines = append(lines, "Indirect CRL")
+	}
+
+	if e.OnlySomeReasons != 0 {
+		lines = append(lines, "Only Some Reasons: "+reasonFlagsText(e.OnlySomeReasons))
+	}
+
+	return text(asn1.OIDIssuingDistributionPoint, e.Critical, lines...)
+}
@@ -0,0 +1,135 @@
+package extensions_test
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestIssuingDistributionPointMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.IssuingDistributionPoint
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.IssuingDistributionPoint{
+				Critical:            true,
+				OnlyContainsCACerts: true,
+				IndirectCRL:         true,
+			},
+			want: pkix.Extension{
+				Id:       pgasn1.OIDIssuingDistributionPoint,
+				Critical: true,
+				Value: []byte{asn1.TagSequence | bit6, 6,
+					asn1.ClassContextSpecific<<6 | 2, 1, 0xff,
+					asn1.ClassContextSpecific<<6 | 4, 1, 0xff,
+				},
+			},
+		},
+		{
+			name: "MutuallyExclusive",
+			ext: extensions.IssuingDistributionPoint{
+				Critical:                   true,
+				OnlyContainsCACerts:        true,
+				OnlyContainsAttributeCerts: true,
+			},
+			want: pkix.Extension{},
+			err:  errors.New("mutually exclusive"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestIssuingDistributionPointUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.IssuingDistributionPoint
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDIssuingDistributionPoint,
+				Critical: true,
+				Value: []byte{asn1.TagSequence | bit6, 4,
+					asn1.ClassContextSpecific<<6 | 3, 2, 7, 0x80,
+				},
+			},
+			want: extensions.IssuingDistributionPoint{
+				Critical:        true,
+				OnlySomeReasons: pgasn1.ReasonFlagUnused,
+			},
+		},
+		{
+			name: "MutuallyExclusive",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDIssuingDistributionPoint,
+				Critical: true,
+				Value: []byte{asn1.TagSequence | bit6, 6,
+					asn1.ClassContextSpecific<<6 | 1, 1, 0xff,
+					asn1.ClassContextSpecific<<6 | 2, 1, 0xff,
+				},
+			},
+			want: extensions.IssuingDistributionPoint{},
+			err:  errors.New("mutually exclusive"),
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDCRLDistributionPoints,
+				Value: []byte{asn1.TagSequence | bit6, 3, asn1.ClassContextSpecific<<6 | 4, 1, 0xff},
+			},
+			want: extensions.IssuingDistributionPoint{},
+			err:  errors.New("bad OID"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.IssuingDistributionPoint
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,32 @@
+package extensions
+
+import (
+	"encoding/hex"
+	"encoding/json"
+)
+
+// hexBytes is a byte slice which is encoded in JSON as a hex string.
+type hexBytes []byte
+
+// MarshalJSON returns the JSON encoding of a value.
+func (b hexBytes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(hex.EncodeToString(b))
+}
+
+// UnmarshalJSON parses a JSON-encoded value and stores the result in the
+// object.
+func (b *hexBytes) UnmarshalJSON(data []byte) error {
+	var s string
+	if err := json.Unmarshal(data, &s); err != nil {
+		return err
+	}
+
+	tmp, err := hex.DecodeString(s)
+	if err != nil {
+		return err
+	}
+
+	*b = tmp
+
+	return nil
+}
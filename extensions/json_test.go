@@ -0,0 +1,630 @@
+package extensions_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"github.com/paulgriffiths/pki/pkifile"
+	"math/big"
+	"net"
+	"reflect"
+	"testing"
+	"time"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestJSON(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.Extension
+		want string
+	}{
+		{
+			name: "ACMEIdentifier",
+			ext: &extensions.ACMEIdentifier{
+				Critical: true,
+				Digest:   make([]byte, 32),
+			},
+			want: `{"critical":true,"digest":"` +
+				"0000000000000000000000000000000000000000000000000000000000000000" + `"}`,
+		},
+		{
+			name: "AuthorityKeyIdentifier/EmptyID",
+			ext:  &extensions.AuthorityKeyIdentifier{ID: []byte{}},
+			want: `{"critical":false,"keyIdentifier":""}`,
+		},
+		{
+			name: "AuthorityKeyIdentifier",
+			ext: &extensions.AuthorityKeyIdentifier{
+				ID:           []byte{1, 2, 3, 4},
+				Issuer:       pgasn1.GeneralNames{{DNSName: "ca.example.com"}},
+				SerialNumber: big.NewInt(42),
+			},
+			want: `{"critical":false,"keyIdentifier":"01020304",` +
+				`"issuer":[{"dnsName":"ca.example.com"}],"serialNumber":42}`,
+		},
+		{
+			name: "ASIdentifiers",
+			ext: &extensions.ASIdentifiers{
+				Critical: true,
+				ASNum: pgasn1.ASIdentifierChoice{
+					IDs: []pgasn1.ASIDOrRange{
+						{Min: 64496, Max: 64496},
+						{Min: 64500, Max: 64510},
+					},
+				},
+				RDI: pgasn1.ASIdentifierChoice{Inherit: true},
+			},
+			want: `{"critical":true,"asnum":{"ids":["64496","64500-64510"]},"rdi":{"inherit":true}}`,
+		},
+		{
+			name: "BasicConstraints",
+			ext: &extensions.BasicConstraints{
+				Critical:   true,
+				IsCA:       true,
+				MaxPathLen: 0,
+			},
+			want: `{"critical":true,"ca":true,"pathLenConstraint":0}`,
+		},
+		{
+			name: "BasicConstraintsNoPathLen",
+			ext: &extensions.BasicConstraints{
+				Critical:   true,
+				IsCA:       true,
+				MaxPathLen: -1,
+			},
+			want: `{"critical":true,"ca":true}`,
+		},
+		{
+			name: "CertificateIssuer",
+			ext: &extensions.CertificateIssuer{
+				Critical: true,
+				GeneralNames: pgasn1.GeneralNames{
+					{DirectoryName: pkix.RDNSequence{{{Type: pgasn1.OIDCommonName, Value: "Test CA"}}}},
+				},
+			},
+			want: `{"critical":true,"names":[{"directoryName":[[{"type":"CN","value":"Test CA"}]]}]}`,
+		},
+		{
+			name: "CertificatePolicies",
+			ext: &extensions.CertificatePolicies{
+				Policies: []pgasn1.PolicyInformation{
+					{
+						Policy: asn1.ObjectIdentifier{2, 23, 140, 1, 2, 1},
+						Qualifiers: []pgasn1.PolicyQualifierInfo{
+							{
+								ID:     pgasn1.OIDPolicyQualifierCPS,
+								CPSURI: "http://example.com/cps",
+							},
+						},
+					},
+					{Policy: pgasn1.OIDAnyPolicy},
+				},
+			},
+			want: `{"critical":false,"policies":[{"policy":"2.23.140.1.2.1","qualifiers":` +
+				`[{"id":"id-qt-cps","cpsURI":"http://example.com/cps"}]},{"policy":"anyPolicy"}]}`,
+		},
+		{
+			name: "CertificatePolicies/RawUserNotice",
+			ext: &extensions.CertificatePolicies{
+				Policies: []pgasn1.PolicyInformation{
+					{
+						Policy: pgasn1.OIDAnyPolicy,
+						Qualifiers: []pgasn1.PolicyQualifierInfo{
+							{
+								ID: pgasn1.OIDPolicyQualifierUserNotice,
+								Raw: asn1.RawValue{
+									FullBytes: []byte{asn1.TagSequence | bit6, 2, asn1.TagUTF8String, 0},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: `{"critical":false,"policies":[{"policy":"anyPolicy","qualifiers":` +
+				`[{"id":"id-qt-unotice","raw":"30020c00"}]}]}`,
+		},
+		{
+			name: "CRLDistributionPoints",
+			ext: &extensions.CRLDistributionPoints{
+				DistributionPoints: []pgasn1.DistributionPoint{
+					{
+						Name: pgasn1.DistributionPointName{
+							FullName: pgasn1.GeneralNames{
+								{URI: mustParseURI(t, "http://example.com/ca.crl")},
+							},
+						},
+						Reasons: pgasn1.ReasonFlagKeyCompromise,
+					},
+				},
+			},
+			want: `{"critical":false,"distributionPoints":[{"name":{"fullName":` +
+				`[{"uri":"http://example.com/ca.crl"}]},"reasons":["keyCompromise"]}]}`,
+		},
+		{
+			name: "CRLNumber",
+			ext: &extensions.CRLNumber{
+				Number: big.NewInt(42),
+			},
+			want: `{"critical":false,"number":42}`,
+		},
+		{
+			name: "DeltaCRLIndicator",
+			ext: &extensions.DeltaCRLIndicator{
+				Critical:      true,
+				BaseCRLNumber: big.NewInt(41),
+			},
+			want: `{"critical":true,"baseCRLNumber":41}`,
+		},
+		{
+			name: "ExtendedKeyUsage",
+			ext: &extensions.ExtendedKeyUsage{
+				OIDs: []asn1.ObjectIdentifier{
+					pgasn1.OIDExtKeyUsageServerAuth,
+					pgasn1.OIDExtKeyUsageClientAuth,
+					{1, 3, 6, 1, 4, 1, 99999, 1},
+				},
+			},
+			want: `{"critical":false,"usages":["serverAuth","clientAuth","1.3.6.1.4.1.99999.1"]}`,
+		},
+		{
+			name: "FreshestCRL",
+			ext: &extensions.FreshestCRL{
+				DistributionPoints: []pgasn1.DistributionPoint{
+					{
+						Name: pgasn1.DistributionPointName{
+							FullName: pgasn1.GeneralNames{
+								{URI: mustParseURI(t, "http://example.com/delta.crl")},
+							},
+						},
+					},
+				},
+			},
+			want: `{"critical":false,"distributionPoints":[{"name":{"fullName":` +
+				`[{"uri":"http://example.com/delta.crl"}]}}]}`,
+		},
+		{
+			name: "IssuerAltName",
+			ext: &extensions.IssuerAltName{
+				GeneralNames: pgasn1.GeneralNames{
+					{EmailAddress: "ca@example.com"},
+				},
+			},
+			want: `{"critical":false,"names":[{"emailAddress":"ca@example.com"}]}`,
+		},
+		{
+			name: "AuthorityInfoAccess",
+			ext: &extensions.AuthorityInfoAccess{
+				Descriptions: []pgasn1.AccessDescription{
+					{
+						Method: pgasn1.OIDAccessMethodOCSP,
+						Location: pgasn1.GeneralName{
+							URI: mustParseURI(t, "http://ocsp.example.com"),
+						},
+					},
+				},
+			},
+			want: `{"critical":false,"descriptions":[{"method":"OCSP","location":{"uri":"http://ocsp.example.com"}}]}`,
+		},
+		{
+			name: "SubjectInfoAccess",
+			ext: &extensions.SubjectInfoAccess{
+				Descriptions: []pgasn1.AccessDescription{
+					{
+						Method: pgasn1.OIDAccessMethodCARepository,
+						Location: pgasn1.GeneralName{
+							URI: mustParseURI(t, "rsync://rpki.example.com/repo/"),
+						},
+					},
+				},
+			},
+			want: `{"critical":false,"descriptions":[{"method":"caRepository",` +
+				`"location":{"uri":"rsync://rpki.example.com/repo/"}}]}`,
+		},
+		{
+			name: "InhibitAnyPolicy",
+			ext: &extensions.InhibitAnyPolicy{
+				Critical:  true,
+				SkipCerts: 2,
+			},
+			want: `{"critical":true,"skipCerts":2}`,
+		},
+		{
+			name: "InvalidityDate",
+			ext: &extensions.InvalidityDate{
+				Time: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			},
+			want: `{"critical":false,"time":"2020-01-02T03:04:05Z"}`,
+		},
+		{
+			name: "IPAddrBlocks",
+			ext: &extensions.IPAddrBlocks{
+				Critical: true,
+				Families: []pgasn1.IPAddressFamily{
+					{
+						AFI: pgasn1.AFIIPv4,
+						Addresses: []pgasn1.IPAddressOrRange{
+							{Prefix: mustParseCIDR(t, "192.0.2.0/24")},
+							{Min: net.ParseIP("198.51.100.0").To4(), Max: net.ParseIP("198.51.100.127").To4()},
+						},
+					},
+					{
+						AFI:     pgasn1.AFIIPv6,
+						Inherit: true,
+					},
+				},
+			},
+			want: `{"critical":true,"families":[{"afi":1,"addresses":["192.0.2.0/24",` +
+				`"198.51.100.0-198.51.100.127"]},{"afi":2,"inherit":true}]}`,
+		},
+		{
+			name: "IssuingDistributionPoint",
+			ext: &extensions.IssuingDistributionPoint{
+				Critical: true,
+				Name: pgasn1.DistributionPointName{
+					FullName: pgasn1.GeneralNames{
+						{URI: mustParseURI(t, "http://example.com/ca.crl")},
+					},
+				},
+				OnlyContainsUserCerts: true,
+				OnlySomeReasons:       pgasn1.ReasonFlagKeyCompromise | pgasn1.ReasonFlagCACompromise,
+			},
+			want: `{"critical":true,"name":{"fullName":[{"uri":"http://example.com/ca.crl"}]},` +
+				`"onlyContainsUserCerts":true,"onlySomeReasons":["keyCompromise","cACompromise"]}`,
+		},
+		{
+			name: "KeyUsage",
+			ext: &extensions.KeyUsage{
+				Critical: true,
+				Value:    x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+			},
+			want: `{"critical":true,"usages":["digitalSignature","keyCertSign"]}`,
+		},
+		{
+			name: "CertificateTemplateName",
+			ext: &extensions.CertificateTemplateName{
+				Name: "SubCA",
+			},
+			want: `{"critical":false,"name":"SubCA"}`,
+		},
+		{
+			name: "CertificateTemplate",
+			ext: &extensions.CertificateTemplate{
+				ID:           asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 311, 21, 8, 1},
+				MajorVersion: 100,
+				MinorVersion: -1,
+			},
+			want: `{"critical":false,"id":"1.3.6.1.4.1.311.21.8.1","majorVersion":100}`,
+		},
+		{
+			name: "CAVersion",
+			ext: &extensions.CAVersion{
+				CertIndex: 1,
+				KeyIndex:  1,
+			},
+			want: `{"critical":false,"certIndex":1,"keyIndex":1}`,
+		},
+		{
+			name: "ApplicationPolicies",
+			ext: &extensions.ApplicationPolicies{
+				Policies: []pgasn1.PolicyInformation{
+					{Policy: pgasn1.OIDExtKeyUsageServerAuth},
+				},
+			},
+			want: `{"critical":false,"policies":[{"policy":"serverAuth"}]}`,
+		},
+		{
+			name: "NameConstraints",
+			ext: &extensions.NameConstraints{
+				Critical: true,
+				Permitted: []pgasn1.GeneralSubtree{
+					{DNSName: "example.com"},
+				},
+				Excluded: []pgasn1.GeneralSubtree{
+					{IPRange: mustParseCIDR(t, "10.0.0.0/8")},
+				},
+			},
+			want: `{"critical":true,"permitted":[{"dnsName":"example.com"}],"excluded":[{"ipRange":"10.0.0.0/8"}]}`,
+		},
+		{
+			name: "OCSPNoCheck",
+			ext:  &extensions.OCSPNoCheck{},
+			want: `{"critical":false}`,
+		},
+		{
+			name: "OCSPNonce",
+			ext: &extensions.OCSPNonce{
+				Nonce: []byte{0xde, 0xad, 0xbe, 0xef},
+			},
+			want: `{"critical":false,"nonce":"deadbeef"}`,
+		},
+		{
+			name: "PolicyConstraints",
+			ext: &extensions.PolicyConstraints{
+				Critical:              true,
+				RequireExplicitPolicy: 0,
+				InhibitPolicyMapping:  -1,
+			},
+			want: `{"critical":true,"requireExplicitPolicy":0}`,
+		},
+		{
+			name: "PolicyMappings",
+			ext: &extensions.PolicyMappings{
+				Critical: true,
+				Mappings: []extensions.PolicyMapping{
+					{
+						IssuerDomainPolicy:  asn1.ObjectIdentifier{1, 2, 3, 4},
+						SubjectDomainPolicy: asn1.ObjectIdentifier{1, 2, 3, 5},
+					},
+				},
+			},
+			want: `{"critical":true,"mappings":[{"issuerDomainPolicy":"1.2.3.4","subjectDomainPolicy":"1.2.3.5"}]}`,
+		},
+		{
+			name: "PrecertificatePoison",
+			ext:  &extensions.PrecertificatePoison{},
+			want: `{"critical":true}`,
+		},
+		{
+			name: "PrivateKeyUsagePeriod",
+			ext: &extensions.PrivateKeyUsagePeriod{
+				NotBefore: time.Date(2020, 1, 1, 0, 0, 0, 0, time.UTC),
+				NotAfter:  time.Date(2021, 1, 1, 0, 0, 0, 0, time.UTC),
+			},
+			want: `{"critical":false,"notBefore":"2020-01-01T00:00:00Z","notAfter":"2021-01-01T00:00:00Z"}`,
+		},
+		{
+			name: "QCStatements",
+			ext: &extensions.QCStatements{
+				Statements: []pgasn1.QCStatement{
+					{ID: pgasn1.OIDQCCompliance},
+					{ID: pgasn1.OIDQCRetentionPeriod, RetentionPeriod: 15},
+					{ID: pgasn1.OIDQCType, Types: []asn1.ObjectIdentifier{pgasn1.OIDQCTypeWeb}},
+				},
+			},
+			want: `{"critical":false,"statements":[{"id":"id-etsi-qcs-QcCompliance"},` +
+				`{"id":"id-etsi-qcs-QcRetentionPeriod","retentionPeriod":15},` +
+				`{"id":"id-etsi-qcs-QcType","types":["id-etsi-qct-web"]}]}`,
+		},
+		{
+			name: "ReasonCode",
+			ext: &extensions.ReasonCode{
+				Reason: extensions.CRLReasonKeyCompromise,
+			},
+			want: `{"critical":false,"reason":"keyCompromise"}`,
+		},
+		{
+			name: "SubjectAltName",
+			ext: &extensions.SubjectAltName{
+				GeneralNames: pgasn1.GeneralNames{
+					{DNSName: "example.com"},
+					{IPAddress: net.ParseIP("192.0.2.1").To4()},
+					{IPAddress: net.ParseIP("2001:db8::1")},
+					{URI: mustParseURI(t, "https://example.com/")},
+					{Raw: asn1.RawValue{Class: asn1.ClassContextSpecific, Tag: nameTagRegisteredID, Bytes: []byte{0x2a, 0x03, 0x04}}},
+				},
+			},
+			want: `{"critical":false,"names":[{"dnsName":"example.com"},` +
+				`{"ipAddress":"192.0.2.1"},{"ipAddress":"2001:db8::1"},{"uri":"https://example.com/"},` +
+				`{"raw":"88032a0304"}]}`,
+		},
+		{
+			name: "SubjectAltName/MixedOrder",
+			ext: &extensions.SubjectAltName{
+				GeneralNames: pgasn1.GeneralNames{
+					{URI: mustParseURI(t, "https://example.com/")},
+					{DNSName: "example.com"},
+					{IPAddress: net.ParseIP("192.0.2.1").To4()},
+					{DNSName: "www.example.com"},
+				},
+			},
+			want: `{"critical":false,"names":[{"uri":"https://example.com/"},` +
+				`{"dnsName":"example.com"},{"ipAddress":"192.0.2.1"},{"dnsName":"www.example.com"}]}`,
+		},
+		{
+			name: "SignedCertificateTimestampList",
+			ext: &extensions.SignedCertificateTimestampList{
+				SCTs: []extensions.SignedCertificateTimestamp{testSCT()},
+			},
+			want: `{"critical":false,"scts":[{"version":0,"logID":"` +
+				"1111111111111111111111111111111111111111111111111111111111111111" +
+				`","timestamp":1577934245678,"extensions":"aa","signature":` +
+				`{"hashAlgorithm":4,"signatureAlgorithm":3,"signature":"01020304"}}]}`,
+		},
+		{
+			name: "SignedCertificateTimestampList/UnknownVersion",
+			ext: &extensions.SignedCertificateTimestampList{
+				SCTs: []extensions.SignedCertificateTimestamp{
+					{Version: 2, Raw: []byte{2, 0xab, 0xcd}},
+				},
+			},
+			want: `{"critical":false,"scts":[{"version":2,"raw":"02abcd"}]}`,
+		},
+		{
+			name: "SubjectKeyIdentifier",
+			ext: &extensions.SubjectKeyIdentifier{
+				ID: []byte{1, 2, 3, 4},
+			},
+			want: `{"critical":false,"keyIdentifier":"01020304"}`,
+		},
+		{
+			name: "SMIMECapabilities",
+			ext: &extensions.SMIMECapabilities{
+				Capabilities: []pgasn1.SMIMECapability{
+					{ID: pgasn1.OIDAES256CBC},
+				},
+			},
+			want: `{"critical":false,"capabilities":[{"id":"AES-256-CBC"}]}`,
+		},
+		{
+			name: "SubjectDirectoryAttributes",
+			ext: &extensions.SubjectDirectoryAttributes{
+				Attributes: []pgasn1.Attribute{
+					{
+						Type:   pgasn1.OIDCountryOfCitizenship,
+						Values: []asn1.RawValue{{FullBytes: []byte{asn1.TagPrintableString, 2, 'G', 'B'}}},
+					},
+				},
+			},
+			want: `{"critical":false,"attributes":[{"type":"id-pda-countryOfCitizenship","values":["13024742"]}]}`,
+		},
+		{
+			name: "TLSFeature",
+			ext: &extensions.TLSFeature{
+				Features: []int{5},
+			},
+			want: `{"critical":false,"features":[5]}`,
+		},
+		{
+			name: "Raw",
+			ext: &extensions.Raw{
+				ID:       asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1},
+				Critical: true,
+				Value:    []byte{asn1.TagNull, 0},
+			},
+			want: `{"id":"1.3.6.1.4.1.99999.1","critical":true,"value":"0500"}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			b, err := json.Marshal(tc.ext)
+			if err != nil {
+				t.Fatalf("couldn't marshal JSON: %v", err)
+			}
+
+			if string(b) != tc.want {
+				t.Errorf("got %s, want %s", b, tc.want)
+			}
+
+			var got = reflect.New(reflect.TypeOf(tc.ext).Elem()).Interface().(extensions.Extension)
+			if err := json.Unmarshal(b, got); err != nil {
+				t.Fatalf("couldn't unmarshal JSON: %v", err)
+			}
+
+			if gotDER, wantDER := mustMarshal(t, got), mustMarshal(t, tc.ext); !reflect.DeepEqual(gotDER, wantDER) {
+				t.Errorf("got %v, want %v", gotDER, wantDER)
+			}
+		})
+	}
+}
+
+func TestUnmarshalJSONErrors(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.Extension
+		json string
+		err  error
+	}{
+		{
+			name: "UnknownKeyUsage",
+			ext:  &extensions.KeyUsage{},
+			json: `{"critical":true,"usages":["digitalSignature","signEverything"]}`,
+			err:  errors.New("unknown key usage"),
+		},
+		{
+			name: "UnknownOIDName",
+			ext:  &extensions.ExtendedKeyUsage{},
+			json: `{"critical":false,"usages":["serverAuthentication"]}`,
+			err:  errors.New("unknown OID name"),
+		},
+		{
+			name: "BadHex",
+			ext:  &extensions.SubjectKeyIdentifier{},
+			json: `{"critical":false,"keyIdentifier":"0x0102"}`,
+			err:  errors.New("bad hex"),
+		},
+		{
+			name: "BadIPAddress",
+			ext:  &extensions.SubjectAltName{},
+			json: `{"critical":false,"names":[{"ipAddress":"192.0.2"}]}`,
+			err:  errors.New("bad IP address"),
+		},
+		{
+			name: "UnknownReason",
+			ext:  &extensions.ReasonCode{},
+			json: `{"critical":false,"reason":"bored"}`,
+			err:  errors.New("unknown reason"),
+		},
+		{
+			name: "ShortLogID",
+			ext:  &extensions.SignedCertificateTimestampList{},
+			json: `{"critical":false,"scts":[{"version":0,"logID":"0102","timestamp":0,` +
+				`"signature":{"hashAlgorithm":4,"signatureAlgorithm":3,"signature":"00"}}]}`,
+			err: errors.New("short log ID"),
+		},
+		{
+			name: "NonCriticalPoison",
+			ext:  &extensions.PrecertificatePoison{},
+			json: `{"critical":false}`,
+			err:  errors.New("not critical"),
+		},
+		{
+			name: "OK",
+			ext:  &extensions.KeyUsage{},
+			json: `{"critical":true,"usages":["cRLSign"]}`,
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := json.Unmarshal([]byte(tc.json), tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+		})
+	}
+}
+
+func TestJSONFromCertificate(t *testing.T) {
+	t.Parallel()
+
+	cert, err := pkifile.CertFromPEMFile("testdata/openssl_cert.pem")
+	if err != nil {
+		t.Fatalf("couldn't read certificate: %v", err)
+	}
+
+	for _, ext := range cert.Extensions {
+		var ext = ext
+
+		t.Run(pgasn1.OIDName(ext.Id), func(t *testing.T) {
+			t.Parallel()
+
+			e, err := extensions.Parse(ext)
+			if err != nil {
+				t.Fatalf("couldn't parse extension: %v", err)
+			}
+
+			b, err := json.Marshal(e)
+			if err != nil {
+				t.Fatalf("couldn't marshal JSON: %v", err)
+			}
+
+			var got = reflect.New(reflect.TypeOf(e).Elem()).Interface().(extensions.Extension)
+			if err := json.Unmarshal(b, got); err != nil {
+				t.Fatalf("couldn't unmarshal JSON: %v", err)
+			}
+
+			if gotExt := mustMarshal(t, got); !reflect.DeepEqual(gotExt, ext) {
+				t.Errorf("got %x, want %x", gotExt.Value, ext.Value)
+			}
+		})
+	}
+}
@@ -5,9 +5,11 @@ import (
 	"crypto/x509/pkix"
 	"encoding/asn1"
 	"encoding/binary"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"math/bits"
+	"strings"
 
 	pgasn1 "github.com/paulgriffiths/pki/asn1"
 )
@@ -19,6 +21,38 @@ type KeyUsage struct {
 	Value    x509.KeyUsage
 }
 
+// numKeyUsages is the number of key usages defined in RFC 5280 section
+// 4.2.1.3.
+const numKeyUsages = 9
+
+// keyUsageNames contains the RFC 5280 names of the key usages, indexed by
+// bit number.
+var keyUsageNames = [numKeyUsages]string{
+	"digitalSignature",
+	"nonRepudiation",
+	"keyEncipherment",
+	"dataEncipherment",
+	"keyAgreement",
+	"keyCertSign",
+	"cRLSign",
+	"encipherOnly",
+	"decipherOnly",
+}
+
+// keyUsageTexts contains the OpenSSL names of the key usages, indexed by
+// bit number.
+var keyUsageTexts = [numKeyUsages]string{
+	"Digital Signature",
+	"Non Repudiation",
+	"Key Encipherment",
+	"Data Encipherment",
+	"Key Agreement",
+	"Certificate Sign",
+	"CRL Sign",
+	"Encipher Only",
+	"Decipher Only",
+}
+
 // Marshal returns a pkix.Extension.
 func (e KeyUsage) Marshal() (pkix.Extension, error) {
 
@@ -28,11 +62,22 @@ func (e KeyUsage) Marshal() (pkix.Extension, error) {
 		return pkix.Extension{}, errors.New("no key usages specified")
 	}
 
+	if e.Value < 0 || e.Value >= 1<<numKeyUsages {
+		return pkix.Extension{}, fmt.Errorf("invalid key usage: %#x", int(e.Value))
+	}
+
+	// DER requires trailing zero bits to be removed from a named bit list,
+	// so the bit string ends with the highest key usage which is set. See
+	// X.690 section 11.2.2.
+	var reversed = make([]byte, 2)
+	binary.BigEndian.PutUint16(reversed, bits.Reverse16(uint16(e.Value)))
+
+	var length = bits.Len16(uint16(e.Value))
+
 	bs := asn1.BitString{
-		BitLength: 9,
-		Bytes:     make([]byte, 2),
+		BitLength: length,
+		Bytes:     reversed[:(length+7)/8],
 	}
-	binary.BigEndian.PutUint16(bs.Bytes, bits.Reverse16(uint16(e.Value)))
 
 	der, err := asn1.Marshal(bs)
 	if err != nil {
@@ -59,10 +104,99 @@ func (e *KeyUsage) Unmarshal(ext pkix.Extension) error {
 		return ErrTrailingBytes
 	}
 
+	// DER requires trailing zero bits to be removed from a named bit list,
+	// so the bit string may be shorter than the nine defined key usages.
+	var value x509.KeyUsage
+	for i := 0; i < numKeyUsages; i++ {
+		if bs.At(i) != 0 {
+			value |= 1 << uint(i)
+		}
+	}
+
+	if value == 0 {
+		return errors.New("no key usages specified")
+	}
+
 	*e = KeyUsage{
 		Critical: ext.Critical,
-		Value:    x509.KeyUsage(bits.Reverse16(binary.BigEndian.Uint16(bs.Bytes)) & 0x1ff),
+		Value:    value,
 	}
 
 	return nil
 }
+
+// keyUsageJSON is the JSON representation of a KeyUsage.
+type keyUsageJSON struct {
+	Critical bool     `json:"critical"`
+	Usages   []string `json:"usages"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "usages" members. The usages are a list of the RFC
+// 5280 names of the key usages, such as "digitalSignature" and
+// "keyCertSign".
+func (e KeyUsage) MarshalJSON() ([]byte, error) {
+	if e.Value < 0 || e.Value >= 1<<numKeyUsages {
+		return nil, fmt.Errorf("invalid key usage: %#x", int(e.Value))
+	}
+
+	var tmp = keyUsageJSON{
+		Critical: e.Critical,
+		Usages:   []string{},
+	}
+
+	for i, name := range keyUsageNames {
+		if e.Value&(1<<uint(i)) != 0 {
+			tmp.Usages = append(tmp.Usages, name)
+		}
+	}
+
+	return json.Marshal(tmp)
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *KeyUsage) UnmarshalJSON(b []byte) error {
+	var tmp keyUsageJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	var value x509.KeyUsage
+
+	for _, name := range tmp.Usages {
+		var found bool
+
+		for i, n := range keyUsageNames {
+			if n == name {
+				value |= 1 << uint(i)
+				found = true
+				break
+			}
+		}
+
+		if !found {
+			return fmt.Errorf("unknown key usage: %q", name)
+		}
+	}
+
+	*e = KeyUsage{
+		Critical: tmp.Critical,
+		Value:    value,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e KeyUsage) String() string {
+	var names []string
+
+	for i, name := range keyUsageTexts {
+		if e.Value&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+
+	return text(pgasn1.OIDKeyUsage, e.Critical, strings.Join(names, ", "))
+}
@@ -58,7 +58,7 @@ func TestKeyUsageMarshal(t *testing.T) {
 			want: pkix.Extension{
 				Id:       pgasn1.OIDKeyUsage,
 				Critical: true,
-				Value:    []byte{asn1.TagBitString, 3, 7, 0x06, 0},
+				Value:    []byte{asn1.TagBitString, 2, 1, 0x06},
 			},
 		},
 	}
@@ -98,6 +98,7 @@ func TestKeyUsageUnmarshal(t *testing.T) {
 				Value:    []byte{asn1.TagBitString, 3, 7, 0, 0},
 			},
 			want: extensions.KeyUsage{},
+			err:  errors.New("no key usages specified"),
 		},
 		{
 			name: "All",
@@ -132,6 +133,27 @@ func TestKeyUsageUnmarshal(t *testing.T) {
 					x509.KeyUsageCRLSign,
 			},
 		},
+		{
+			name: "MinimalEncoding",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDKeyUsage,
+				Critical: true,
+				Value:    []byte{asn1.TagBitString, 2, 7, 0x80},
+			},
+			want: extensions.KeyUsage{
+				Critical: true,
+				Value:    x509.KeyUsageDigitalSignature,
+			},
+		},
+		{
+			name: "NoBits",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDKeyUsage,
+				Value: []byte{asn1.TagBitString, 1, 0},
+			},
+			want: extensions.KeyUsage{},
+			err:  errors.New("no key usages"),
+		},
 		{
 			name: "BadOID",
 			ext: pkix.Extension{
@@ -0,0 +1,390 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"fmt"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+// CertificateTemplateName represents a Microsoft version 1 certificate
+// template name extension, as issued by Active Directory Certificate
+// Services.
+type CertificateTemplateName struct {
+	Critical bool
+	Name     string
+}
+
+// CertificateTemplate represents a Microsoft version 2 certificate template
+// extension, as issued by Active Directory Certificate Services. A
+// MinorVersion of -1 indicates that the minor version is absent.
+type CertificateTemplate struct {
+	Critical     bool
+	ID           asn1.ObjectIdentifier
+	MajorVersion int64
+	MinorVersion int64
+}
+
+// CAVersion represents a Microsoft CA version extension, which identifies
+// the CA certificate and key pair in use after CA certificate renewals.
+type CAVersion struct {
+	Critical  bool
+	CertIndex int
+	KeyIndex  int
+}
+
+// ApplicationPolicies represents a Microsoft application policies extension.
+// It uses the same syntax as the certificate policies extension, and the
+// policy identifiers are usually extended key usage OIDs.
+type ApplicationPolicies struct {
+	Critical bool
+	Policies []pgasn1.PolicyInformation
+}
+
+// maxCAVersionIndex is the maximum CA certificate or key index, since each
+// is encoded in 16 bits.
+const maxCAVersionIndex = 1<<16 - 1
+
+// Marshal returns a pkix.Extension.
+func (e CertificateTemplateName) Marshal() (pkix.Extension, error) {
+	der, err := pgasn1.CertificateTemplateName(e.Name).Marshal()
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       pgasn1.OIDMSCertificateTemplateName,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *CertificateTemplateName) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(pgasn1.OIDMSCertificateTemplateName) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var ae pgasn1.CertificateTemplateName
+	if err := ae.Unmarshal(ext.Value); err != nil {
+		return err
+	}
+
+	*e = CertificateTemplateName{
+		Critical: ext.Critical,
+		Name:     string(ae),
+	}
+
+	return nil
+}
+
+// Marshal returns a pkix.Extension.
+func (e CertificateTemplate) Marshal() (pkix.Extension, error) {
+	var ae = pgasn1.CertificateTemplate{
+		ID:           e.ID,
+		MajorVersion: e.MajorVersion,
+		MinorVersion: e.MinorVersion,
+	}
+
+	der, err := ae.Marshal()
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       pgasn1.OIDMSCertificateTemplate,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *CertificateTemplate) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(pgasn1.OIDMSCertificateTemplate) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var ae pgasn1.CertificateTemplate
+	if err := ae.Unmarshal(ext.Value); err != nil {
+		return err
+	}
+
+	*e = CertificateTemplate{
+		Critical:     ext.Critical,
+		ID:           ae.ID,
+		MajorVersion: ae.MajorVersion,
+		MinorVersion: ae.MinorVersion,
+	}
+
+	return nil
+}
+
+// Marshal returns a pkix.Extension.
+func (e CAVersion) Marshal() (pkix.Extension, error) {
+	if e.CertIndex < 0 || e.CertIndex > maxCAVersionIndex {
+		return pkix.Extension{}, fmt.Errorf("invalid CA certificate index: %d", e.CertIndex)
+	}
+
+	if e.KeyIndex < 0 || e.KeyIndex > maxCAVersionIndex {
+		return pkix.Extension{}, fmt.Errorf("invalid CA key index: %d", e.KeyIndex)
+	}
+
+	// Compute the version in int64, since shifting a key index of 0x8000 or
+	// more would overflow a 32-bit int.
+	der, err := asn1.Marshal(int64(e.KeyIndex)<<16 | int64(e.CertIndex))
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       pgasn1.OIDMSCAVersion,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *CAVersion) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(pgasn1.OIDMSCAVersion) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var version int64
+	if rest, err := asn1.Unmarshal(ext.Value, &version); err != nil {
+		return err
+	} else if len(rest) > 0 {
+		return ErrTrailingBytes
+	}
+
+	if version < 0 || version > maxCAVersionIndex<<16|maxCAVersionIndex {
+		return fmt.Errorf("invalid CA version: %d", version)
+	}
+
+	*e = CAVersion{
+		Critical:  ext.Critical,
+		CertIndex: int(version & maxCAVersionIndex),
+		KeyIndex:  int(version >> 16),
+	}
+
+	return nil
+}
+
+// Marshal returns a pkix.Extension.
+func (e ApplicationPolicies) Marshal() (pkix.Extension, error) {
+	der, err := pgasn1.CertificatePolicies(e.Policies).Marshal()
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       pgasn1.OIDMSApplicationPolicies,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *ApplicationPolicies) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(pgasn1.OIDMSApplicationPolicies) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var ae pgasn1.CertificatePolicies
+	if err := ae.Unmarshal(ext.Value); err != nil {
+		return err
+	}
+
+	*e = ApplicationPolicies{
+		Critical: ext.Critical,
+		Policies: ae,
+	}
+
+	return nil
+}
+
+// applicationPoliciesJSON is the JSON representation of an
+// ApplicationPolicies.
+type applicationPoliciesJSON struct {
+	Critical bool                       `json:"critical"`
+	Policies []pgasn1.PolicyInformation `json:"policies"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "policies" members. See
+// asn1.PolicyInformation.MarshalJSON.
+func (e ApplicationPolicies) MarshalJSON() ([]byte, error) {
+	return json.Marshal(applicationPoliciesJSON{
+		Critical: e.Critical,
+		Policies: e.Policies,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *ApplicationPolicies) UnmarshalJSON(b []byte) error {
+	var tmp applicationPoliciesJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = ApplicationPolicies{
+		Critical: tmp.Critical,
+		Policies: tmp.Policies,
+	}
+
+	return nil
+}
+
+// certificateTemplateNameJSON is the JSON representation of a
+// CertificateTemplateName.
+type certificateTemplateNameJSON struct {
+	Critical bool   `json:"critical"`
+	Name     string `json:"name"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "name" members.
+func (e CertificateTemplateName) MarshalJSON() ([]byte, error) {
+	return json.Marshal(certificateTemplateNameJSON{
+		Critical: e.Critical,
+		Name:     e.Name,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *CertificateTemplateName) UnmarshalJSON(b []byte) error {
+	var tmp certificateTemplateNameJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = CertificateTemplateName{
+		Critical: tmp.Critical,
+		Name:     tmp.Name,
+	}
+
+	return nil
+}
+
+// caVersionJSON is the JSON representation of a CAVersion.
+type caVersionJSON struct {
+	Critical  bool `json:"critical"`
+	CertIndex int  `json:"certIndex"`
+	KeyIndex  int  `json:"keyIndex"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical", "certIndex" and "keyIndex" members.
+func (e CAVersion) MarshalJSON() ([]byte, error) {
+	return json.Marshal(caVersionJSON{
+		Critical:  e.Critical,
+		CertIndex: e.CertIndex,
+		KeyIndex:  e.KeyIndex,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *CAVersion) UnmarshalJSON(b []byte) error {
+	var tmp caVersionJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = CAVersion{
+		Critical:  tmp.Critical,
+		CertIndex: tmp.CertIndex,
+		KeyIndex:  tmp.KeyIndex,
+	}
+
+	return nil
+}
+
+// certificateTemplateJSON is the JSON representation of a
+// CertificateTemplate.
+type certificateTemplateJSON struct {
+	Critical     bool   `json:"critical"`
+	ID           string `json:"id"`
+	MajorVersion int64  `json:"majorVersion"`
+	MinorVersion *int64 `json:"minorVersion,omitempty"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical", "id" and "majorVersion" members and an optional
+// "minorVersion" member, which is omitted if MinorVersion is -1. The
+// template ID is a named or dotted OID.
+func (e CertificateTemplate) MarshalJSON() ([]byte, error) {
+	var tmp = certificateTemplateJSON{
+		Critical:     e.Critical,
+		ID:           pgasn1.OIDName(e.ID),
+		MajorVersion: e.MajorVersion,
+	}
+
+	if e.MinorVersion != -1 {
+		var n = e.MinorVersion
+		tmp.MinorVersion = &n
+	}
+
+	return json.Marshal(tmp)
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *CertificateTemplate) UnmarshalJSON(b []byte) error {
+	var tmp certificateTemplateJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	oid, err := pgasn1.ParseOIDName(tmp.ID)
+	if err != nil {
+		return err
+	}
+
+	var ext = CertificateTemplate{
+		Critical:     tmp.Critical,
+		ID:           oid,
+		MajorVersion: tmp.MajorVersion,
+		MinorVersion: -1,
+	}
+
+	if tmp.MinorVersion != nil {
+		ext.MinorVersion = *tmp.MinorVersion
+	}
+
+	*e = ext
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e CertificateTemplateName) String() string {
+	return text(pgasn1.OIDMSCertificateTemplateName, e.Critical, e.Name)
+}
+
+// String returns a text representation of the extension. See Text.
+func (e CertificateTemplate) String() string {
+	var lines = []string{
+		"Template: " + pgasn1.OIDLongName(e.ID),
+		fmt.Sprintf("Major Version: %d", e.MajorVersion),
+	}
+
+	if e.MinorVersion != -1 {
+		lines = append(lines, fmt.Sprintf("Minor Version: %d", e.MinorVersion))
+	}
+
+	return text(pgasn1.OIDMSCertificateTemplate, e.Critical, lines...)
+}
+
+// String returns a text representation of the extension. See Text.
+func (e CAVersion) String() string {
+	return text(pgasn1.OIDMSCAVersion, e.Critical, fmt.Sprintf("V%d.%d", e.CertIndex, e.KeyIndex))
+}
+
+// String returns a text representation of the extension. See Text.
+func (e ApplicationPolicies) String() string {
+	return text(pgasn1.OIDMSApplicationPolicies, e.Critical, policiesText(e.Policies)...)
+}
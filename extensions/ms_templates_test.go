@@ -0,0 +1,473 @@
+package extensions_test
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestCertificateTemplateNameMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.CertificateTemplateName
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext:  extensions.CertificateTemplateName{Name: "CA"},
+			want: pkix.Extension{
+				Id:    pgasn1.OIDMSCertificateTemplateName,
+				Value: []byte{pgasn1.TagBMPString, 4, 0, 'C', 0, 'A'},
+			},
+		},
+		{
+			name: "Empty",
+			ext:  extensions.CertificateTemplateName{},
+			want: pkix.Extension{},
+			err:  errors.New("no name"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCertificateTemplateNameUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.CertificateTemplateName
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDMSCertificateTemplateName,
+				Value: []byte{pgasn1.TagBMPString, 4, 0, 'C', 0, 'A'},
+			},
+			want: extensions.CertificateTemplateName{Name: "CA"},
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDMSCertificateTemplate,
+				Value: []byte{pgasn1.TagBMPString, 4, 0, 'C', 0, 'A'},
+			},
+			want: extensions.CertificateTemplateName{},
+			err:  errors.New("bad OID"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.CertificateTemplateName
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCertificateTemplateMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.CertificateTemplate
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.CertificateTemplate{
+				ID:           asn1.ObjectIdentifier{1, 2, 3},
+				MajorVersion: 100,
+				MinorVersion: 2,
+			},
+			want: pkix.Extension{
+				Id: pgasn1.OIDMSCertificateTemplate,
+				Value: []byte{asn1.TagSequence | bit6, 10,
+					asn1.TagOID, 2, 0x2a, 0x03,
+					asn1.TagInteger, 1, 100,
+					asn1.TagInteger, 1, 2,
+				},
+			},
+		},
+		{
+			name: "NoID",
+			ext:  extensions.CertificateTemplate{MinorVersion: -1},
+			want: pkix.Extension{},
+			err:  errors.New("no ID"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCertificateTemplateUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.CertificateTemplate
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDMSCertificateTemplate,
+				Value: []byte{asn1.TagSequence | bit6, 7,
+					asn1.TagOID, 2, 0x2a, 0x03,
+					asn1.TagInteger, 1, 100,
+				},
+			},
+			want: extensions.CertificateTemplate{
+				ID:           asn1.ObjectIdentifier{1, 2, 3},
+				MajorVersion: 100,
+				MinorVersion: -1,
+			},
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDMSCertificateTemplateName,
+				Value: []byte{asn1.TagSequence | bit6, 7,
+					asn1.TagOID, 2, 0x2a, 0x03,
+					asn1.TagInteger, 1, 100,
+				},
+			},
+			want: extensions.CertificateTemplate{},
+			err:  errors.New("bad OID"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.CertificateTemplate
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCAVersionMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.CAVersion
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext:  extensions.CAVersion{CertIndex: 2, KeyIndex: 1},
+			want: pkix.Extension{
+				Id:    pgasn1.OIDMSCAVersion,
+				Value: []byte{asn1.TagInteger, 3, 0x01, 0x00, 0x02},
+			},
+		},
+		{
+			name: "Zero",
+			ext:  extensions.CAVersion{},
+			want: pkix.Extension{
+				Id:    pgasn1.OIDMSCAVersion,
+				Value: []byte{asn1.TagInteger, 1, 0},
+			},
+		},
+		{
+			name: "Maximum",
+			ext:  extensions.CAVersion{CertIndex: 0xffff, KeyIndex: 0xffff},
+			want: pkix.Extension{
+				Id:    pgasn1.OIDMSCAVersion,
+				Value: []byte{asn1.TagInteger, 5, 0x00, 0xff, 0xff, 0xff, 0xff},
+			},
+		},
+		{
+			name: "CertIndexOutOfRange",
+			ext:  extensions.CAVersion{CertIndex: 1 << 16},
+			want: pkix.Extension{},
+			err:  errors.New("out of range"),
+		},
+		{
+			name: "NegativeKeyIndex",
+			ext:  extensions.CAVersion{KeyIndex: -1},
+			want: pkix.Extension{},
+			err:  errors.New("negative"),
+		},
+		{
+			name: "KeyIndexOutOfRange",
+			ext:  extensions.CAVersion{KeyIndex: 1 << 16},
+			want: pkix.Extension{},
+			err:  errors.New("out of range"),
+		},
+		{
+			name: "NegativeCertIndex",
+			ext:  extensions.CAVersion{CertIndex: -1},
+			want: pkix.Extension{},
+			err:  errors.New("negative"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestCAVersionUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.CAVersion
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDMSCAVersion,
+				Value: []byte{asn1.TagInteger, 3, 0x03, 0x00, 0x04},
+			},
+			want: extensions.CAVersion{CertIndex: 4, KeyIndex: 3},
+		},
+		{
+			name: "OutOfRange",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDMSCAVersion,
+				Value: []byte{asn1.TagInteger, 5, 0x01, 0x00, 0x00, 0x00, 0x00},
+			},
+			want: extensions.CAVersion{},
+			err:  errors.New("out of range"),
+		},
+		{
+			name: "Negative",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDMSCAVersion,
+				Value: []byte{asn1.TagInteger, 1, 0xff},
+			},
+			want: extensions.CAVersion{},
+			err:  errors.New("negative"),
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDMSCertificateTemplate,
+				Value: []byte{asn1.TagInteger, 1, 0},
+			},
+			want: extensions.CAVersion{},
+			err:  errors.New("bad OID"),
+		},
+		{
+			name: "TrailingBytes",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDMSCAVersion,
+				Value: []byte{asn1.TagInteger, 1, 0, 0},
+			},
+			want: extensions.CAVersion{},
+			err:  errors.New("trailing bytes"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.CAVersion
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestApplicationPoliciesMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.ApplicationPolicies
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.ApplicationPolicies{
+				Policies: []pgasn1.PolicyInformation{
+					{Policy: asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 2}},
+				},
+			},
+			want: pkix.Extension{
+				Id: pgasn1.OIDMSApplicationPolicies,
+				Value: []byte{asn1.TagSequence | bit6, 12,
+					asn1.TagSequence | bit6, 10,
+					asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x03, 0x02,
+				},
+			},
+		},
+		{
+			name: "Empty",
+			ext:  extensions.ApplicationPolicies{},
+			want: pkix.Extension{},
+			err:  errors.New("no policies"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestApplicationPoliciesUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.ApplicationPolicies
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDMSApplicationPolicies,
+				Value: []byte{asn1.TagSequence | bit6, 12,
+					asn1.TagSequence | bit6, 10,
+					asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x03, 0x02,
+				},
+			},
+			want: extensions.ApplicationPolicies{
+				Policies: []pgasn1.PolicyInformation{
+					{Policy: asn1.ObjectIdentifier{1, 3, 6, 1, 5, 5, 7, 3, 2}},
+				},
+			},
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDCertificatePolicies,
+				Value: []byte{asn1.TagSequence | bit6, 12,
+					asn1.TagSequence | bit6, 10,
+					asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x03, 0x02,
+				},
+			},
+			want: extensions.ApplicationPolicies{},
+			err:  errors.New("bad OID"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.ApplicationPolicies
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,106 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+
+	"github.com/paulgriffiths/pki/asn1"
+)
+
+// NameConstraints represents an X509 name constraints extension as defined
+// in RFC 5280 section 4.2.1.10.
+type NameConstraints struct {
+	Critical  bool
+	Permitted []asn1.GeneralSubtree
+	Excluded  []asn1.GeneralSubtree
+}
+
+// Marshal returns a pkix.Extension.
+func (e NameConstraints) Marshal() (pkix.Extension, error) {
+	der, err := asn1.NameConstraints{
+		Permitted: e.Permitted,
+		Excluded:  e.Excluded,
+	}.Marshal()
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       asn1.OIDNameConstraints,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *NameConstraints) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(asn1.OIDNameConstraints) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var ae asn1.NameConstraints
+	if err := ae.Unmarshal(ext.Value); err != nil {
+		return err
+	}
+
+	*e = NameConstraints{
+		Critical:  ext.Critical,
+		Permitted: ae.Permitted,
+		Excluded:  ae.Excluded,
+	}
+
+	return nil
+}
+
+// nameConstraintsJSON is the JSON representation of a NameConstraints.
+type nameConstraintsJSON struct {
+	Critical  bool                  `json:"critical"`
+	Permitted []asn1.GeneralSubtree `json:"permitted,omitempty"`
+	Excluded  []asn1.GeneralSubtree `json:"excluded,omitempty"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with a "critical" member and optional "permitted" and "excluded" lists.
+// See asn1.GeneralSubtree.MarshalJSON.
+func (e NameConstraints) MarshalJSON() ([]byte, error) {
+	return json.Marshal(nameConstraintsJSON{
+		Critical:  e.Critical,
+		Permitted: e.Permitted,
+		Excluded:  e.Excluded,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *NameConstraints) UnmarshalJSON(b []byte) error {
+	var tmp nameConstraintsJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = NameConstraints{
+		Critical:  tmp.Critical,
+		Permitted: tmp.Permitted,
+		Excluded:  tmp.Excluded,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e NameConstraints) String() string {
+	var lines []string
+
+	if len(e.Permitted) != 0 {
+		lines = append(lines, "Permitted:")
+		lines = append(lines, indent(subtreesText(e.Permitted))...)
+	}
+
+	if len(e.Excluded) != 0 {
+		lines = append(lines, "Excluded:")
+		lines = append(lines, indent(subtreesText(e.Excluded))...)
+	}
+
+	return text(asn1.OIDNameConstraints, e.Critical, lines...)
+}
@@ -0,0 +1,142 @@
+package extensions_test
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestNameConstraintsMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.NameConstraints
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.NameConstraints{
+				Critical: true,
+				Permitted: []pgasn1.GeneralSubtree{
+					{URIDomain: ".example.com"},
+				},
+			},
+			want: pkix.Extension{
+				Id:       pgasn1.OIDNameConstraints,
+				Critical: true,
+				Value: []byte{asn1.TagSequence | bit6, 18,
+					asn1.ClassContextSpecific<<6 | bit6 | 0, 16,
+					asn1.TagSequence | bit6, 14,
+					nameTagURI | asn1.ClassContextSpecific<<6, 12,
+					'.', 'e', 'x', 'a', 'm', 'p', 'l', 'e', '.', 'c', 'o', 'm',
+				},
+			},
+		},
+		{
+			name: "Empty",
+			ext:  extensions.NameConstraints{Critical: true},
+			want: pkix.Extension{},
+			err:  errors.New("no subtrees"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNameConstraintsUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.NameConstraints
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDNameConstraints,
+				Critical: true,
+				Value: []byte{asn1.TagSequence | bit6, 18,
+					asn1.ClassContextSpecific<<6 | bit6 | 0, 16,
+					asn1.TagSequence | bit6, 14,
+					nameTagURI | asn1.ClassContextSpecific<<6, 12,
+					'.', 'e', 'x', 'a', 'm', 'p', 'l', 'e', '.', 'c', 'o', 'm',
+				},
+			},
+			want: extensions.NameConstraints{
+				Critical: true,
+				Permitted: []pgasn1.GeneralSubtree{
+					{URIDomain: ".example.com"},
+				},
+			},
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDBasicConstraints,
+				Critical: true,
+				Value: []byte{asn1.TagSequence | bit6, 18,
+					asn1.ClassContextSpecific<<6 | bit6 | 0, 16,
+					asn1.TagSequence | bit6, 14,
+					nameTagURI | asn1.ClassContextSpecific<<6, 12,
+					'.', 'e', 'x', 'a', 'm', 'p', 'l', 'e', '.', 'c', 'o', 'm',
+				},
+			},
+			want: extensions.NameConstraints{},
+			err:  errors.New("bad OID"),
+		},
+		{
+			name: "BadASN1",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDNameConstraints,
+				Critical: true,
+				Value:    []byte{0xff},
+			},
+			want: extensions.NameConstraints{},
+			err:  errors.New("bad ASN.1"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.NameConstraints
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,178 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"fmt"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+// OCSPNoCheck represents an OCSP no check extension as defined in RFC 6960
+// section 4.2.2.2.1. It indicates that an OCSP client can trust a delegated
+// OCSP responder certificate for its lifetime, and has an ASN.1 NULL value.
+type OCSPNoCheck struct {
+	Critical bool
+}
+
+// OCSPNonce represents an OCSP nonce extension as defined in RFC 8954
+// section 2.1. The nonce must be between 1 and 32 octets in length.
+type OCSPNonce struct {
+	Critical bool
+	Nonce    []byte
+}
+
+// Permitted OCSP nonce lengths. See RFC 8954 section 2.1.
+const (
+	minOCSPNonceLen = 1
+	maxOCSPNonceLen = 32
+)
+
+// Marshal returns a pkix.Extension.
+func (e OCSPNoCheck) Marshal() (pkix.Extension, error) {
+	return pkix.Extension{
+		Id:       pgasn1.OIDOCSPNoCheck,
+		Critical: e.Critical,
+		Value:    append([]byte{}, asn1Null...),
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *OCSPNoCheck) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(pgasn1.OIDOCSPNoCheck) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	if err := unmarshalNull(ext.Value); err != nil {
+		return err
+	}
+
+	*e = OCSPNoCheck{
+		Critical: ext.Critical,
+	}
+
+	return nil
+}
+
+// Marshal returns a pkix.Extension.
+func (e OCSPNonce) Marshal() (pkix.Extension, error) {
+	if err := checkOCSPNonce(e.Nonce); err != nil {
+		return pkix.Extension{}, err
+	}
+
+	der, err := asn1.Marshal(e.Nonce)
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       pgasn1.OIDOCSPNonce,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *OCSPNonce) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(pgasn1.OIDOCSPNonce) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var nonce []byte
+	if rest, err := asn1.Unmarshal(ext.Value, &nonce); err != nil {
+		return err
+	} else if len(rest) > 0 {
+		return ErrTrailingBytes
+	}
+
+	if err := checkOCSPNonce(nonce); err != nil {
+		return err
+	}
+
+	*e = OCSPNonce{
+		Critical: ext.Critical,
+		Nonce:    nonce,
+	}
+
+	return nil
+}
+
+// checkOCSPNonce returns an error if the length of a nonce is outside of the
+// range permitted by RFC 8954.
+func checkOCSPNonce(nonce []byte) error {
+	if len(nonce) < minOCSPNonceLen || len(nonce) > maxOCSPNonceLen {
+		return fmt.Errorf("invalid OCSP nonce length: %d", len(nonce))
+	}
+
+	return nil
+}
+
+// ocspNoCheckJSON is the JSON representation of an OCSPNoCheck.
+type ocspNoCheckJSON struct {
+	Critical bool `json:"critical"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with a "critical" member.
+func (e OCSPNoCheck) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ocspNoCheckJSON{
+		Critical: e.Critical,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *OCSPNoCheck) UnmarshalJSON(b []byte) error {
+	var tmp ocspNoCheckJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = OCSPNoCheck{
+		Critical: tmp.Critical,
+	}
+
+	return nil
+}
+
+// ocspNonceJSON is the JSON representation of an OCSPNonce.
+type ocspNonceJSON struct {
+	Critical bool     `json:"critical"`
+	Nonce    hexBytes `json:"nonce"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "nonce" members. The nonce is a hex string.
+func (e OCSPNonce) MarshalJSON() ([]byte, error) {
+	return json.Marshal(ocspNonceJSON{
+		Critical: e.Critical,
+		Nonce:    e.Nonce,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *OCSPNonce) UnmarshalJSON(b []byte) error {
+	var tmp ocspNonceJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = OCSPNonce{
+		Critical: tmp.Critical,
+		Nonce:    tmp.Nonce,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e OCSPNoCheck) String() string {
+	return text(pgasn1.OIDOCSPNoCheck, e.Critical)
+}
+
+// String returns a text representation of the extension. See Text.
+func (e OCSPNonce) String() string {
+	return text(pgasn1.OIDOCSPNonce, e.Critical, hexText(e.Nonce))
+}
@@ -0,0 +1,214 @@
+package extensions_test
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestOCSPNoCheckMarshal(t *testing.T) {
+	t.Parallel()
+
+	got, err := extensions.OCSPNoCheck{}.Marshal()
+	if err != nil {
+		t.Fatalf("couldn't marshal extension: %v", err)
+	}
+
+	var want = pkix.Extension{
+		Id:    pgasn1.OIDOCSPNoCheck,
+		Value: []byte{asn1.TagNull, 0},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestOCSPNoCheckUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.OCSPNoCheck
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDOCSPNoCheck,
+				Critical: true,
+				Value:    []byte{asn1.TagNull, 0},
+			},
+			want: extensions.OCSPNoCheck{Critical: true},
+		},
+		{
+			name: "NotNull",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDOCSPNoCheck,
+				Value: []byte{asn1.TagOctetString, 0},
+			},
+			want: extensions.OCSPNoCheck{},
+			err:  errors.New("not NULL"),
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDOCSPNonce,
+				Value: []byte{asn1.TagNull, 0},
+			},
+			want: extensions.OCSPNoCheck{},
+			err:  errors.New("bad OID"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.OCSPNoCheck
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOCSPNonceMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.OCSPNonce
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext:  extensions.OCSPNonce{Nonce: []byte{1, 2, 3, 4}},
+			want: pkix.Extension{
+				Id:    pgasn1.OIDOCSPNonce,
+				Value: []byte{asn1.TagOctetString, 4, 1, 2, 3, 4},
+			},
+		},
+		{
+			name: "Empty",
+			ext:  extensions.OCSPNonce{},
+			want: pkix.Extension{},
+			err:  errors.New("empty nonce"),
+		},
+		{
+			name: "TooLong",
+			ext:  extensions.OCSPNonce{Nonce: bytes.Repeat([]byte{1}, 33)},
+			want: pkix.Extension{},
+			err:  errors.New("nonce too long"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestOCSPNonceUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.OCSPNonce
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDOCSPNonce,
+				Value: append([]byte{asn1.TagOctetString, 32}, bytes.Repeat([]byte{7}, 32)...),
+			},
+			want: extensions.OCSPNonce{Nonce: bytes.Repeat([]byte{7}, 32)},
+		},
+		{
+			name: "Empty",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDOCSPNonce,
+				Value: []byte{asn1.TagOctetString, 0},
+			},
+			want: extensions.OCSPNonce{},
+			err:  errors.New("empty nonce"),
+		},
+		{
+			name: "TooLong",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDOCSPNonce,
+				Value: append([]byte{asn1.TagOctetString, 33}, bytes.Repeat([]byte{7}, 33)...),
+			},
+			want: extensions.OCSPNonce{},
+			err:  errors.New("nonce too long"),
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDOCSPNoCheck,
+				Value: []byte{asn1.TagOctetString, 1, 1},
+			},
+			want: extensions.OCSPNonce{},
+			err:  errors.New("bad OID"),
+		},
+		{
+			name: "TrailingBytes",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDOCSPNonce,
+				Value: []byte{asn1.TagOctetString, 1, 1, 0},
+			},
+			want: extensions.OCSPNonce{},
+			err:  errors.New("trailing bytes"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.OCSPNonce
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,126 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+
+	"github.com/paulgriffiths/pki/asn1"
+)
+
+// PolicyConstraints represents an X509 policy constraints extension as
+// defined in RFC 5280 section 4.2.1.11. A value of -1 indicates that the
+// corresponding field is absent.
+type PolicyConstraints struct {
+	Critical              bool
+	RequireExplicitPolicy int
+	InhibitPolicyMapping  int
+}
+
+// Marshal returns a pkix.Extension.
+func (e PolicyConstraints) Marshal() (pkix.Extension, error) {
+	var ae = asn1.PolicyConstraints{
+		RequireExplicitPolicy: e.RequireExplicitPolicy,
+		InhibitPolicyMapping:  e.InhibitPolicyMapping,
+	}
+
+	der, err := ae.Marshal()
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       asn1.OIDPolicyConstraints,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *PolicyConstraints) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(asn1.OIDPolicyConstraints) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var ae asn1.PolicyConstraints
+	if err := ae.Unmarshal(ext.Value); err != nil {
+		return err
+	}
+
+	*e = PolicyConstraints{
+		Critical:              ext.Critical,
+		RequireExplicitPolicy: ae.RequireExplicitPolicy,
+		InhibitPolicyMapping:  ae.InhibitPolicyMapping,
+	}
+
+	return nil
+}
+
+// policyConstraintsJSON is the JSON representation of a PolicyConstraints.
+type policyConstraintsJSON struct {
+	Critical              bool `json:"critical"`
+	RequireExplicitPolicy *int `json:"requireExplicitPolicy,omitempty"`
+	InhibitPolicyMapping  *int `json:"inhibitPolicyMapping,omitempty"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with a "critical" member and optional "requireExplicitPolicy" and
+// "inhibitPolicyMapping" integers, each of which is omitted if its value is
+// -1.
+func (e PolicyConstraints) MarshalJSON() ([]byte, error) {
+	var tmp = policyConstraintsJSON{Critical: e.Critical}
+
+	if e.RequireExplicitPolicy != -1 {
+		var n = e.RequireExplicitPolicy
+		tmp.RequireExplicitPolicy = &n
+	}
+
+	if e.InhibitPolicyMapping != -1 {
+		var n = e.InhibitPolicyMapping
+		tmp.InhibitPolicyMapping = &n
+	}
+
+	return json.Marshal(tmp)
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *PolicyConstraints) UnmarshalJSON(b []byte) error {
+	var tmp policyConstraintsJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	var ext = PolicyConstraints{
+		Critical:              tmp.Critical,
+		RequireExplicitPolicy: -1,
+		InhibitPolicyMapping:  -1,
+	}
+
+	if tmp.RequireExplicitPolicy != nil {
+		ext.RequireExplicitPolicy = *tmp.RequireExplicitPolicy
+	}
+
+	if tmp.InhibitPolicyMapping != nil {
+		ext.InhibitPolicyMapping = *tmp.InhibitPolicyMapping
+	}
+
+	*e = ext
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e PolicyConstraints) String() string {
+	var lines []string
+
+	if e.RequireExplicitPolicy >= 0 {
+		lines = append(lines, fmt.Sprintf("Require Explicit Policy:%d", e.RequireExplicitPolicy))
+	}
+
+	if e.InhibitPolicyMapping >= 0 {
+		lines = append(lines, fmt.Sprintf("Inhibit Policy Mapping:%d", e.InhibitPolicyMapping))
+	}
+
+	return text(asn1.OIDPolicyConstraints, e.Critical, lines...)
+}
@@ -0,0 +1,129 @@
+package extensions_test
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestPolicyConstraintsMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.PolicyConstraints
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.PolicyConstraints{
+				Critical:              true,
+				RequireExplicitPolicy: 0,
+				InhibitPolicyMapping:  -1,
+			},
+			want: pkix.Extension{
+				Id:       pgasn1.OIDPolicyConstraints,
+				Critical: true,
+				Value:    []byte{asn1.TagSequence | bit6, 3, asn1.ClassContextSpecific << 6, 1, 0},
+			},
+		},
+		{
+			name: "Empty",
+			ext: extensions.PolicyConstraints{
+				Critical:              true,
+				RequireExplicitPolicy: -1,
+				InhibitPolicyMapping:  -1,
+			},
+			want: pkix.Extension{},
+			err:  errors.New("empty"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPolicyConstraintsUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.PolicyConstraints
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDPolicyConstraints,
+				Critical: true,
+				Value:    []byte{asn1.TagSequence | bit6, 3, asn1.ClassContextSpecific<<6 | 1, 1, 2},
+			},
+			want: extensions.PolicyConstraints{
+				Critical:              true,
+				RequireExplicitPolicy: -1,
+				InhibitPolicyMapping:  2,
+			},
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDBasicConstraints,
+				Critical: true,
+				Value:    []byte{asn1.TagSequence | bit6, 3, asn1.ClassContextSpecific<<6 | 1, 1, 2},
+			},
+			want: extensions.PolicyConstraints{},
+			err:  errors.New("bad OID"),
+		},
+		{
+			name: "BadASN1",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDPolicyConstraints,
+				Critical: true,
+				Value:    []byte{0xff},
+			},
+			want: extensions.PolicyConstraints{},
+			err:  errors.New("bad ASN.1"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.PolicyConstraints
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,172 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+// PolicyMappings represents an X509 policy mappings extension as defined in
+// RFC 5280 section 4.2.1.5.
+type PolicyMappings struct {
+	Critical bool
+	Mappings []PolicyMapping
+}
+
+// PolicyMapping represents a single mapping of an issuer domain policy to a
+// subject domain policy.
+type PolicyMapping struct {
+	IssuerDomainPolicy  asn1.ObjectIdentifier
+	SubjectDomainPolicy asn1.ObjectIdentifier
+}
+
+// Marshal returns a pkix.Extension.
+func (e PolicyMappings) Marshal() (pkix.Extension, error) {
+	if err := checkPolicyMappings(e.Mappings); err != nil {
+		return pkix.Extension{}, err
+	}
+
+	der, err := asn1.Marshal(e.Mappings)
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       pgasn1.OIDPolicyMappings,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *PolicyMappings) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(pgasn1.OIDPolicyMappings) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var mappings []PolicyMapping
+	if rest, err := asn1.Unmarshal(ext.Value, &mappings); err != nil {
+		return err
+	} else if len(rest) > 0 {
+		return ErrTrailingBytes
+	}
+
+	if err := checkPolicyMappings(mappings); err != nil {
+		return err
+	}
+
+	*e = PolicyMappings{
+		Critical: ext.Critical,
+		Mappings: mappings,
+	}
+
+	return nil
+}
+
+// checkPolicyMappings checks that a list of policy mappings is not empty and
+// does not map anyPolicy. See RFC 5280 section 4.2.1.5.
+func checkPolicyMappings(mappings []PolicyMapping) error {
+	if len(mappings) == 0 {
+		return errors.New("no policy mappings specified")
+	}
+
+	// Policies MUST NOT be mapped either to or from the special value
+	// anyPolicy.
+	for _, m := range mappings {
+		if m.IssuerDomainPolicy.Equal(pgasn1.OIDAnyPolicy) ||
+			m.SubjectDomainPolicy.Equal(pgasn1.OIDAnyPolicy) {
+			return errors.New("anyPolicy must not be mapped")
+		}
+	}
+
+	return nil
+}
+
+// policyMappingsJSON is the JSON representation of a PolicyMappings.
+type policyMappingsJSON struct {
+	Critical bool            `json:"critical"`
+	Mappings []PolicyMapping `json:"mappings"`
+}
+
+// policyMappingJSON is the JSON representation of a PolicyMapping.
+type policyMappingJSON struct {
+	IssuerDomainPolicy  string `json:"issuerDomainPolicy"`
+	SubjectDomainPolicy string `json:"subjectDomainPolicy"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "mappings" members. See PolicyMapping.MarshalJSON.
+func (e PolicyMappings) MarshalJSON() ([]byte, error) {
+	return json.Marshal(policyMappingsJSON{
+		Critical: e.Critical,
+		Mappings: e.Mappings,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *PolicyMappings) UnmarshalJSON(b []byte) error {
+	var tmp policyMappingsJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = PolicyMappings{
+		Critical: tmp.Critical,
+		Mappings: tmp.Mappings,
+	}
+
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of the policy mapping, which is an
+// object with "issuerDomainPolicy" and "subjectDomainPolicy" members
+// containing named or dotted policy OIDs.
+func (m PolicyMapping) MarshalJSON() ([]byte, error) {
+	return json.Marshal(policyMappingJSON{
+		IssuerDomainPolicy:  pgasn1.OIDName(m.IssuerDomainPolicy),
+		SubjectDomainPolicy: pgasn1.OIDName(m.SubjectDomainPolicy),
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded policy mapping and stores the result
+// in the object.
+func (m *PolicyMapping) UnmarshalJSON(b []byte) error {
+	var tmp policyMappingJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	issuer, err := pgasn1.ParseOIDName(tmp.IssuerDomainPolicy)
+	if err != nil {
+		return err
+	}
+
+	subject, err := pgasn1.ParseOIDName(tmp.SubjectDomainPolicy)
+	if err != nil {
+		return err
+	}
+
+	*m = PolicyMapping{
+		IssuerDomainPolicy:  issuer,
+		SubjectDomainPolicy: subject,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e PolicyMappings) String() string {
+	var lines []string
+
+	for _, m := range e.Mappings {
+		lines = append(lines,
+			pgasn1.OIDLongName(m.IssuerDomainPolicy)+":"+pgasn1.OIDLongName(m.SubjectDomainPolicy))
+	}
+
+	return text(pgasn1.OIDPolicyMappings, e.Critical, lines...)
+}
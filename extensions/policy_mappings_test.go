@@ -0,0 +1,175 @@
+package extensions_test
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestPolicyMappingsMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.PolicyMappings
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.PolicyMappings{
+				Critical: true,
+				Mappings: []extensions.PolicyMapping{
+					{
+						IssuerDomainPolicy:  asn1.ObjectIdentifier{1, 2, 3},
+						SubjectDomainPolicy: asn1.ObjectIdentifier{1, 2, 4},
+					},
+				},
+			},
+			want: pkix.Extension{
+				Id:       pgasn1.OIDPolicyMappings,
+				Critical: true,
+				Value: []byte{asn1.TagSequence | bit6, 10,
+					asn1.TagSequence | bit6, 8,
+					asn1.TagOID, 2, 0x2a, 0x03,
+					asn1.TagOID, 2, 0x2a, 0x04,
+				},
+			},
+		},
+		{
+			name: "Empty",
+			ext:  extensions.PolicyMappings{Critical: true},
+			want: pkix.Extension{},
+			err:  errors.New("no mappings"),
+		},
+		{
+			name: "AnyPolicy",
+			ext: extensions.PolicyMappings{
+				Critical: true,
+				Mappings: []extensions.PolicyMapping{
+					{
+						IssuerDomainPolicy:  pgasn1.OIDAnyPolicy,
+						SubjectDomainPolicy: asn1.ObjectIdentifier{1, 2, 4},
+					},
+				},
+			},
+			want: pkix.Extension{},
+			err:  errors.New("anyPolicy"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPolicyMappingsUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.PolicyMappings
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDPolicyMappings,
+				Critical: true,
+				Value: []byte{asn1.TagSequence | bit6, 10,
+					asn1.TagSequence | bit6, 8,
+					asn1.TagOID, 2, 0x2a, 0x03,
+					asn1.TagOID, 2, 0x2a, 0x04,
+				},
+			},
+			want: extensions.PolicyMappings{
+				Critical: true,
+				Mappings: []extensions.PolicyMapping{
+					{
+						IssuerDomainPolicy:  asn1.ObjectIdentifier{1, 2, 3},
+						SubjectDomainPolicy: asn1.ObjectIdentifier{1, 2, 4},
+					},
+				},
+			},
+		},
+		{
+			name: "Empty",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDPolicyMappings,
+				Value: []byte{asn1.TagSequence | bit6, 0},
+			},
+			want: extensions.PolicyMappings{},
+			err:  errors.New("no policy mappings specified"),
+		},
+		{
+			name: "AnyPolicy",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDPolicyMappings,
+				Value: []byte{asn1.TagSequence | bit6, 12,
+					asn1.TagSequence | bit6, 10,
+					asn1.TagOID, 4, 0x55, 0x1d, 0x20, 0x00,
+					asn1.TagOID, 2, 0x2a, 0x04,
+				},
+			},
+			want: extensions.PolicyMappings{},
+			err:  errors.New("anyPolicy must not be mapped"),
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDPolicyConstraints,
+				Value: []byte{asn1.TagSequence | bit6, 0},
+			},
+			want: extensions.PolicyMappings{},
+			err:  errors.New("bad OID"),
+		},
+		{
+			name: "TrailingBytes",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDPolicyMappings,
+				Value: []byte{asn1.TagSequence | bit6, 0, 0xff},
+			},
+			want: extensions.PolicyMappings{},
+			err:  errors.New("trailing bytes"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.PolicyMappings
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,98 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/json"
+	"errors"
+	"fmt"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+// PrecertificatePoison represents a Certificate Transparency precertificate
+// poison extension as defined in RFC 6962 section 3.1. The extension is
+// always critical and has an ASN.1 NULL value.
+type PrecertificatePoison struct{}
+
+// asn1Null is the DER-encoding of an ASN.1 NULL.
+var asn1Null = []byte{asn1.TagNull, 0}
+
+// Marshal returns a pkix.Extension.
+func (e PrecertificatePoison) Marshal() (pkix.Extension, error) {
+	return pkix.Extension{
+		Id:       pgasn1.OIDPrecertificatePoison,
+		Critical: true,
+		Value:    append([]byte{}, asn1Null...),
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *PrecertificatePoison) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(pgasn1.OIDPrecertificatePoison) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	if !ext.Critical {
+		return errors.New("precertificate poison extension is not critical")
+	}
+
+	if err := unmarshalNull(ext.Value); err != nil {
+		return err
+	}
+
+	*e = PrecertificatePoison{}
+
+	return nil
+}
+
+// unmarshalNull returns an error if an extension value is not an ASN.1 NULL.
+func unmarshalNull(b []byte) error {
+	var null asn1.RawValue
+	if rest, err := asn1.Unmarshal(b, &null); err != nil {
+		return err
+	} else if len(rest) > 0 {
+		return ErrTrailingBytes
+	}
+
+	if null.Class != asn1.ClassUniversal || null.Tag != asn1.TagNull || len(null.Bytes) != 0 {
+		return errors.New("extension value is not NULL")
+	}
+
+	return nil
+}
+
+// precertificatePoisonJSON is the JSON representation of a
+// PrecertificatePoison.
+type precertificatePoisonJSON struct {
+	Critical bool `json:"critical"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with a "critical" member which is always true.
+func (e PrecertificatePoison) MarshalJSON() ([]byte, error) {
+	return json.Marshal(precertificatePoisonJSON{Critical: true})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *PrecertificatePoison) UnmarshalJSON(b []byte) error {
+	var tmp precertificatePoisonJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	if !tmp.Critical {
+		return errors.New("precertificate poison extension is not critical")
+	}
+
+	*e = PrecertificatePoison{}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e PrecertificatePoison) String() string {
+	return text(pgasn1.OIDPrecertificatePoison, true, "NULL")
+}
@@ -0,0 +1,101 @@
+package extensions_test
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestPrecertificatePoisonMarshal(t *testing.T) {
+	t.Parallel()
+
+	got, err := extensions.PrecertificatePoison{}.Marshal()
+	if err != nil {
+		t.Fatalf("couldn't marshal extension: %v", err)
+	}
+
+	var want = pkix.Extension{
+		Id:       pgasn1.OIDPrecertificatePoison,
+		Critical: true,
+		Value:    []byte{asn1.TagNull, 0},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+func TestPrecertificatePoisonUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDPrecertificatePoison,
+				Critical: true,
+				Value:    []byte{asn1.TagNull, 0},
+			},
+		},
+		{
+			name: "NotCritical",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDPrecertificatePoison,
+				Value: []byte{asn1.TagNull, 0},
+			},
+			err: errors.New("not critical"),
+		},
+		{
+			name: "NotNull",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDPrecertificatePoison,
+				Critical: true,
+				Value:    []byte{asn1.TagBoolean, 1, 0xff},
+			},
+			err: errors.New("not NULL"),
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDSignedCertificateTimestampList,
+				Critical: true,
+				Value:    []byte{asn1.TagNull, 0},
+			},
+			err: errors.New("bad OID"),
+		},
+		{
+			name: "TrailingBytes",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDPrecertificatePoison,
+				Critical: true,
+				Value:    []byte{asn1.TagNull, 0, 0},
+			},
+			err: errors.New("trailing bytes"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.PrecertificatePoison
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+		})
+	}
+}
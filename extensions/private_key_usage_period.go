@@ -0,0 +1,163 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"time"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+// PrivateKeyUsagePeriod represents an X509 private key usage period extension
+// as defined in RFC 3280 section 4.2.1.4. A zero value for NotBefore or
+// NotAfter indicates that the corresponding field is absent, but at least one
+// of them must be present.
+type PrivateKeyUsagePeriod struct {
+	Critical  bool
+	NotBefore time.Time
+	NotAfter  time.Time
+}
+
+// privateKeyUsagePeriod is the intermediate representation of a
+// PrivateKeyUsagePeriod.
+//
+// PrivateKeyUsagePeriod ::= SEQUENCE {
+//      notBefore       [0]     GeneralizedTime OPTIONAL,
+//      notAfter        [1]     GeneralizedTime OPTIONAL }
+type privateKeyUsagePeriod struct {
+	NotBefore time.Time `asn1:"optional,tag:0,generalized"`
+	NotAfter  time.Time `asn1:"optional,tag:1,generalized"`
+}
+
+// Marshal returns a pkix.Extension.
+func (e PrivateKeyUsagePeriod) Marshal() (pkix.Extension, error) {
+	var tmp = privateKeyUsagePeriod{
+		NotBefore: e.NotBefore.UTC(),
+		NotAfter:  e.NotAfter.UTC(),
+	}
+
+	if err := tmp.check(); err != nil {
+		return pkix.Extension{}, err
+	}
+
+	der, err := asn1.Marshal(tmp)
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       pgasn1.OIDPrivateKeyUsagePeriod,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *PrivateKeyUsagePeriod) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(pgasn1.OIDPrivateKeyUsagePeriod) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var tmp privateKeyUsagePeriod
+	if rest, err := asn1.Unmarshal(ext.Value, &tmp); err != nil {
+		return err
+	} else if len(rest) > 0 {
+		return ErrTrailingBytes
+	}
+
+	if err := tmp.check(); err != nil {
+		return err
+	}
+
+	*e = PrivateKeyUsagePeriod{
+		Critical:  ext.Critical,
+		NotBefore: tmp.NotBefore,
+		NotAfter:  tmp.NotAfter,
+	}
+
+	return nil
+}
+
+// check returns an error if neither time is present, or if the period ends
+// before it begins.
+func (e privateKeyUsagePeriod) check() error {
+
+	// CAs conforming to this profile MUST NOT generate certificates with
+	// private key usage period extensions unless at least one of the two
+	// components is present. See RFC 3280 section 4.2.1.4.
+	if e.NotBefore.IsZero() && e.NotAfter.IsZero() {
+		return errors.New("no private key usage period specified")
+	}
+
+	if !e.NotBefore.IsZero() && !e.NotAfter.IsZero() && e.NotAfter.Before(e.NotBefore) {
+		return errors.New("private key usage period ends before it begins")
+	}
+
+	return nil
+}
+
+// privateKeyUsagePeriodJSON is the JSON representation of a
+// PrivateKeyUsagePeriod.
+type privateKeyUsagePeriodJSON struct {
+	Critical  bool       `json:"critical"`
+	NotBefore *time.Time `json:"notBefore,omitempty"`
+	NotAfter  *time.Time `json:"notAfter,omitempty"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with a "critical" member and optional "notBefore" and "notAfter" members.
+// The times are RFC 3339 strings.
+func (e PrivateKeyUsagePeriod) MarshalJSON() ([]byte, error) {
+	var tmp = privateKeyUsagePeriodJSON{Critical: e.Critical}
+
+	if !e.NotBefore.IsZero() {
+		tmp.NotBefore = &e.NotBefore
+	}
+
+	if !e.NotAfter.IsZero() {
+		tmp.NotAfter = &e.NotAfter
+	}
+
+	return json.Marshal(tmp)
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *PrivateKeyUsagePeriod) UnmarshalJSON(b []byte) error {
+	var tmp privateKeyUsagePeriodJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	var ext = PrivateKeyUsagePeriod{Critical: tmp.Critical}
+
+	if tmp.NotBefore != nil {
+		ext.NotBefore = *tmp.NotBefore
+	}
+
+	if tmp.NotAfter != nil {
+		ext.NotAfter = *tmp.NotAfter
+	}
+
+	*e = ext
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e PrivateKeyUsagePeriod) String() string {
+	var lines []string
+
+	if !e.NotBefore.IsZero() {
+		lines = append(lines, "Not Before: "+timeText(e.NotBefore))
+	}
+
+	if !e.NotAfter.IsZero() {
+		lines = append(lines, "Not After: "+timeText(e.NotAfter))
+	}
+
+	return text(pgasn1.OIDPrivateKeyUsagePeriod, e.Critical, lines...)
+}
@@ -0,0 +1,164 @@
+package extensions_test
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestPrivateKeyUsagePeriodMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.PrivateKeyUsagePeriod
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "Both",
+			ext: extensions.PrivateKeyUsagePeriod{
+				NotBefore: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+				NotAfter:  time.Date(2021, 1, 2, 4, 4, 5, 0, time.FixedZone("CET", 3600)),
+			},
+			want: pkix.Extension{
+				Id: pgasn1.OIDPrivateKeyUsagePeriod,
+				Value: []byte{asn1.TagSequence | bit6, 34,
+					asn1.ClassContextSpecific<<6 | 0, 15,
+					'2', '0', '2', '0', '0', '1', '0', '2', '0', '3', '0', '4', '0', '5', 'Z',
+					asn1.ClassContextSpecific<<6 | 1, 15,
+					'2', '0', '2', '1', '0', '1', '0', '2', '0', '3', '0', '4', '0', '5', 'Z',
+				},
+			},
+		},
+		{
+			name: "NotAfterOnly",
+			ext: extensions.PrivateKeyUsagePeriod{
+				NotAfter: time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+			},
+			want: pkix.Extension{
+				Id: pgasn1.OIDPrivateKeyUsagePeriod,
+				Value: []byte{asn1.TagSequence | bit6, 17,
+					asn1.ClassContextSpecific<<6 | 1, 15,
+					'2', '0', '2', '1', '0', '1', '0', '2', '0', '3', '0', '4', '0', '5', 'Z',
+				},
+			},
+		},
+		{
+			name: "Empty",
+			ext:  extensions.PrivateKeyUsagePeriod{},
+			want: pkix.Extension{},
+			err:  errors.New("no times"),
+		},
+		{
+			name: "Reversed",
+			ext: extensions.PrivateKeyUsagePeriod{
+				NotBefore: time.Date(2021, 1, 2, 3, 4, 5, 0, time.UTC),
+				NotAfter:  time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			},
+			want: pkix.Extension{},
+			err:  errors.New("reversed"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestPrivateKeyUsagePeriodUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.PrivateKeyUsagePeriod
+		err  error
+	}{
+		{
+			name: "NotBeforeOnly",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDPrivateKeyUsagePeriod,
+				Value: []byte{asn1.TagSequence | bit6, 17,
+					asn1.ClassContextSpecific<<6 | 0, 15,
+					'2', '0', '2', '0', '0', '1', '0', '2', '0', '3', '0', '4', '0', '5', 'Z',
+				},
+			},
+			want: extensions.PrivateKeyUsagePeriod{
+				NotBefore: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC),
+			},
+		},
+		{
+			name: "Empty",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDPrivateKeyUsagePeriod,
+				Value: []byte{asn1.TagSequence | bit6, 0},
+			},
+			want: extensions.PrivateKeyUsagePeriod{},
+			err:  errors.New("no times"),
+		},
+		{
+			name: "WrongTag",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDPrivateKeyUsagePeriod,
+				Value: []byte{asn1.TagSequence | bit6, 17,
+					asn1.ClassContextSpecific<<6 | 2, 15,
+					'2', '0', '2', '0', '0', '1', '0', '2', '0', '3', '0', '4', '0', '5', 'Z',
+				},
+			},
+			want: extensions.PrivateKeyUsagePeriod{},
+			err:  errors.New("wrong tag"),
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDKeyUsage,
+				Value: []byte{asn1.TagSequence | bit6, 17,
+					asn1.ClassContextSpecific<<6 | 0, 15,
+					'2', '0', '2', '0', '0', '1', '0', '2', '0', '3', '0', '4', '0', '5', 'Z',
+				},
+			},
+			want: extensions.PrivateKeyUsagePeriod{},
+			err:  errors.New("bad OID"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.PrivateKeyUsagePeriod
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,153 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	goasn1 "encoding/asn1"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/paulgriffiths/pki/asn1"
+)
+
+// QCStatements represents a qualified certificate statements extension as
+// defined in RFC 3739 section 3.2.6 and ETSI EN 319 412-5.
+type QCStatements struct {
+	Critical   bool
+	Statements []asn1.QCStatement
+}
+
+// Marshal returns a pkix.Extension.
+func (e QCStatements) Marshal() (pkix.Extension, error) {
+	der, err := asn1.QCStatements(e.Statements).Marshal()
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       asn1.OIDQCStatements,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *QCStatements) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(asn1.OIDQCStatements) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var ae asn1.QCStatements
+	if err := ae.Unmarshal(ext.Value); err != nil {
+		return err
+	}
+
+	*e = QCStatements{
+		Critical:   ext.Critical,
+		Statements: ae,
+	}
+
+	return nil
+}
+
+// qcStatementsJSON is the JSON representation of a QCStatements.
+type qcStatementsJSON struct {
+	Critical   bool               `json:"critical"`
+	Statements []asn1.QCStatement `json:"statements"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "statements" members. See
+// asn1.QCStatement.MarshalJSON.
+func (e QCStatements) MarshalJSON() ([]byte, error) {
+	return json.Marshal(qcStatementsJSON{
+		Critical:   e.Critical,
+		Statements: e.Statements,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *QCStatements) UnmarshalJSON(b []byte) error {
+	var tmp qcStatementsJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = QCStatements{
+		Critical:   tmp.Critical,
+		Statements: tmp.Statements,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e QCStatements) String() string {
+	var lines []string
+
+	for _, s := range e.Statements {
+		lines = append(lines, qcStatementText(s)...)
+	}
+
+	return text(asn1.OIDQCStatements, e.Critical, lines...)
+}
+
+// qcStatementText returns the text representation of a QC statement, which
+// is the name of the statement followed by any statement information.
+func qcStatementText(s asn1.QCStatement) []string {
+	var name = asn1.OIDLongName(s.ID)
+
+	switch {
+	case s.ID.Equal(asn1.OIDQCCompliance), s.ID.Equal(asn1.OIDQCSSCD):
+		return []string{name}
+
+	case s.ID.Equal(asn1.OIDQCType):
+		return []string{name + ":", nestedIndent + oidsText(s.Types)}
+
+	case s.ID.Equal(asn1.OIDQCRetentionPeriod):
+		return []string{name + ":", fmt.Sprintf("%sRetention Period: %d years",
+			nestedIndent, s.RetentionPeriod)}
+
+	case s.ID.Equal(asn1.OIDQCPDS):
+		var lines = []string{name + ":"}
+		for _, loc := range s.PDSLocations {
+			lines = append(lines, nestedIndent+loc.Language+": "+loc.URL)
+		}
+
+		return lines
+
+	case s.ID.Equal(asn1.OIDQCLimitValue):
+		var currency = s.LimitValue.Currency
+		if currency == "" {
+			currency = fmt.Sprintf("%d", s.LimitValue.NumericCurrency)
+		}
+
+		return []string{name + ":", fmt.Sprintf("%sLimit: %d x 10^%d %s",
+			nestedIndent, s.LimitValue.Amount, s.LimitValue.Exponent, currency)}
+
+	case s.ID.Equal(asn1.OIDQCPSD2):
+		var roles []string
+		for _, r := range s.PSD2.Roles {
+			roles = append(roles, r.Name)
+		}
+
+		return []string{
+			name + ":",
+			nestedIndent + "Roles: " + strings.Join(roles, ", "),
+			nestedIndent + "NCA Name: " + s.PSD2.NCAName,
+			nestedIndent + "NCA ID: " + s.PSD2.NCAID,
+		}
+	}
+
+	if len(s.Raw.FullBytes) == 0 && len(s.Raw.Bytes) == 0 && s.Raw.Tag == 0 {
+		return []string{name}
+	}
+
+	der, err := goasn1.Marshal(s.Raw)
+	if err != nil {
+		return []string{name}
+	}
+
+	return append([]string{name + ":"}, indent(dumpText(der))...)
+}
@@ -0,0 +1,131 @@
+package extensions_test
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestQCStatementsMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.QCStatements
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.QCStatements{
+				Statements: []pgasn1.QCStatement{
+					{ID: pgasn1.OIDQCCompliance},
+				},
+			},
+			want: pkix.Extension{
+				Id: pgasn1.OIDQCStatements,
+				Value: []byte{asn1.TagSequence | bit6, 10,
+					asn1.TagSequence | bit6, 8,
+					asn1.TagOID, 6, 0x04, 0x00, 0x8e, 0x46, 0x01, 0x01,
+				},
+			},
+		},
+		{
+			name: "Empty",
+			ext:  extensions.QCStatements{},
+			want: pkix.Extension{},
+			err:  errors.New("no statements"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestQCStatementsUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.QCStatements
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDQCStatements,
+				Value: []byte{asn1.TagSequence | bit6, 13,
+					asn1.TagSequence | bit6, 11,
+					asn1.TagOID, 6, 0x04, 0x00, 0x8e, 0x46, 0x01, 0x03,
+					asn1.TagInteger, 1, 10,
+				},
+			},
+			want: extensions.QCStatements{
+				Statements: []pgasn1.QCStatement{
+					{ID: pgasn1.OIDQCRetentionPeriod, RetentionPeriod: 10},
+				},
+			},
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDCertificatePolicies,
+				Value: []byte{asn1.TagSequence | bit6, 10,
+					asn1.TagSequence | bit6, 8,
+					asn1.TagOID, 6, 0x04, 0x00, 0x8e, 0x46, 0x01, 0x01,
+				},
+			},
+			want: extensions.QCStatements{},
+			err:  errors.New("bad OID"),
+		},
+		{
+			name: "BadASN1",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDQCStatements,
+				Value: []byte{0xff},
+			},
+			want: extensions.QCStatements{},
+			err:  errors.New("bad ASN.1"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.QCStatements
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,181 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"fmt"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+// CRLReason represents a revocation reason code as defined in RFC 5280
+// section 5.3.1.
+type CRLReason int
+
+// Revocation reason codes. Value 7 is not used.
+const (
+	CRLReasonUnspecified          CRLReason = 0
+	CRLReasonKeyCompromise        CRLReason = 1
+	CRLReasonCACompromise         CRLReason = 2
+	CRLReasonAffiliationChanged   CRLReason = 3
+	CRLReasonSuperseded           CRLReason = 4
+	CRLReasonCessationOfOperation CRLReason = 5
+	CRLReasonCertificateHold      CRLReason = 6
+	CRLReasonRemoveFromCRL        CRLReason = 8
+	CRLReasonPrivilegeWithdrawn   CRLReason = 9
+	CRLReasonAACompromise         CRLReason = 10
+)
+
+// crlReasonNames contains the RFC 5280 names of the revocation reason codes.
+var crlReasonNames = map[CRLReason]string{
+	CRLReasonUnspecified:          "unspecified",
+	CRLReasonKeyCompromise:        "keyCompromise",
+	CRLReasonCACompromise:         "cACompromise",
+	CRLReasonAffiliationChanged:   "affiliationChanged",
+	CRLReasonSuperseded:           "superseded",
+	CRLReasonCessationOfOperation: "cessationOfOperation",
+	CRLReasonCertificateHold:      "certificateHold",
+	CRLReasonRemoveFromCRL:        "removeFromCRL",
+	CRLReasonPrivilegeWithdrawn:   "privilegeWithdrawn",
+	CRLReasonAACompromise:         "aACompromise",
+}
+
+// crlReasonTexts contains the OpenSSL names of the revocation reason codes.
+var crlReasonTexts = map[CRLReason]string{
+	CRLReasonUnspecified:          "Unspecified",
+	CRLReasonKeyCompromise:        "Key Compromise",
+	CRLReasonCACompromise:         "CA Compromise",
+	CRLReasonAffiliationChanged:   "Affiliation Changed",
+	CRLReasonSuperseded:           "Superseded",
+	CRLReasonCessationOfOperation: "Cessation Of Operation",
+	CRLReasonCertificateHold:      "Certificate Hold",
+	CRLReasonRemoveFromCRL:        "Remove From CRL",
+	CRLReasonPrivilegeWithdrawn:   "Privilege Withdrawn",
+	CRLReasonAACompromise:         "AA Compromise",
+}
+
+// ReasonCode represents an X509 CRL entry reason code extension as defined in
+// RFC 5280 section 5.3.1.
+type ReasonCode struct {
+	Critical bool
+	Reason   CRLReason
+}
+
+// Marshal returns a pkix.Extension.
+func (e ReasonCode) Marshal() (pkix.Extension, error) {
+	if !e.Reason.valid() {
+		return pkix.Extension{}, fmt.Errorf("invalid reason code: %d", e.Reason)
+	}
+
+	der, err := asn1.Marshal(asn1.Enumerated(e.Reason))
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       pgasn1.OIDReasonCode,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *ReasonCode) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(pgasn1.OIDReasonCode) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var reason asn1.Enumerated
+	if rest, err := asn1.Unmarshal(ext.Value, &reason); err != nil {
+		return err
+	} else if len(rest) > 0 {
+		return ErrTrailingBytes
+	}
+
+	if !CRLReason(reason).valid() {
+		return fmt.Errorf("invalid reason code: %d", reason)
+	}
+
+	*e = ReasonCode{
+		Critical: ext.Critical,
+		Reason:   CRLReason(reason),
+	}
+
+	return nil
+}
+
+// valid returns true if the reason code is one defined in RFC 5280.
+func (r CRLReason) valid() bool {
+	return r >= CRLReasonUnspecified && r <= CRLReasonAACompromise && r != 7
+}
+
+// reasonCodeJSON is the JSON representation of a ReasonCode.
+type reasonCodeJSON struct {
+	Critical bool      `json:"critical"`
+	Reason   CRLReason `json:"reason"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "reason" members. See CRLReason.MarshalJSON.
+func (e ReasonCode) MarshalJSON() ([]byte, error) {
+	return json.Marshal(reasonCodeJSON{
+		Critical: e.Critical,
+		Reason:   e.Reason,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *ReasonCode) UnmarshalJSON(b []byte) error {
+	var tmp reasonCodeJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = ReasonCode{
+		Critical: tmp.Critical,
+		Reason:   tmp.Reason,
+	}
+
+	return nil
+}
+
+// MarshalJSON returns the JSON encoding of the reason code, which is a
+// string containing its RFC 5280 name, such as "keyCompromise".
+func (r CRLReason) MarshalJSON() ([]byte, error) {
+	name, ok := crlReasonNames[r]
+	if !ok {
+		return nil, fmt.Errorf("invalid reason code: %d", r)
+	}
+
+	return json.Marshal(name)
+}
+
+// UnmarshalJSON parses a JSON-encoded reason code and stores the result in
+// the object.
+func (r *CRLReason) UnmarshalJSON(b []byte) error {
+	var name string
+	if err := json.Unmarshal(b, &name); err != nil {
+		return err
+	}
+
+	for reason, n := range crlReasonNames {
+		if n == name {
+			*r = reason
+			return nil
+		}
+	}
+
+	return fmt.Errorf("unknown reason code: %q", name)
+}
+
+// String returns a text representation of the extension. See Text.
+func (e ReasonCode) String() string {
+	var s, ok = crlReasonTexts[e.Reason]
+	if !ok {
+		s = fmt.Sprintf("%d", e.Reason)
+	}
+
+	return text(pgasn1.OIDReasonCode, e.Critical, s)
+}
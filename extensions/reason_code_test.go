@@ -0,0 +1,137 @@
+package extensions_test
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestReasonCodeMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.ReasonCode
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext:  extensions.ReasonCode{Reason: extensions.CRLReasonKeyCompromise},
+			want: pkix.Extension{
+				Id:    pgasn1.OIDReasonCode,
+				Value: []byte{asn1.TagEnum, 1, 1},
+			},
+		},
+		{
+			name: "Unused",
+			ext:  extensions.ReasonCode{Reason: 7},
+			want: pkix.Extension{},
+			err:  errors.New("unused reason code"),
+		},
+		{
+			name: "OutOfRange",
+			ext:  extensions.ReasonCode{Reason: 11},
+			want: pkix.Extension{},
+			err:  errors.New("reason code out of range"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestReasonCodeUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.ReasonCode
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDReasonCode,
+				Value: []byte{asn1.TagEnum, 1, 10},
+			},
+			want: extensions.ReasonCode{Reason: extensions.CRLReasonAACompromise},
+		},
+		{
+			name: "Unused",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDReasonCode,
+				Value: []byte{asn1.TagEnum, 1, 7},
+			},
+			want: extensions.ReasonCode{},
+			err:  errors.New("unused reason code"),
+		},
+		{
+			name: "NotEnumerated",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDReasonCode,
+				Value: []byte{asn1.TagInteger, 1, 1},
+			},
+			want: extensions.ReasonCode{},
+			err:  errors.New("not enumerated"),
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDInvalidityDate,
+				Value: []byte{asn1.TagEnum, 1, 1},
+			},
+			want: extensions.ReasonCode{},
+			err:  errors.New("bad OID"),
+		},
+		{
+			name: "TrailingBytes",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDReasonCode,
+				Value: []byte{asn1.TagEnum, 1, 1, 0xff},
+			},
+			want: extensions.ReasonCode{},
+			err:  errors.New("trailing bytes"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.ReasonCode
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
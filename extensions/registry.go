@@ -0,0 +1,101 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	goasn1 "encoding/asn1"
+	"errors"
+	"fmt"
+	"sync"
+
+	"github.com/paulgriffiths/pki/asn1"
+)
+
+// registry maps the string representation of an extension OID to a
+// function returning a new extension of the corresponding type.
+var registry = struct {
+	sync.RWMutex
+	m map[string]func() Extension
+}{
+	m: map[string]func() Extension{
+		asn1.OIDSubjectDirectoryAttributes.String():     func() Extension { return &SubjectDirectoryAttributes{} },
+		asn1.OIDSubjectKeyIdentifier.String():           func() Extension { return &SubjectKeyIdentifier{} },
+		asn1.OIDKeyUsage.String():                       func() Extension { return &KeyUsage{} },
+		asn1.OIDPrivateKeyUsagePeriod.String():          func() Extension { return &PrivateKeyUsagePeriod{} },
+		asn1.OIDSubjectAltName.String():                 func() Extension { return &SubjectAltName{} },
+		asn1.OIDIssuerAltName.String():                  func() Extension { return &IssuerAltName{} },
+		asn1.OIDBasicConstraints.String():               func() Extension { return &BasicConstraints{} },
+		asn1.OIDCRLNumber.String():                      func() Extension { return &CRLNumber{} },
+		asn1.OIDReasonCode.String():                     func() Extension { return &ReasonCode{} },
+		asn1.OIDInvalidityDate.String():                 func() Extension { return &InvalidityDate{} },
+		asn1.OIDDeltaCRLIndicator.String():              func() Extension { return &DeltaCRLIndicator{} },
+		asn1.OIDIssuingDistributionPoint.String():       func() Extension { return &IssuingDistributionPoint{} },
+		asn1.OIDCertificateIssuer.String():              func() Extension { return &CertificateIssuer{} },
+		asn1.OIDNameConstraints.String():                func() Extension { return &NameConstraints{} },
+		asn1.OIDCRLDistributionPoints.String():          func() Extension { return &CRLDistributionPoints{} },
+		asn1.OIDCertificatePolicies.String():            func() Extension { return &CertificatePolicies{} },
+		asn1.OIDPolicyMappings.String():                 func() Extension { return &PolicyMappings{} },
+		asn1.OIDAuthorityKeyIdentifier.String():         func() Extension { return &AuthorityKeyIdentifier{} },
+		asn1.OIDPolicyConstraints.String():              func() Extension { return &PolicyConstraints{} },
+		asn1.OIDExtendedKeyUsage.String():               func() Extension { return &ExtendedKeyUsage{} },
+		asn1.OIDFreshestCRL.String():                    func() Extension { return &FreshestCRL{} },
+		asn1.OIDInhibitAnyPolicy.String():               func() Extension { return &InhibitAnyPolicy{} },
+		asn1.OIDAuthorityInfoAccess.String():            func() Extension { return &AuthorityInfoAccess{} },
+		asn1.OIDQCStatements.String():                   func() Extension { return &QCStatements{} },
+		asn1.OIDIPAddrBlocks.String():                   func() Extension { return &IPAddrBlocks{} },
+		asn1.OIDAutonomousSysIDs.String():               func() Extension { return &ASIdentifiers{} },
+		asn1.OIDSubjectInfoAccess.String():              func() Extension { return &SubjectInfoAccess{} },
+		asn1.OIDTLSFeature.String():                     func() Extension { return &TLSFeature{} },
+		asn1.OIDACMEIdentifier.String():                 func() Extension { return &ACMEIdentifier{} },
+		asn1.OIDOCSPNonce.String():                      func() Extension { return &OCSPNonce{} },
+		asn1.OIDOCSPNoCheck.String():                    func() Extension { return &OCSPNoCheck{} },
+		asn1.OIDSMIMECapabilities.String():              func() Extension { return &SMIMECapabilities{} },
+		asn1.OIDSignedCertificateTimestampList.String(): func() Extension { return &SignedCertificateTimestampList{} },
+		asn1.OIDPrecertificatePoison.String():           func() Extension { return &PrecertificatePoison{} },
+		asn1.OIDMSCertificateTemplateName.String():      func() Extension { return &CertificateTemplateName{} },
+		asn1.OIDMSCAVersion.String():                    func() Extension { return &CAVersion{} },
+		asn1.OIDMSCertificateTemplate.String():          func() Extension { return &CertificateTemplate{} },
+		asn1.OIDMSApplicationPolicies.String():          func() Extension { return &ApplicationPolicies{} },
+	},
+}
+
+// Register registers a function returning a new extension of the type
+// identified by an OID, so that Parse can parse extensions of that type. An
+// error is returned if a type is already registered for the OID.
+func Register(oid goasn1.ObjectIdentifier, fn func() Extension) error {
+	if len(oid) == 0 {
+		return errors.New("no OID specified")
+	} else if fn == nil {
+		return errors.New("no extension function specified")
+	}
+
+	registry.Lock()
+	defer registry.Unlock()
+
+	if _, ok := registry.m[oid.String()]; ok {
+		return fmt.Errorf("extension type already registered for OID %v", oid)
+	}
+
+	registry.m[oid.String()] = fn
+
+	return nil
+}
+
+// Parse parses a pkix.Extension and returns an extension of the type
+// registered for its OID. If no type is registered for the OID, a *Raw is
+// returned.
+func Parse(ext pkix.Extension) (Extension, error) {
+	registry.RLock()
+	fn, ok := registry.m[ext.Id.String()]
+	registry.RUnlock()
+
+	var e Extension = &Raw{}
+	if ok {
+		e = fn()
+	}
+
+	if err := e.Unmarshal(ext); err != nil {
+		return nil, err
+	}
+
+	return e, nil
+}
@@ -0,0 +1,193 @@
+package extensions_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+// testPrivateExtension is a private extension type used to test extension
+// registration.
+type testPrivateExtension struct {
+	Critical bool
+	Value    int
+}
+
+var oidTestPrivateExtension = asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 1}
+
+func (e testPrivateExtension) Marshal() (pkix.Extension, error) {
+	der, err := asn1.Marshal(e.Value)
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       oidTestPrivateExtension,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+func (e *testPrivateExtension) Unmarshal(ext pkix.Extension) error {
+	var value int
+	if _, err := asn1.Unmarshal(ext.Value, &value); err != nil {
+		return err
+	}
+
+	*e = testPrivateExtension{Critical: ext.Critical, Value: value}
+
+	return nil
+}
+
+func init() {
+	if err := extensions.Register(oidTestPrivateExtension, func() extensions.Extension {
+		return &testPrivateExtension{}
+	}); err != nil {
+		panic(err)
+	}
+}
+
+func TestParse(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.Extension
+		err  error
+	}{
+		{
+			name: "KeyUsage",
+			ext: pkix.Extension{
+				Id:       pgasn1.OIDKeyUsage,
+				Critical: true,
+				Value:    []byte{asn1.TagBitString, 2, 1, 0x06},
+			},
+			want: &extensions.KeyUsage{
+				Critical: true,
+				Value:    x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+			},
+		},
+		{
+			name: "OCSPNoCheck",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDOCSPNoCheck,
+				Value: []byte{asn1.TagNull, 0},
+			},
+			want: &extensions.OCSPNoCheck{},
+		},
+		{
+			name: "Private",
+			ext: pkix.Extension{
+				Id:    oidTestPrivateExtension,
+				Value: []byte{asn1.TagInteger, 1, 42},
+			},
+			want: &testPrivateExtension{Value: 42},
+		},
+		{
+			name: "Unknown",
+			ext: pkix.Extension{
+				Id:       asn1.ObjectIdentifier{1, 2, 3, 4},
+				Critical: true,
+				Value:    []byte{asn1.TagInteger, 1, 42},
+			},
+			want: &extensions.Raw{
+				ID:       asn1.ObjectIdentifier{1, 2, 3, 4},
+				Critical: true,
+				Value:    []byte{asn1.TagInteger, 1, 42},
+			},
+		},
+		{
+			name: "BadValue",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDBasicConstraints,
+				Value: []byte{0xff},
+			},
+			err: errors.New("bad value"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := extensions.Parse(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+
+			if err != nil {
+				return
+			}
+
+			ext, err := got.Marshal()
+			if err != nil {
+				t.Fatalf("couldn't marshal extension: %v", err)
+			}
+
+			if !reflect.DeepEqual(ext, tc.ext) {
+				t.Errorf("got %v, want %v", ext, tc.ext)
+			}
+		})
+	}
+}
+
+func TestRegister(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		oid  asn1.ObjectIdentifier
+		fn   func() extensions.Extension
+		err  error
+	}{
+		{
+			name: "BuiltIn",
+			oid:  pgasn1.OIDKeyUsage,
+			fn:   func() extensions.Extension { return &extensions.KeyUsage{} },
+			err:  errors.New("already registered"),
+		},
+		{
+			name: "AlreadyRegistered",
+			oid:  oidTestPrivateExtension,
+			fn:   func() extensions.Extension { return &testPrivateExtension{} },
+			err:  errors.New("already registered"),
+		},
+		{
+			name: "NoOID",
+			fn:   func() extensions.Extension { return &testPrivateExtension{} },
+			err:  errors.New("no OID"),
+		},
+		{
+			name: "NoFunction",
+			oid:  asn1.ObjectIdentifier{1, 3, 6, 1, 4, 1, 99999, 2},
+			err:  errors.New("no function"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			err := extensions.Register(tc.oid, tc.fn)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+		})
+	}
+}
@@ -2,10 +2,8 @@ package extensions
 
 import (
 	"crypto/x509/pkix"
-	"errors"
-	"fmt"
-	"net"
-	"net/url"
+	"encoding/json"
+	"strings"
 
 	"github.com/paulgriffiths/pki/asn1"
 )
@@ -13,57 +11,58 @@ import (
 // SubjectAltName represents a subject alternative name extension as defined
 // in RFC5280 section 4.2.1.6.
 type SubjectAltName struct {
-	Critical       bool
-	DNSNames       []string
-	EmailAddresses []string
-	IPAddresses    []net.IP
-	URIs           []*url.URL
+	Critical bool
+	asn1.GeneralNames
 }
 
 // Marshal returns a pkix.Extension.
 func (e SubjectAltName) Marshal() (pkix.Extension, error) {
-	if len(e.DNSNames) == 0 &&
-		len(e.EmailAddresses) == 0 &&
-		len(e.IPAddresses) == 0 &&
-		len(e.URIs) == 0 {
-		return pkix.Extension{}, errors.New("no names specified")
-	}
+	return marshalGeneralNames(asn1.OIDSubjectAltName, e.Critical, e.GeneralNames)
+}
 
-	der, err := asn1.GeneralNames{
-		DNSNames:       e.DNSNames,
-		EmailAddresses: e.EmailAddresses,
-		IPAddresses:    e.IPAddresses,
-		URIs:           e.URIs,
-	}.Marshal()
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *SubjectAltName) Unmarshal(ext pkix.Extension) error {
+	names, err := unmarshalGeneralNames(asn1.OIDSubjectAltName, ext)
 	if err != nil {
-		return pkix.Extension{}, nil
+		return err
 	}
 
-	return pkix.Extension{
-		Id:       asn1.OIDSubjectAltName,
-		Critical: e.Critical,
-		Value:    der,
-	}, nil
+	*e = SubjectAltName{
+		Critical:     ext.Critical,
+		GeneralNames: names,
+	}
+
+	return nil
 }
 
-// Unmarshal parses a pkix.Extension and stores the result in the object.
-func (e *SubjectAltName) Unmarshal(ext pkix.Extension) error {
-	if !ext.Id.Equal(asn1.OIDSubjectAltName) {
-		return fmt.Errorf("unexpected OID: %v", ext.Id)
-	}
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "names" members. See asn1.GeneralNames.MarshalJSON.
+func (e SubjectAltName) MarshalJSON() ([]byte, error) {
+	return json.Marshal(generalNamesJSON{
+		Critical: e.Critical,
+		Names:    e.GeneralNames,
+	})
+}
 
-	var ae asn1.GeneralNames
-	if err := ae.Unmarshal(ext.Value); err != nil {
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *SubjectAltName) UnmarshalJSON(b []byte) error {
+	var tmp generalNamesJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
 		return err
 	}
 
 	*e = SubjectAltName{
-		Critical:       ext.Critical,
-		DNSNames:       ae.DNSNames,
-		EmailAddresses: ae.EmailAddresses,
-		IPAddresses:    ae.IPAddresses,
-		URIs:           ae.URIs,
+		Critical:     tmp.Critical,
+		GeneralNames: tmp.Names,
 	}
 
 	return nil
 }
+
+// String returns a text representation of the extension. See Text.
+func (e SubjectAltName) String() string {
+	var names = generalNamesText(e.GeneralNames)
+
+	return text(asn1.OIDSubjectAltName, e.Critical, strings.Join(names, ", "))
+}
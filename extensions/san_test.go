@@ -38,8 +38,10 @@ func TestSubjectAltNameMarshal(t *testing.T) {
 		{
 			name: "OK",
 			ext: extensions.SubjectAltName{
-				Critical:    true,
-				IPAddresses: []net.IP{net.ParseIP("10.0.0.1")},
+				Critical: true,
+				GeneralNames: pgasn1.GeneralNames{
+					{IPAddress: net.ParseIP("10.0.0.1")},
+				},
 			},
 			want: pkix.Extension{
 				Id:       pgasn1.OIDSubjectAltName,
@@ -55,6 +57,16 @@ func TestSubjectAltNameMarshal(t *testing.T) {
 			want: pkix.Extension{},
 			err:  errors.New("no names"),
 		},
+		{
+			name: "BadName",
+			ext: extensions.SubjectAltName{
+				GeneralNames: pgasn1.GeneralNames{
+					{DNSName: "..."},
+				},
+			},
+			want: pkix.Extension{},
+			err:  errors.New("bad name"),
+		},
 	}
 
 	for _, tc := range testcases {
@@ -94,8 +106,10 @@ func TestSubjectAltNameUnmarshal(t *testing.T) {
 				},
 			},
 			want: extensions.SubjectAltName{
-				Critical:    true,
-				IPAddresses: []net.IP{net.ParseIP("10.0.0.1").To4()},
+				Critical: true,
+				GeneralNames: pgasn1.GeneralNames{
+					{IPAddress: net.ParseIP("10.0.0.1").To4()},
+				},
 			},
 		},
 		{
@@ -131,3 +145,41 @@ func TestSubjectAltNameUnmarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestSubjectAltNameRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var ext = pkix.Extension{
+		Id: pgasn1.OIDSubjectAltName,
+		Value: []byte{asn1.TagSequence | bit6, 42,
+			nameTagDNSName | asn1.ClassContextSpecific<<6, 3, 'f', 'o', 'o',
+			nameTagDirectoryName | asn1.ClassContextSpecific<<6 | bit6, 16,
+			asn1.TagSequence | bit6, 14,
+			asn1.TagSet | bit6, 12,
+			asn1.TagSequence | bit6, 10,
+			asn1.TagOID, 3, 0x55, 0x04, 0x03,
+			asn1.TagPrintableString, 3, 'F', 'o', 'o',
+			nameTagOtherName | asn1.ClassContextSpecific<<6 | bit6, 12,
+			asn1.TagOID, 3, 0x2a, 0x03, 0x04,
+			0 | asn1.ClassContextSpecific<<6 | bit6, 5,
+			asn1.TagUTF8String, 3, 'F', 'o', 'o',
+			nameTagRegisteredID | asn1.ClassContextSpecific<<6, 3, 0x2a, 0x03, 0x04,
+		},
+	}
+
+	var san extensions.SubjectAltName
+	if err := san.Unmarshal(ext); err != nil {
+		t.Fatalf("couldn't unmarshal extension: %v", err)
+	}
+
+	if got := mustMarshal(t, &san); !reflect.DeepEqual(got, ext) {
+		t.Errorf("got %x, want %x", got.Value, ext.Value)
+	}
+
+	var want = "X509v3 Subject Alternative Name:\n" +
+		"    DNS:foo, DirName:CN = Foo, othername:<unsupported>, Registered ID:1.2.3.4"
+
+	if got := san.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,490 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/binary"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+	"time"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+// SignedCertificateTimestampList represents a Certificate Transparency signed
+// certificate timestamp list extension as defined in RFC 6962 section 3.3.
+// The extension value is an OCTET STRING containing a TLS-encoded
+// SignedCertificateTimestampList.
+type SignedCertificateTimestampList struct {
+	Critical bool
+	SCTs     []SignedCertificateTimestamp
+}
+
+// SignedCertificateTimestamp represents a signed certificate timestamp as
+// defined in RFC 6962 section 3.2. Timestamp is the number of milliseconds
+// since the UNIX epoch, ignoring leap seconds.
+//
+// The format of versions other than v1 is unknown, and RFC 6962 requires
+// clients to ignore timestamps they don't understand. For such a version,
+// Raw contains the complete TLS-encoded timestamp, including the version,
+// and the other fields apart from Version are zero.
+//
+// struct {
+//     Version sct_version;
+//     LogID id;
+//     uint64 timestamp;
+//     CtExtensions extensions;
+//     digitally-signed struct { ... };
+// } SignedCertificateTimestamp;
+type SignedCertificateTimestamp struct {
+	Version    SCTVersion
+	LogID      [sha256Size]byte
+	Timestamp  uint64
+	Extensions []byte
+	Signature  DigitallySigned
+	Raw        []byte
+}
+
+// DigitallySigned represents a TLS digitally-signed element as defined in
+// RFC 5246 section 4.7.
+//
+// struct {
+//     SignatureAndHashAlgorithm algorithm;
+//     opaque signature<0..2^16-1>;
+// } DigitallySigned;
+type DigitallySigned struct {
+	HashAlgorithm      HashAlgorithm
+	SignatureAlgorithm SignatureAlgorithm
+	Signature          []byte
+}
+
+// SCTVersion is a signed certificate timestamp version.
+type SCTVersion uint8
+
+// HashAlgorithm is a TLS hash algorithm as defined in RFC 5246 section
+// 7.4.1.4.1.
+type HashAlgorithm uint8
+
+// SignatureAlgorithm is a TLS signature algorithm as defined in RFC 5246
+// section 7.4.1.4.1.
+type SignatureAlgorithm uint8
+
+// Signed certificate timestamp version values.
+const (
+	SCTVersionV1 SCTVersion = 0
+)
+
+// TLS hash algorithm values.
+const (
+	HashAlgorithmNone   HashAlgorithm = 0
+	HashAlgorithmMD5    HashAlgorithm = 1
+	HashAlgorithmSHA1   HashAlgorithm = 2
+	HashAlgorithmSHA224 HashAlgorithm = 3
+	HashAlgorithmSHA256 HashAlgorithm = 4
+	HashAlgorithmSHA384 HashAlgorithm = 5
+	HashAlgorithmSHA512 HashAlgorithm = 6
+)
+
+// TLS signature algorithm values.
+const (
+	SignatureAlgorithmAnonymous SignatureAlgorithm = 0
+	SignatureAlgorithmRSA       SignatureAlgorithm = 1
+	SignatureAlgorithmDSA       SignatureAlgorithm = 2
+	SignatureAlgorithmECDSA     SignatureAlgorithm = 3
+)
+
+// hashAlgorithmTexts contains the OpenSSL names of the TLS hash algorithms.
+var hashAlgorithmTexts = map[HashAlgorithm]string{
+	HashAlgorithmMD5:    "MD5",
+	HashAlgorithmSHA1:   "SHA1",
+	HashAlgorithmSHA224: "SHA224",
+	HashAlgorithmSHA256: "SHA256",
+	HashAlgorithmSHA384: "SHA384",
+	HashAlgorithmSHA512: "SHA512",
+}
+
+// sctTimeTextLayout is the layout of a signed certificate timestamp in the
+// text representation of the extension.
+const sctTimeTextLayout = "Jan _2 15:04:05.000 2006 GMT"
+
+// sha256Size is the length of a log ID, which is the SHA-256 hash of the
+// log's public key.
+const sha256Size = 32
+
+// maxTLSVectorLen is the maximum length of a TLS vector with a two-byte
+// length prefix.
+const maxTLSVectorLen = 1<<16 - 1
+
+// Marshal returns a pkix.Extension.
+func (e SignedCertificateTimestampList) Marshal() (pkix.Extension, error) {
+	if len(e.SCTs) == 0 {
+		return pkix.Extension{}, errors.New("no signed certificate timestamps specified")
+	}
+
+	var list []byte
+
+	for _, sct := range e.SCTs {
+		b, err := sct.marshal()
+		if err != nil {
+			return pkix.Extension{}, err
+		}
+
+		if list, err = appendTLSVector(list, b, 1); err != nil {
+			return pkix.Extension{}, err
+		}
+	}
+
+	tls, err := appendTLSVector(nil, list, 1)
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	der, err := asn1.Marshal(tls)
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       pgasn1.OIDSignedCertificateTimestampList,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *SignedCertificateTimestampList) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(pgasn1.OIDSignedCertificateTimestampList) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var tls []byte
+	if rest, err := asn1.Unmarshal(ext.Value, &tls); err != nil {
+		return err
+	} else if len(rest) > 0 {
+		return ErrTrailingBytes
+	}
+
+	list, rest, err := readTLSVector(tls, 1)
+	if err != nil {
+		return err
+	} else if len(rest) > 0 {
+		return errors.New("trailing bytes after signed certificate timestamp list")
+	}
+
+	var scts []SignedCertificateTimestamp
+
+	for len(list) > 0 {
+		var b []byte
+		if b, list, err = readTLSVector(list, 1); err != nil {
+			return err
+		}
+
+		var sct SignedCertificateTimestamp
+		if err := sct.unmarshal(b); err != nil {
+			return err
+		}
+
+		scts = append(scts, sct)
+	}
+
+	*e = SignedCertificateTimestampList{
+		Critical: ext.Critical,
+		SCTs:     scts,
+	}
+
+	return nil
+}
+
+// signedCertificateTimestampListJSON is the JSON representation of a
+// SignedCertificateTimestampList.
+type signedCertificateTimestampListJSON struct {
+	Critical bool                             `json:"critical"`
+	SCTs     []signedCertificateTimestampJSON `json:"scts"`
+}
+
+// signedCertificateTimestampJSON is the JSON representation of a
+// SignedCertificateTimestamp.
+type signedCertificateTimestampJSON struct {
+	Version    SCTVersion           `json:"version"`
+	LogID      hexBytes             `json:"logID,omitempty"`
+	Timestamp  uint64               `json:"timestamp,omitempty"`
+	Extensions hexBytes             `json:"extensions,omitempty"`
+	Signature  *digitallySignedJSON `json:"signature,omitempty"`
+	Raw        hexBytes             `json:"raw,omitempty"`
+}
+
+// digitallySignedJSON is the JSON representation of a DigitallySigned.
+type digitallySignedJSON struct {
+	HashAlgorithm      HashAlgorithm      `json:"hashAlgorithm"`
+	SignatureAlgorithm SignatureAlgorithm `json:"signatureAlgorithm"`
+	Signature          hexBytes           `json:"signature"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "scts" members. Each signed certificate timestamp is
+// an object with "version", "logID", "timestamp", optional "extensions" and
+// "signature" members. The log ID and extensions are hex strings, and the
+// timestamp is the number of milliseconds since the UNIX epoch. The
+// signature is an object with "hashAlgorithm" and "signatureAlgorithm"
+// members containing the TLS algorithm numbers, and a "signature" member
+// containing a hex string. A timestamp with a version other than v1 has
+// only "version" and "raw" members, the latter a hex string containing the
+// TLS-encoded timestamp.
+func (e SignedCertificateTimestampList) MarshalJSON() ([]byte, error) {
+	var tmp = signedCertificateTimestampListJSON{
+		Critical: e.Critical,
+		SCTs:     []signedCertificateTimestampJSON{},
+	}
+
+	for _, sct := range e.SCTs {
+		if sct.Version != SCTVersionV1 {
+			tmp.SCTs = append(tmp.SCTs, signedCertificateTimestampJSON{
+				Version: sct.Version,
+				Raw:     sct.Raw,
+			})
+			continue
+		}
+
+		tmp.SCTs = append(tmp.SCTs, signedCertificateTimestampJSON{
+			Version:    sct.Version,
+			LogID:      sct.LogID[:],
+			Timestamp:  sct.Timestamp,
+			Extensions: sct.Extensions,
+			Signature: &digitallySignedJSON{
+				HashAlgorithm:      sct.Signature.HashAlgorithm,
+				SignatureAlgorithm: sct.Signature.SignatureAlgorithm,
+				Signature:          sct.Signature.Signature,
+			},
+		})
+	}
+
+	return json.Marshal(tmp)
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *SignedCertificateTimestampList) UnmarshalJSON(b []byte) error {
+	var tmp signedCertificateTimestampListJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	var scts []SignedCertificateTimestamp
+
+	for _, s := range tmp.SCTs {
+		if s.Version != SCTVersionV1 {
+			scts = append(scts, SignedCertificateTimestamp{
+				Version: s.Version,
+				Raw:     s.Raw,
+			})
+			continue
+		}
+
+		if len(s.LogID) != sha256Size {
+			return fmt.Errorf("log ID has length %d, want %d", len(s.LogID), sha256Size)
+		}
+
+		if s.Signature == nil {
+			return errors.New("missing signed certificate timestamp signature")
+		}
+
+		var sct = SignedCertificateTimestamp{
+			Version:    s.Version,
+			Timestamp:  s.Timestamp,
+			Extensions: s.Extensions,
+			Signature: DigitallySigned{
+				HashAlgorithm:      s.Signature.HashAlgorithm,
+				SignatureAlgorithm: s.Signature.SignatureAlgorithm,
+				Signature:          s.Signature.Signature,
+			},
+		}
+		copy(sct.LogID[:], s.LogID)
+
+		scts = append(scts, sct)
+	}
+
+	*e = SignedCertificateTimestampList{
+		Critical: tmp.Critical,
+		SCTs:     scts,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e SignedCertificateTimestampList) String() string {
+	var lines []string
+
+	for _, sct := range e.SCTs {
+		if sct.Version != SCTVersionV1 {
+			lines = append(lines,
+				"Signed Certificate Timestamp:",
+				nestedIndent+fmt.Sprintf("Version   : unknown (0x%x)", int(sct.Version)),
+				nestedIndent+"Data      : "+hexText(sct.Raw),
+			)
+			continue
+		}
+
+		var version = "v1 (0x0)"
+
+		var exts = "none"
+		if len(sct.Extensions) != 0 {
+			exts = hexText(sct.Extensions)
+		}
+
+		lines = append(lines,
+			"Signed Certificate Timestamp:",
+			nestedIndent+"Version   : "+version,
+			nestedIndent+"Log ID    : "+hexText(sct.LogID[:]),
+			nestedIndent+"Timestamp : "+sct.Time().UTC().Format(sctTimeTextLayout),
+			nestedIndent+"Extensions: "+exts,
+			nestedIndent+"Signature : "+sct.Signature.algorithmText(),
+			nestedIndent+"            "+hexText(sct.Signature.Signature),
+		)
+	}
+
+	return text(pgasn1.OIDSignedCertificateTimestampList, e.Critical, lines...)
+}
+
+// Time returns the timestamp as a time.Time.
+func (s SignedCertificateTimestamp) Time() time.Time {
+	return time.Unix(int64(s.Timestamp/1000), int64(s.Timestamp%1000)*int64(time.Millisecond)).UTC()
+}
+
+// marshal returns the TLS-encoding of a signed certificate timestamp.
+func (s SignedCertificateTimestamp) marshal() ([]byte, error) {
+	if s.Version != SCTVersionV1 {
+		if len(s.Raw) == 0 || s.Raw[0] != byte(s.Version) {
+			return nil, fmt.Errorf("no encoding for signed certificate timestamp version %d", s.Version)
+		}
+
+		return s.Raw, nil
+	}
+
+	var b = []byte{byte(s.Version)}
+	b = append(b, s.LogID[:]...)
+	b = append(b, make([]byte, 8)...)
+	binary.BigEndian.PutUint64(b[len(b)-8:], s.Timestamp)
+
+	b, err := appendTLSVector(b, s.Extensions, 0)
+	if err != nil {
+		return nil, err
+	}
+
+	b = append(b, byte(s.Signature.HashAlgorithm), byte(s.Signature.SignatureAlgorithm))
+
+	return appendTLSVector(b, s.Signature.Signature, 0)
+}
+
+// unmarshal parses a TLS-encoded signed certificate timestamp and stores the
+// result in the object.
+func (s *SignedCertificateTimestamp) unmarshal(b []byte) error {
+	if len(b) < 1 {
+		return errors.New("truncated signed certificate timestamp")
+	}
+
+	var tmp = SignedCertificateTimestamp{Version: SCTVersion(b[0])}
+
+	// The format of any other version is unknown, so it can't be parsed.
+	// See RFC 6962 section 3.2.
+	if tmp.Version != SCTVersionV1 {
+		tmp.Raw = append([]byte(nil), b...)
+		*s = tmp
+
+		return nil
+	}
+
+	b = b[1:]
+
+	if len(b) < sha256Size+8 {
+		return errors.New("truncated signed certificate timestamp")
+	}
+
+	copy(tmp.LogID[:], b)
+	tmp.Timestamp = binary.BigEndian.Uint64(b[sha256Size:])
+	b = b[sha256Size+8:]
+
+	var err error
+	if tmp.Extensions, b, err = readTLSVector(b, 0); err != nil {
+		return err
+	}
+
+	if len(b) < 2 {
+		return errors.New("truncated signed certificate timestamp")
+	}
+
+	tmp.Signature.HashAlgorithm = HashAlgorithm(b[0])
+	tmp.Signature.SignatureAlgorithm = SignatureAlgorithm(b[1])
+
+	if tmp.Signature.Signature, b, err = readTLSVector(b[2:], 0); err != nil {
+		return err
+	} else if len(b) > 0 {
+		return errors.New("trailing bytes after signed certificate timestamp")
+	}
+
+	*s = tmp
+
+	return nil
+}
+
+// appendTLSVector appends a TLS variable-length vector with a two-byte length
+// prefix to a byte slice. An error is returned if the length of the data is
+// less than min or greater than the maximum.
+func appendTLSVector(b, data []byte, min int) ([]byte, error) {
+	if len(data) < min || len(data) > maxTLSVectorLen {
+		return nil, fmt.Errorf("invalid TLS vector length: %d", len(data))
+	}
+
+	b = append(b, byte(len(data)>>8), byte(len(data)))
+
+	return append(b, data...), nil
+}
+
+// readTLSVector reads a TLS variable-length vector with a two-byte length
+// prefix, and returns its contents and any remaining bytes. A nil slice is
+// returned for an empty vector.
+func readTLSVector(b []byte, min int) ([]byte, []byte, error) {
+	if len(b) < 2 {
+		return nil, nil, errors.New("truncated TLS vector")
+	}
+
+	var n = int(binary.BigEndian.Uint16(b))
+	b = b[2:]
+
+	if len(b) < n {
+		return nil, nil, errors.New("truncated TLS vector")
+	} else if n < min {
+		return nil, nil, fmt.Errorf("invalid TLS vector length: %d", n)
+	}
+
+	if n == 0 {
+		return nil, b, nil
+	}
+
+	return append([]byte{}, b[:n]...), b[n:], nil
+}
+
+// algorithmText returns the OpenSSL name of the signature algorithm, such as
+// "ecdsa-with-SHA256".
+func (d DigitallySigned) algorithmText() string {
+	var hash, ok = hashAlgorithmTexts[d.HashAlgorithm]
+	if !ok {
+		hash = fmt.Sprintf("hash %d", d.HashAlgorithm)
+	}
+
+	switch d.SignatureAlgorithm {
+	case SignatureAlgorithmRSA:
+		return strings.ToLower(hash) + "WithRSAEncryption"
+
+	case SignatureAlgorithmDSA:
+		return "dsa_with_" + hash
+
+	case SignatureAlgorithmECDSA:
+		return "ecdsa-with-" + hash
+	}
+
+	return fmt.Sprintf("%s with signature %d", hash, d.SignatureAlgorithm)
+}
@@ -0,0 +1,285 @@
+package extensions_test
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+	"time"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestSignedCertificateTimestampListMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.SignedCertificateTimestampList
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.SignedCertificateTimestampList{
+				SCTs: []extensions.SignedCertificateTimestamp{testSCT()},
+			},
+			want: pkix.Extension{
+				Id:    pgasn1.OIDSignedCertificateTimestampList,
+				Value: testSCTListDER(),
+			},
+		},
+		{
+			name: "Empty",
+			ext:  extensions.SignedCertificateTimestampList{},
+			want: pkix.Extension{},
+			err:  errors.New("no SCTs"),
+		},
+		{
+			name: "BadVersion",
+			ext: extensions.SignedCertificateTimestampList{
+				SCTs: []extensions.SignedCertificateTimestamp{{Version: 1}},
+			},
+			want: pkix.Extension{},
+			err:  errors.New("bad version"),
+		},
+		{
+			name: "UnknownVersion",
+			ext: extensions.SignedCertificateTimestampList{
+				SCTs: []extensions.SignedCertificateTimestamp{
+					{Version: 2, Raw: []byte{2, 0xab}},
+				},
+			},
+			want: pkix.Extension{
+				Id:    pgasn1.OIDSignedCertificateTimestampList,
+				Value: []byte{asn1.TagOctetString, 6, 0, 4, 0, 2, 2, 0xab},
+			},
+		},
+		{
+			name: "RawVersionMismatch",
+			ext: extensions.SignedCertificateTimestampList{
+				SCTs: []extensions.SignedCertificateTimestamp{
+					{Version: 2, Raw: []byte{1, 0xab}},
+				},
+			},
+			want: pkix.Extension{},
+			err:  errors.New("version mismatch"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSignedCertificateTimestampListUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.SignedCertificateTimestampList
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDSignedCertificateTimestampList,
+				Value: testSCTListDER(),
+			},
+			want: extensions.SignedCertificateTimestampList{
+				SCTs: []extensions.SignedCertificateTimestamp{testSCT()},
+			},
+		},
+		{
+			name: "EmptyList",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDSignedCertificateTimestampList,
+				Value: []byte{asn1.TagOctetString, 2, 0, 0},
+			},
+			want: extensions.SignedCertificateTimestampList{},
+			err:  errors.New("empty list"),
+		},
+		{
+			name: "TruncatedList",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDSignedCertificateTimestampList,
+				Value: []byte{asn1.TagOctetString, 4, 0, 3, 0, 1},
+			},
+			want: extensions.SignedCertificateTimestampList{},
+			err:  errors.New("truncated list"),
+		},
+		{
+			name: "TrailingTLSBytes",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDSignedCertificateTimestampList,
+				Value: withOctetString(append(testSCTListTLS(), 0)),
+			},
+			want: extensions.SignedCertificateTimestampList{},
+			err:  errors.New("trailing TLS bytes"),
+		},
+		{
+			name: "UnknownVersion",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDSignedCertificateTimestampList,
+				Value: []byte{asn1.TagOctetString, 5, 0, 3, 0, 1, 1},
+			},
+			want: extensions.SignedCertificateTimestampList{
+				SCTs: []extensions.SignedCertificateTimestamp{
+					{Version: 1, Raw: []byte{1}},
+				},
+			},
+		},
+		{
+			name: "MixedVersions",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDSignedCertificateTimestampList,
+				Value: testMixedSCTListDER(),
+			},
+			want: extensions.SignedCertificateTimestampList{
+				SCTs: []extensions.SignedCertificateTimestamp{
+					testSCT(),
+					{Version: 2, Raw: []byte{2, 0xab, 0xcd}},
+				},
+			},
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDPrecertificatePoison,
+				Value: testSCTListDER(),
+			},
+			want: extensions.SignedCertificateTimestampList{},
+			err:  errors.New("bad OID"),
+		},
+		{
+			name: "TrailingBytes",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDSignedCertificateTimestampList,
+				Value: append(testSCTListDER(), 0),
+			},
+			want: extensions.SignedCertificateTimestampList{},
+			err:  errors.New("trailing bytes"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.SignedCertificateTimestampList
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSignedCertificateTimestampListRoundTrip(t *testing.T) {
+	t.Parallel()
+
+	var ext = pkix.Extension{
+		Id:    pgasn1.OIDSignedCertificateTimestampList,
+		Value: testMixedSCTListDER(),
+	}
+
+	var e extensions.SignedCertificateTimestampList
+	if err := e.Unmarshal(ext); err != nil {
+		t.Fatalf("couldn't unmarshal extension: %v", err)
+	}
+
+	got, err := e.Marshal()
+	if err != nil {
+		t.Fatalf("couldn't marshal extension: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, ext) {
+		t.Errorf("got %v, want %v", got, ext)
+	}
+}
+
+func TestSignedCertificateTimestampTime(t *testing.T) {
+	t.Parallel()
+
+	var want = time.Date(2020, 1, 2, 3, 4, 5, 678000000, time.UTC)
+
+	if got := testSCT().Time(); !got.Equal(want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
+
+// testSCT returns a signed certificate timestamp matching testSCTListTLS.
+func testSCT() extensions.SignedCertificateTimestamp {
+	var sct = extensions.SignedCertificateTimestamp{
+		Version:    extensions.SCTVersionV1,
+		Timestamp:  1577934245678,
+		Extensions: []byte{0xaa},
+		Signature: extensions.DigitallySigned{
+			HashAlgorithm:      extensions.HashAlgorithmSHA256,
+			SignatureAlgorithm: extensions.SignatureAlgorithmECDSA,
+			Signature:          []byte{0x01, 0x02, 0x03, 0x04},
+		},
+	}
+
+	copy(sct.LogID[:], bytes.Repeat([]byte{0x11}, 32))
+
+	return sct
+}
+
+// testSCTListTLS returns a TLS-encoded signed certificate timestamp list
+// containing a single SCT.
+func testSCTListTLS() []byte {
+	var b = []byte{0, 54, 0, 52, 0}
+	b = append(b, bytes.Repeat([]byte{0x11}, 32)...)
+	b = append(b, 0x00, 0x00, 0x01, 0x6f, 0x64, 0x35, 0xcf, 0x2e)
+	b = append(b, 0, 1, 0xaa)
+	b = append(b, 4, 3, 0, 4, 0x01, 0x02, 0x03, 0x04)
+
+	return b
+}
+
+// testMixedSCTListDER returns the DER-encoded extension value for a signed
+// certificate timestamp list containing the SCT in testSCTListTLS followed
+// by an SCT with an unknown version.
+func testMixedSCTListDER() []byte {
+	var b = []byte{0, 59}
+	b = append(b, testSCTListTLS()[2:]...)
+	b = append(b, 0, 3, 2, 0xab, 0xcd)
+
+	return withOctetString(b)
+}
+
+// testSCTListDER returns the DER-encoded extension value for testSCTListTLS.
+func testSCTListDER() []byte {
+	return withOctetString(testSCTListTLS())
+}
+
+// withOctetString wraps a short byte slice in a DER-encoded OCTET STRING.
+func withOctetString(b []byte) []byte {
+	return append([]byte{asn1.TagOctetString, byte(len(b))}, b...)
+}
@@ -0,0 +1,566 @@
+package extensions
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	goasn1 "encoding/asn1"
+	"fmt"
+	"strings"
+
+	"github.com/paulgriffiths/pki/asn1"
+)
+
+// Set is a collection of extensions parsed from a certificate, certificate
+// request or CRL, in which each extension type appears at most once.
+// Extensions of types which are not registered, or which cannot be parsed,
+// are included as *Raw values. See Errors.
+type Set struct {
+	exts []Extension
+	oids map[string]int
+	errs []ParseError
+}
+
+// ParseError records an extension which could not be parsed.
+type ParseError struct {
+	ID  goasn1.ObjectIdentifier
+	Err error
+}
+
+// ParseAll parses a list of extensions, and returns an error if any OID
+// appears more than once. See RFC 5280 section 4.2. An extension which
+// cannot be parsed does not cause an error, so that one malformed or
+// unusual extension does not prevent the others from being used. It is
+// instead included in the set as a *Raw value, and reported by Errors.
+func ParseAll(exts []pkix.Extension) (Set, error) {
+	set, dups := parseSet(exts)
+	if len(dups) != 0 {
+		return Set{}, fmt.Errorf("duplicate extension: %v", dups[0])
+	}
+
+	return set, nil
+}
+
+// parseSet parses a list of extensions as for ParseAll, and returns the OIDs
+// of any extensions which were omitted from the set because an extension
+// with the same OID appeared earlier in the list.
+func parseSet(exts []pkix.Extension) (Set, []goasn1.ObjectIdentifier) {
+	var set = Set{oids: make(map[string]int)}
+	var dups []goasn1.ObjectIdentifier
+
+	for _, ext := range exts {
+		if _, ok := set.oids[ext.Id.String()]; ok {
+			dups = append(dups, ext.Id)
+			continue
+		}
+
+		e, err := Parse(ext)
+		if err != nil {
+			set.errs = append(set.errs, ParseError{ID: ext.Id, Err: err})
+			e = &Raw{ID: ext.Id, Critical: ext.Critical, Value: ext.Value}
+		}
+
+		set.oids[ext.Id.String()] = len(set.exts)
+		set.exts = append(set.exts, e)
+	}
+
+	return set, dups
+}
+
+// FromCertificate parses the extensions in a certificate.
+func FromCertificate(cert *x509.Certificate) (Set, error) {
+	return ParseAll(cert.Extensions)
+}
+
+// FromCertificateRequest parses the extensions in the extensionRequest
+// attribute of a certificate request, which are the extensions in its
+// Extensions field when it is parsed.
+func FromCertificateRequest(csr *x509.CertificateRequest) (Set, error) {
+	return ParseAll(csr.Extensions)
+}
+
+// FromCRL parses the extensions in a CRL. Extensions of the revoked
+// certificate entries are not included, and may be parsed with
+// FromRevocationListEntry.
+func FromCRL(crl *x509.RevocationList) (Set, error) {
+	return ParseAll(crl.Extensions)
+}
+
+// FromRevocationListEntry parses the extensions in a CRL entry.
+func FromRevocationListEntry(entry x509.RevocationListEntry) (Set, error) {
+	return ParseAll(entry.Extensions)
+}
+
+// Error returns a string representation of the error.
+func (e ParseError) Error() string {
+	return fmt.Sprintf("couldn't parse extension %v: %v", e.ID, e.Err)
+}
+
+// Errors returns the errors for any extensions which could not be parsed,
+// in the order in which they appear in the set.
+func (s Set) Errors() []ParseError {
+	return append([]ParseError{}, s.errs...)
+}
+
+// Len returns the number of extensions in the set.
+func (s Set) Len() int {
+	return len(s.exts)
+}
+
+// All returns the extensions in the set, in the order in which they were
+// parsed.
+func (s Set) All() []Extension {
+	return append([]Extension{}, s.exts...)
+}
+
+// Get returns the extension with the specified OID, if present.
+func (s Set) Get(oid goasn1.ObjectIdentifier) (Extension, bool) {
+	i, ok := s.oids[oid.String()]
+	if !ok {
+		return nil, false
+	}
+
+	return s.exts[i], true
+}
+
+// String returns the text representations of the extensions in the set, in
+// the order in which they were parsed, separated by newlines. See Text.
+func (s Set) String() string {
+	var texts []string
+
+	for _, e := range s.exts {
+		texts = append(texts, Text(e))
+	}
+
+	return strings.Join(texts, "\n")
+}
+
+// SubjectDirectoryAttributes returns the subject directory attributes
+// extension, if present.
+func (s Set) SubjectDirectoryAttributes() (SubjectDirectoryAttributes, bool) {
+	if e, ok := s.Get(asn1.OIDSubjectDirectoryAttributes); ok {
+		if v, ok := e.(*SubjectDirectoryAttributes); ok {
+			return *v, true
+		}
+	}
+
+	return SubjectDirectoryAttributes{}, false
+}
+
+// SubjectKeyIdentifier returns the subject key identifier extension, if
+// present.
+func (s Set) SubjectKeyIdentifier() (SubjectKeyIdentifier, bool) {
+	if e, ok := s.Get(asn1.OIDSubjectKeyIdentifier); ok {
+		if v, ok := e.(*SubjectKeyIdentifier); ok {
+			return *v, true
+		}
+	}
+
+	return SubjectKeyIdentifier{}, false
+}
+
+// KeyUsage returns the key usage extension, if present.
+func (s Set) KeyUsage() (KeyUsage, bool) {
+	if e, ok := s.Get(asn1.OIDKeyUsage); ok {
+		if v, ok := e.(*KeyUsage); ok {
+			return *v, true
+		}
+	}
+
+	return KeyUsage{}, false
+}
+
+// PrivateKeyUsagePeriod returns the private key usage period extension, if
+// present.
+func (s Set) PrivateKeyUsagePeriod() (PrivateKeyUsagePeriod, bool) {
+	if e, ok := s.Get(asn1.OIDPrivateKeyUsagePeriod); ok {
+		if v, ok := e.(*PrivateKeyUsagePeriod); ok {
+			return *v, true
+		}
+	}
+
+	return PrivateKeyUsagePeriod{}, false
+}
+
+// SubjectAltName returns the subject alternative name extension, if present.
+func (s Set) SubjectAltName() (SubjectAltName, bool) {
+	if e, ok := s.Get(asn1.OIDSubjectAltName); ok {
+		if v, ok := e.(*SubjectAltName); ok {
+			return *v, true
+		}
+	}
+
+	return SubjectAltName{}, false
+}
+
+// IssuerAltName returns the issuer alternative name extension, if present.
+func (s Set) IssuerAltName() (IssuerAltName, bool) {
+	if e, ok := s.Get(asn1.OIDIssuerAltName); ok {
+		if v, ok := e.(*IssuerAltName); ok {
+			return *v, true
+		}
+	}
+
+	return IssuerAltName{}, false
+}
+
+// BasicConstraints returns the basic constraints extension, if present.
+func (s Set) BasicConstraints() (BasicConstraints, bool) {
+	if e, ok := s.Get(asn1.OIDBasicConstraints); ok {
+		if v, ok := e.(*BasicConstraints); ok {
+			return *v, true
+		}
+	}
+
+	return BasicConstraints{}, false
+}
+
+// CRLNumber returns the CRL number extension, if present.
+func (s Set) CRLNumber() (CRLNumber, bool) {
+	if e, ok := s.Get(asn1.OIDCRLNumber); ok {
+		if v, ok := e.(*CRLNumber); ok {
+			return *v, true
+		}
+	}
+
+	return CRLNumber{}, false
+}
+
+// ReasonCode returns the reason code extension, if present.
+func (s Set) ReasonCode() (ReasonCode, bool) {
+	if e, ok := s.Get(asn1.OIDReasonCode); ok {
+		if v, ok := e.(*ReasonCode); ok {
+			return *v, true
+		}
+	}
+
+	return ReasonCode{}, false
+}
+
+// InvalidityDate returns the invalidity date extension, if present.
+func (s Set) InvalidityDate() (InvalidityDate, bool) {
+	if e, ok := s.Get(asn1.OIDInvalidityDate); ok {
+		if v, ok := e.(*InvalidityDate); ok {
+			return *v, true
+		}
+	}
+
+	return InvalidityDate{}, false
+}
+
+// DeltaCRLIndicator returns the delta CRL indicator extension, if present.
+func (s Set) DeltaCRLIndicator() (DeltaCRLIndicator, bool) {
+	if e, ok := s.Get(asn1.OIDDeltaCRLIndicator); ok {
+		if v, ok := e.(*DeltaCRLIndicator); ok {
+			return *v, true
+		}
+	}
+
+	return DeltaCRLIndicator{}, false
+}
+
+// IssuingDistributionPoint returns the issuing distribution point extension,
+// if present.
+func (s Set) IssuingDistributionPoint() (IssuingDistributionPoint, bool) {
+	if e, ok := s.Get(asn1.OIDIssuingDistributionPoint); ok {
+		if v, ok := e.(*IssuingDistributionPoint); ok {
+			return *v, true
+		}
+	}
+
+	return IssuingDistributionPoint{}, false
+}
+
+// CertificateIssuer returns the certificate issuer extension, if present.
+func (s Set) CertificateIssuer() (CertificateIssuer, bool) {
+	if e, ok := s.Get(asn1.OIDCertificateIssuer); ok {
+		if v, ok := e.(*CertificateIssuer); ok {
+			return *v, true
+		}
+	}
+
+	return CertificateIssuer{}, false
+}
+
+// NameConstraints returns the name constraints extension, if present.
+func (s Set) NameConstraints() (NameConstraints, bool) {
+	if e, ok := s.Get(asn1.OIDNameConstraints); ok {
+		if v, ok := e.(*NameConstraints); ok {
+			return *v, true
+		}
+	}
+
+	return NameConstraints{}, false
+}
+
+// CRLDistributionPoints returns the CRL distribution points extension, if
+// present.
+func (s Set) CRLDistributionPoints() (CRLDistributionPoints, bool) {
+	if e, ok := s.Get(asn1.OIDCRLDistributionPoints); ok {
+		if v, ok := e.(*CRLDistributionPoints); ok {
+			return *v, true
+		}
+	}
+
+	return CRLDistributionPoints{}, false
+}
+
+// CertificatePolicies returns the certificate policies extension, if present.
+func (s Set) CertificatePolicies() (CertificatePolicies, bool) {
+	if e, ok := s.Get(asn1.OIDCertificatePolicies); ok {
+		if v, ok := e.(*CertificatePolicies); ok {
+			return *v, true
+		}
+	}
+
+	return CertificatePolicies{}, false
+}
+
+// PolicyMappings returns the policy mappings extension, if present.
+func (s Set) PolicyMappings() (PolicyMappings, bool) {
+	if e, ok := s.Get(asn1.OIDPolicyMappings); ok {
+		if v, ok := e.(*PolicyMappings); ok {
+			return *v, true
+		}
+	}
+
+	return PolicyMappings{}, false
+}
+
+// AuthorityKeyIdentifier returns the authority key identifier extension, if
+// present.
+func (s Set) AuthorityKeyIdentifier() (AuthorityKeyIdentifier, bool) {
+	if e, ok := s.Get(asn1.OIDAuthorityKeyIdentifier); ok {
+		if v, ok := e.(*AuthorityKeyIdentifier); ok {
+			return *v, true
+		}
+	}
+
+	return AuthorityKeyIdentifier{}, false
+}
+
+// PolicyConstraints returns the policy constraints extension, if present.
+func (s Set) PolicyConstraints() (PolicyConstraints, bool) {
+	if e, ok := s.Get(asn1.OIDPolicyConstraints); ok {
+		if v, ok := e.(*PolicyConstraints); ok {
+			return *v, true
+		}
+	}
+
+	return PolicyConstraints{}, false
+}
+
+// ExtendedKeyUsage returns the extended key usage extension, if present.
+func (s Set) ExtendedKeyUsage() (ExtendedKeyUsage, bool) {
+	if e, ok := s.Get(asn1.OIDExtendedKeyUsage); ok {
+		if v, ok := e.(*ExtendedKeyUsage); ok {
+			return *v, true
+		}
+	}
+
+	return ExtendedKeyUsage{}, false
+}
+
+// FreshestCRL returns the freshest CRL extension, if present.
+func (s Set) FreshestCRL() (FreshestCRL, bool) {
+	if e, ok := s.Get(asn1.OIDFreshestCRL); ok {
+		if v, ok := e.(*FreshestCRL); ok {
+			return *v, true
+		}
+	}
+
+	return FreshestCRL{}, false
+}
+
+// InhibitAnyPolicy returns the inhibit anyPolicy extension, if present.
+func (s Set) InhibitAnyPolicy() (InhibitAnyPolicy, bool) {
+	if e, ok := s.Get(asn1.OIDInhibitAnyPolicy); ok {
+		if v, ok := e.(*InhibitAnyPolicy); ok {
+			return *v, true
+		}
+	}
+
+	return InhibitAnyPolicy{}, false
+}
+
+// AuthorityInfoAccess returns the authority information access extension, if
+// present.
+func (s Set) AuthorityInfoAccess() (AuthorityInfoAccess, bool) {
+	if e, ok := s.Get(asn1.OIDAuthorityInfoAccess); ok {
+		if v, ok := e.(*AuthorityInfoAccess); ok {
+			return *v, true
+		}
+	}
+
+	return AuthorityInfoAccess{}, false
+}
+
+// QCStatements returns the qualified certificate statements extension, if
+// present.
+func (s Set) QCStatements() (QCStatements, bool) {
+	if e, ok := s.Get(asn1.OIDQCStatements); ok {
+		if v, ok := e.(*QCStatements); ok {
+			return *v, true
+		}
+	}
+
+	return QCStatements{}, false
+}
+
+// IPAddrBlocks returns the IP address delegation extension, if present.
+func (s Set) IPAddrBlocks() (IPAddrBlocks, bool) {
+	if e, ok := s.Get(asn1.OIDIPAddrBlocks); ok {
+		if v, ok := e.(*IPAddrBlocks); ok {
+			return *v, true
+		}
+	}
+
+	return IPAddrBlocks{}, false
+}
+
+// ASIdentifiers returns the AS identifier delegation extension, if present.
+func (s Set) ASIdentifiers() (ASIdentifiers, bool) {
+	if e, ok := s.Get(asn1.OIDAutonomousSysIDs); ok {
+		if v, ok := e.(*ASIdentifiers); ok {
+			return *v, true
+		}
+	}
+
+	return ASIdentifiers{}, false
+}
+
+// SubjectInfoAccess returns the subject information access extension, if
+// present.
+func (s Set) SubjectInfoAccess() (SubjectInfoAccess, bool) {
+	if e, ok := s.Get(asn1.OIDSubjectInfoAccess); ok {
+		if v, ok := e.(*SubjectInfoAccess); ok {
+			return *v, true
+		}
+	}
+
+	return SubjectInfoAccess{}, false
+}
+
+// TLSFeature returns the TLS feature extension, if present.
+func (s Set) TLSFeature() (TLSFeature, bool) {
+	if e, ok := s.Get(asn1.OIDTLSFeature); ok {
+		if v, ok := e.(*TLSFeature); ok {
+			return *v, true
+		}
+	}
+
+	return TLSFeature{}, false
+}
+
+// ACMEIdentifier returns the ACME identifier extension, if present.
+func (s Set) ACMEIdentifier() (ACMEIdentifier, bool) {
+	if e, ok := s.Get(asn1.OIDACMEIdentifier); ok {
+		if v, ok := e.(*ACMEIdentifier); ok {
+			return *v, true
+		}
+	}
+
+	return ACMEIdentifier{}, false
+}
+
+// OCSPNonce returns the OCSP nonce extension, if present.
+func (s Set) OCSPNonce() (OCSPNonce, bool) {
+	if e, ok := s.Get(asn1.OIDOCSPNonce); ok {
+		if v, ok := e.(*OCSPNonce); ok {
+			return *v, true
+		}
+	}
+
+	return OCSPNonce{}, false
+}
+
+// OCSPNoCheck returns the OCSP no check extension, if present.
+func (s Set) OCSPNoCheck() (OCSPNoCheck, bool) {
+	if e, ok := s.Get(asn1.OIDOCSPNoCheck); ok {
+		if v, ok := e.(*OCSPNoCheck); ok {
+			return *v, true
+		}
+	}
+
+	return OCSPNoCheck{}, false
+}
+
+// SMIMECapabilities returns the S/MIME capabilities extension, if present.
+func (s Set) SMIMECapabilities() (SMIMECapabilities, bool) {
+	if e, ok := s.Get(asn1.OIDSMIMECapabilities); ok {
+		if v, ok := e.(*SMIMECapabilities); ok {
+			return *v, true
+		}
+	}
+
+	return SMIMECapabilities{}, false
+}
+
+// SignedCertificateTimestampList returns the signed certificate timestamp list
+// extension, if present.
+func (s Set) SignedCertificateTimestampList() (SignedCertificateTimestampList, bool) {
+	if e, ok := s.Get(asn1.OIDSignedCertificateTimestampList); ok {
+		if v, ok := e.(*SignedCertificateTimestampList); ok {
+			return *v, true
+		}
+	}
+
+	return SignedCertificateTimestampList{}, false
+}
+
+// PrecertificatePoison returns the precertificate poison extension, if present.
+func (s Set) PrecertificatePoison() (PrecertificatePoison, bool) {
+	if e, ok := s.Get(asn1.OIDPrecertificatePoison); ok {
+		if v, ok := e.(*PrecertificatePoison); ok {
+			return *v, true
+		}
+	}
+
+	return PrecertificatePoison{}, false
+}
+
+// CertificateTemplateName returns the Microsoft certificate template name
+// extension, if present.
+func (s Set) CertificateTemplateName() (CertificateTemplateName, bool) {
+	if e, ok := s.Get(asn1.OIDMSCertificateTemplateName); ok {
+		if v, ok := e.(*CertificateTemplateName); ok {
+			return *v, true
+		}
+	}
+
+	return CertificateTemplateName{}, false
+}
+
+// CAVersion returns the Microsoft CA version extension, if present.
+func (s Set) CAVersion() (CAVersion, bool) {
+	if e, ok := s.Get(asn1.OIDMSCAVersion); ok {
+		if v, ok := e.(*CAVersion); ok {
+			return *v, true
+		}
+	}
+
+	return CAVersion{}, false
+}
+
+// CertificateTemplate returns the Microsoft certificate template extension, if
+// present.
+func (s Set) CertificateTemplate() (CertificateTemplate, bool) {
+	if e, ok := s.Get(asn1.OIDMSCertificateTemplate); ok {
+		if v, ok := e.(*CertificateTemplate); ok {
+			return *v, true
+		}
+	}
+
+	return CertificateTemplate{}, false
+}
+
+// ApplicationPolicies returns the Microsoft application policies extension, if
+// present.
+func (s Set) ApplicationPolicies() (ApplicationPolicies, bool) {
+	if e, ok := s.Get(asn1.OIDMSApplicationPolicies); ok {
+		if v, ok := e.(*ApplicationPolicies); ok {
+			return *v, true
+		}
+	}
+
+	return ApplicationPolicies{}, false
+}
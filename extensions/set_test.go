@@ -0,0 +1,277 @@
+package extensions_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"errors"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+var (
+	testSKIExtension = pkix.Extension{
+		Id:    pgasn1.OIDSubjectKeyIdentifier,
+		Value: []byte{asn1.TagOctetString, 4, 1, 2, 3, 4},
+	}
+
+	testKeyUsageExtension = pkix.Extension{
+		Id:       pgasn1.OIDKeyUsage,
+		Critical: true,
+		Value:    []byte{asn1.TagBitString, 3, 7, 0x80, 0x00},
+	}
+
+	testUnknownExtension = pkix.Extension{
+		Id:    asn1.ObjectIdentifier{1, 2, 3, 4},
+		Value: []byte{asn1.TagNull, 0},
+	}
+)
+
+func TestParseAll(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		exts []pkix.Extension
+		want []extensions.Extension
+		errs []asn1.ObjectIdentifier
+		err  error
+	}{
+		{
+			name: "OK",
+			exts: []pkix.Extension{
+				testSKIExtension,
+				testKeyUsageExtension,
+				testUnknownExtension,
+			},
+			want: []extensions.Extension{
+				&extensions.SubjectKeyIdentifier{ID: []byte{1, 2, 3, 4}},
+				&extensions.KeyUsage{Critical: true, Value: x509.KeyUsageDigitalSignature},
+				&extensions.Raw{ID: asn1.ObjectIdentifier{1, 2, 3, 4}, Value: []byte{asn1.TagNull, 0}},
+			},
+		},
+		{
+			name: "Empty",
+			want: []extensions.Extension{},
+		},
+		{
+			name: "Duplicate",
+			exts: []pkix.Extension{
+				testSKIExtension,
+				testKeyUsageExtension,
+				testSKIExtension,
+			},
+			err: errors.New("duplicate"),
+		},
+		{
+			name: "DuplicateUnknown",
+			exts: []pkix.Extension{
+				testUnknownExtension,
+				testUnknownExtension,
+			},
+			err: errors.New("duplicate"),
+		},
+		{
+			name: "BadValue",
+			exts: []pkix.Extension{
+				testSKIExtension,
+				{Id: pgasn1.OIDBasicConstraints, Critical: true, Value: []byte{0xff}},
+			},
+			want: []extensions.Extension{
+				&extensions.SubjectKeyIdentifier{ID: []byte{1, 2, 3, 4}},
+				&extensions.Raw{ID: pgasn1.OIDBasicConstraints, Critical: true, Value: []byte{0xff}},
+			},
+			errs: []asn1.ObjectIdentifier{pgasn1.OIDBasicConstraints},
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := extensions.ParseAll(tc.exts)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			if got.Len() != len(tc.want) {
+				t.Errorf("got length %d, want %d", got.Len(), len(tc.want))
+			}
+
+			if !reflect.DeepEqual(got.All(), tc.want) {
+				t.Errorf("got %v, want %v", got.All(), tc.want)
+			}
+
+			var errs = got.Errors()
+			if len(errs) != len(tc.errs) {
+				t.Fatalf("got errors %v, want errors for %v", errs, tc.errs)
+			}
+
+			for i := range errs {
+				if !errs[i].ID.Equal(tc.errs[i]) || errs[i].Err == nil {
+					t.Errorf("got error %v, want error for %v", errs[i], tc.errs[i])
+				}
+			}
+		})
+	}
+}
+
+func TestSetAccessors(t *testing.T) {
+	t.Parallel()
+
+	set, err := extensions.FromCertificate(&x509.Certificate{
+		Extensions: []pkix.Extension{
+			testSKIExtension,
+			testKeyUsageExtension,
+			testUnknownExtension,
+		},
+	})
+	if err != nil {
+		t.Fatalf("couldn't parse extensions: %v", err)
+	}
+
+	if got, ok := set.SubjectKeyIdentifier(); !ok {
+		t.Errorf("subject key identifier not found")
+	} else if want := (extensions.SubjectKeyIdentifier{ID: []byte{1, 2, 3, 4}}); !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if got, ok := set.KeyUsage(); !ok {
+		t.Errorf("key usage not found")
+	} else if want := (extensions.KeyUsage{Critical: true, Value: x509.KeyUsageDigitalSignature}); got != want {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if got, ok := set.NameConstraints(); ok {
+		t.Errorf("got name constraints %v, want none", got)
+	}
+
+	if got, ok := set.Get(asn1.ObjectIdentifier{1, 2, 3, 4}); !ok {
+		t.Errorf("unknown extension not found")
+	} else if _, ok := got.(*extensions.Raw); !ok {
+		t.Errorf("got %T, want *extensions.Raw", got)
+	}
+}
+
+func TestFromCertificateRequest(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("couldn't generate key: %v", err)
+	}
+
+	der, err := x509.CreateCertificateRequest(rand.Reader, &x509.CertificateRequest{
+		Subject:         pkix.Name{CommonName: "test"},
+		ExtraExtensions: []pkix.Extension{testSKIExtension, testKeyUsageExtension},
+	}, key)
+	if err != nil {
+		t.Fatalf("couldn't create certificate request: %v", err)
+	}
+
+	csr, err := x509.ParseCertificateRequest(der)
+	if err != nil {
+		t.Fatalf("couldn't parse certificate request: %v", err)
+	}
+
+	set, err := extensions.FromCertificateRequest(csr)
+	if err != nil {
+		t.Fatalf("couldn't parse extensions: %v", err)
+	}
+
+	if set.Len() != 2 {
+		t.Errorf("got length %d, want 2", set.Len())
+	}
+
+	if _, ok := set.SubjectKeyIdentifier(); !ok {
+		t.Errorf("subject key identifier not found")
+	}
+
+	if _, ok := set.KeyUsage(); !ok {
+		t.Errorf("key usage not found")
+	}
+}
+
+func TestFromCRL(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("couldn't generate key: %v", err)
+	}
+
+	var issuer = &x509.Certificate{
+		Subject:      pkix.Name{CommonName: "Test CA"},
+		KeyUsage:     x509.KeyUsageCRLSign,
+		SubjectKeyId: []byte{1, 2, 3, 4},
+	}
+
+	der, err := x509.CreateRevocationList(rand.Reader, &x509.RevocationList{
+		Number:     big.NewInt(42),
+		ThisUpdate: time.Now(),
+		NextUpdate: time.Now().Add(time.Hour),
+		RevokedCertificateEntries: []x509.RevocationListEntry{
+			{
+				SerialNumber:   big.NewInt(1),
+				RevocationTime: time.Now(),
+				ReasonCode:     int(extensions.CRLReasonKeyCompromise),
+			},
+		},
+	}, issuer, key)
+	if err != nil {
+		t.Fatalf("couldn't create CRL: %v", err)
+	}
+
+	crl, err := x509.ParseRevocationList(der)
+	if err != nil {
+		t.Fatalf("couldn't parse CRL: %v", err)
+	}
+
+	set, err := extensions.FromCRL(crl)
+	if err != nil {
+		t.Fatalf("couldn't parse extensions: %v", err)
+	}
+
+	if got, ok := set.CRLNumber(); !ok {
+		t.Errorf("CRL number not found")
+	} else if got.Number.Cmp(big.NewInt(42)) != 0 {
+		t.Errorf("got CRL number %v, want 42", got.Number)
+	}
+
+	if got, ok := set.AuthorityKeyIdentifier(); !ok {
+		t.Errorf("authority key identifier not found")
+	} else if !bytes.Equal(got.ID, issuer.SubjectKeyId) {
+		t.Errorf("got key identifier %v, want %v", got.ID, issuer.SubjectKeyId)
+	}
+
+	if len(crl.RevokedCertificateEntries) != 1 {
+		t.Fatalf("got %d CRL entries, want 1", len(crl.RevokedCertificateEntries))
+	}
+
+	entry, err := extensions.FromRevocationListEntry(crl.RevokedCertificateEntries[0])
+	if err != nil {
+		t.Fatalf("couldn't parse entry extensions: %v", err)
+	}
+
+	if got, ok := entry.ReasonCode(); !ok {
+		t.Errorf("reason code not found")
+	} else if got.Reason != extensions.CRLReasonKeyCompromise {
+		t.Errorf("got reason %v, want %v", got.Reason, extensions.CRLReasonKeyCompromise)
+	}
+}
@@ -3,8 +3,11 @@ package extensions
 import (
 	"crypto"
 	"crypto/sha1"
+	"crypto/sha256"
+	"crypto/sha512"
 	"crypto/x509"
 	"crypto/x509/pkix"
+	"encoding/json"
 	"errors"
 	"fmt"
 
@@ -61,14 +64,161 @@ func (e *SubjectKeyIdentifier) Unmarshal(ext pkix.Extension) error {
 	return nil
 }
 
-// MakePublicKeyIdentifier builds a public key identifier in accordance with the
-// first method described in RFC5280 section 4.2.1.2.
+// KeyIDMethod identifies a method for generating a key identifier from a
+// public key.
+type KeyIDMethod int
+
+// Key identifier methods. The RFC 5280 methods are described in section
+// 4.2.1.2 of that RFC, and the RFC 7093 methods in section 2 of that RFC.
+const (
+	// KeyIDMethodSPKISHA1 is the 160-bit SHA-1 hash of the DER-encoded
+	// SubjectPublicKeyInfo. This is the method used by
+	// MakePublicKeyIdentifier.
+	KeyIDMethodSPKISHA1 KeyIDMethod = iota
+
+	// KeyIDMethodRFC5280Method1 is the 160-bit SHA-1 hash of the value of
+	// the subjectPublicKey BIT STRING, excluding the tag, length, and number
+	// of unused bits.
+	KeyIDMethodRFC5280Method1
+
+	// KeyIDMethodRFC5280Method2 is a four-bit type field with the value 0100
+	// followed by the least significant 60 bits of the SHA-1 hash of the
+	// value of the subjectPublicKey BIT STRING.
+	KeyIDMethodRFC5280Method2
+
+	// KeyIDMethodRFC7093Method1 is the leftmost 160 bits of the SHA-256 hash
+	// of the value of the subjectPublicKey BIT STRING.
+	KeyIDMethodRFC7093Method1
+
+	// KeyIDMethodRFC7093Method2 is the leftmost 160 bits of the SHA-384 hash
+	// of the value of the subjectPublicKey BIT STRING.
+	KeyIDMethodRFC7093Method2
+
+	// KeyIDMethodRFC7093Method3 is the leftmost 160 bits of the SHA-512 hash
+	// of the value of the subjectPublicKey BIT STRING.
+	KeyIDMethodRFC7093Method3
+
+	// KeyIDMethodRFC7093Method4 is the SHA-256 hash of the DER-encoded
+	// SubjectPublicKeyInfo. RFC 7093 leaves the hash algorithm to the
+	// issuer, and SHA-256 is used here.
+	KeyIDMethodRFC7093Method4
+)
+
+// keyIDLength is the length in bytes of a truncated key identifier.
+const keyIDLength = 20
+
+// subjectPublicKeyInfo is used to extract the subjectPublicKey BIT STRING
+// from a DER-encoded SubjectPublicKeyInfo.
+type subjectPublicKeyInfo struct {
+	Algorithm pkix.AlgorithmIdentifier
+	PublicKey asn1.BitString
+}
+
+// MakePublicKeyIdentifier builds a public key identifier from the SHA-1 hash
+// of the DER-encoded SubjectPublicKeyInfo. See KeyIDMethodSPKISHA1.
 func MakePublicKeyIdentifier(pub crypto.PublicKey) ([]byte, error) {
-	keyBytes, err := x509.MarshalPKIXPublicKey(pub)
+	return MakeKeyIdentifier(pub, KeyIDMethodSPKISHA1)
+}
+
+// MakeKeyIdentifier builds a public key identifier using the specified
+// method.
+func MakeKeyIdentifier(pub crypto.PublicKey, method KeyIDMethod) ([]byte, error) {
+	spki, err := x509.MarshalPKIXPublicKey(pub)
 	if err != nil {
 		return nil, err
 	}
 
-	id := sha1.Sum(keyBytes)
-	return id[:], nil
+	switch method {
+	case KeyIDMethodSPKISHA1:
+		id := sha1.Sum(spki)
+		return id[:], nil
+
+	case KeyIDMethodRFC7093Method4:
+		id := sha256.Sum256(spki)
+		return id[:], nil
+	}
+
+	var info subjectPublicKeyInfo
+	if rest, err := asn1.Unmarshal(spki, &info); err != nil {
+		return nil, err
+	} else if len(rest) > 0 {
+		return nil, ErrTrailingBytes
+	}
+
+	var key = info.PublicKey.Bytes
+
+	switch method {
+	case KeyIDMethodRFC5280Method1:
+		id := sha1.Sum(key)
+		return id[:], nil
+
+	case KeyIDMethodRFC5280Method2:
+		sum := sha1.Sum(key)
+		id := sum[len(sum)-8:]
+		id[0] = 0x40 | id[0]&0x0f
+		return id, nil
+
+	case KeyIDMethodRFC7093Method1:
+		id := sha256.Sum256(key)
+		return id[:keyIDLength], nil
+
+	case KeyIDMethodRFC7093Method2:
+		id := sha512.Sum384(key)
+		return id[:keyIDLength], nil
+
+	case KeyIDMethodRFC7093Method3:
+		id := sha512.Sum512(key)
+		return id[:keyIDLength], nil
+	}
+
+	return nil, fmt.Errorf("unknown key identifier method: %d", method)
+}
+
+// NewSubjectKeyIdentifier returns a subject key identifier extension for the
+// specified public key, using the specified key identifier method.
+func NewSubjectKeyIdentifier(pub crypto.PublicKey, method KeyIDMethod) (SubjectKeyIdentifier, error) {
+	id, err := MakeKeyIdentifier(pub, method)
+	if err != nil {
+		return SubjectKeyIdentifier{}, err
+	}
+
+	return SubjectKeyIdentifier{ID: id}, nil
+}
+
+// subjectKeyIdentifierJSON is the JSON representation of a
+// SubjectKeyIdentifier.
+type subjectKeyIdentifierJSON struct {
+	Critical bool     `json:"critical"`
+	ID       hexBytes `json:"keyIdentifier"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "keyIdentifier" members. The key identifier is a hex
+// string.
+func (e SubjectKeyIdentifier) MarshalJSON() ([]byte, error) {
+	return json.Marshal(subjectKeyIdentifierJSON{
+		Critical: e.Critical,
+		ID:       e.ID,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *SubjectKeyIdentifier) UnmarshalJSON(b []byte) error {
+	var tmp subjectKeyIdentifierJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = SubjectKeyIdentifier{
+		Critical: tmp.Critical,
+		ID:       tmp.ID,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e SubjectKeyIdentifier) String() string {
+	return text(pgasn1.OIDSubjectKeyIdentifier, e.Critical, hexText(e.ID))
 }
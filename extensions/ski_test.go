@@ -153,3 +153,110 @@ func TestMakePublicKeyIdentifierUnmarshal(t *testing.T) {
 		})
 	}
 }
+
+func TestMakeKeyIdentifier(t *testing.T) {
+	t.Parallel()
+
+	key, err := pkifile.PublicKeyFromPEMFile("testdata/rsa_public.pem")
+	if err != nil {
+		t.Fatalf("couldn't read public key: %v", err)
+	}
+
+	var testcases = []struct {
+		name   string
+		method extensions.KeyIDMethod
+		want   []byte
+		err    error
+	}{
+		{
+			name:   "SPKISHA1",
+			method: extensions.KeyIDMethodSPKISHA1,
+			want: []byte{139, 122, 233, 0, 202, 196, 176, 136, 23, 29,
+				139, 141, 212, 216, 106, 85, 40, 88, 245, 184},
+		},
+		{
+			name:   "RFC5280Method1",
+			method: extensions.KeyIDMethodRFC5280Method1,
+			want: []byte{52, 152, 5, 115, 95, 140, 82, 181, 126, 202,
+				83, 91, 116, 129, 49, 216, 16, 158, 219, 145},
+		},
+		{
+			name:   "RFC5280Method2",
+			method: extensions.KeyIDMethodRFC5280Method2,
+			want:   []byte{68, 129, 49, 216, 16, 158, 219, 145},
+		},
+		{
+			name:   "RFC7093Method1",
+			method: extensions.KeyIDMethodRFC7093Method1,
+			want: []byte{119, 171, 113, 46, 242, 43, 109, 77, 44, 42,
+				165, 39, 101, 61, 199, 68, 169, 242, 80, 191},
+		},
+		{
+			name:   "RFC7093Method2",
+			method: extensions.KeyIDMethodRFC7093Method2,
+			want: []byte{165, 143, 224, 91, 21, 79, 163, 49, 203, 222,
+				37, 55, 52, 76, 252, 117, 180, 84, 160, 98},
+		},
+		{
+			name:   "RFC7093Method3",
+			method: extensions.KeyIDMethodRFC7093Method3,
+			want: []byte{255, 127, 21, 89, 41, 179, 157, 77, 192, 196,
+				221, 121, 86, 101, 106, 160, 216, 106, 18, 74},
+		},
+		{
+			name:   "RFC7093Method4",
+			method: extensions.KeyIDMethodRFC7093Method4,
+			want: []byte{114, 55, 20, 137, 221, 61, 57, 59, 237, 42,
+				37, 251, 205, 152, 160, 209, 84, 138, 72, 11,
+				17, 181, 244, 6, 127, 194, 35, 192, 63, 201, 81, 220},
+		},
+		{
+			name:   "UnknownMethod",
+			method: extensions.KeyIDMethod(99),
+			err:    errors.New("unknown key identifier method"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := extensions.MakeKeyIdentifier(key, tc.method)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !bytes.Equal(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestNewSubjectKeyIdentifier(t *testing.T) {
+	t.Parallel()
+
+	key, err := pkifile.PublicKeyFromPEMFile("testdata/rsa_public.pem")
+	if err != nil {
+		t.Fatalf("couldn't read public key: %v", err)
+	}
+
+	got, err := extensions.NewSubjectKeyIdentifier(key, extensions.KeyIDMethodRFC5280Method2)
+	if err != nil {
+		t.Fatalf("couldn't create subject key identifier: %v", err)
+	}
+
+	var want = extensions.SubjectKeyIdentifier{
+		ID: []byte{68, 129, 49, 216, 16, 158, 219, 145},
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+
+	if _, err := extensions.NewSubjectKeyIdentifier("not a key", extensions.KeyIDMethodSPKISHA1); err == nil {
+		t.Errorf("got nil error for bad public key")
+	}
+}
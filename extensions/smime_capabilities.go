@@ -0,0 +1,115 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/json"
+	"fmt"
+
+	"github.com/paulgriffiths/pki/asn1"
+)
+
+// SMIMECapabilities represents an S/MIME capabilities extension as defined in
+// RFC 4262. The same value may also be used as a PKCS#9 smimeCapabilities
+// attribute in a PKCS#10 certificate signing request.
+type SMIMECapabilities struct {
+	Critical     bool
+	Capabilities []asn1.SMIMECapability
+}
+
+// Marshal returns a pkix.Extension.
+func (e SMIMECapabilities) Marshal() (pkix.Extension, error) {
+	der, err := e.MarshalAttributeValue()
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       asn1.OIDSMIMECapabilities,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *SMIMECapabilities) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(asn1.OIDSMIMECapabilities) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	if err := e.UnmarshalAttributeValue(ext.Value); err != nil {
+		return err
+	}
+
+	e.Critical = ext.Critical
+
+	return nil
+}
+
+// MarshalAttributeValue returns the DER-encoding of the capabilities for use
+// as the value of an smimeCapabilities attribute. The Critical field is
+// ignored.
+func (e SMIMECapabilities) MarshalAttributeValue() ([]byte, error) {
+	return asn1.SMIMECapabilities(e.Capabilities).Marshal()
+}
+
+// UnmarshalAttributeValue parses the DER-encoded value of an
+// smimeCapabilities attribute and stores the result in the object.
+func (e *SMIMECapabilities) UnmarshalAttributeValue(b []byte) error {
+	var ae asn1.SMIMECapabilities
+	if err := ae.Unmarshal(b); err != nil {
+		return err
+	}
+
+	*e = SMIMECapabilities{
+		Capabilities: ae,
+	}
+
+	return nil
+}
+
+// smimeCapabilitiesJSON is the JSON representation of an SMIMECapabilities.
+type smimeCapabilitiesJSON struct {
+	Critical     bool                   `json:"critical"`
+	Capabilities []asn1.SMIMECapability `json:"capabilities"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "capabilities" members. See
+// asn1.SMIMECapability.MarshalJSON.
+func (e SMIMECapabilities) MarshalJSON() ([]byte, error) {
+	return json.Marshal(smimeCapabilitiesJSON{
+		Critical:     e.Critical,
+		Capabilities: e.Capabilities,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *SMIMECapabilities) UnmarshalJSON(b []byte) error {
+	var tmp smimeCapabilitiesJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = SMIMECapabilities{
+		Critical:     tmp.Critical,
+		Capabilities: tmp.Capabilities,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e SMIMECapabilities) String() string {
+	var lines []string
+
+	for _, c := range e.Capabilities {
+		lines = append(lines, asn1.OIDLongName(c.ID))
+
+		if len(c.Parameters.FullBytes) != 0 {
+			lines = append(lines, nestedIndent+"Parameters: "+hexText(c.Parameters.FullBytes))
+		}
+	}
+
+	return text(asn1.OIDSMIMECapabilities, e.Critical, lines...)
+}
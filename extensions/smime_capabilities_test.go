@@ -0,0 +1,157 @@
+package extensions_test
+
+import (
+	"bytes"
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestSMIMECapabilitiesMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.SMIMECapabilities
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.SMIMECapabilities{
+				Capabilities: []pgasn1.SMIMECapability{
+					{ID: pgasn1.OIDDESEDE3CBC},
+				},
+			},
+			want: pkix.Extension{
+				Id: pgasn1.OIDSMIMECapabilities,
+				Value: []byte{asn1.TagSequence | bit6, 12,
+					asn1.TagSequence | bit6, 10,
+					asn1.TagOID, 8, 0x2a, 0x86, 0x48, 0x86, 0xf7, 0x0d, 0x03, 0x07,
+				},
+			},
+		},
+		{
+			name: "Empty",
+			ext:  extensions.SMIMECapabilities{},
+			want: pkix.Extension{},
+			err:  errors.New("no capabilities"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSMIMECapabilitiesUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.SMIMECapabilities
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDSMIMECapabilities,
+				Value: []byte{asn1.TagSequence | bit6, 12,
+					asn1.TagSequence | bit6, 10,
+					asn1.TagOID, 8, 0x2a, 0x86, 0x48, 0x86, 0xf7, 0x0d, 0x03, 0x07,
+				},
+			},
+			want: extensions.SMIMECapabilities{
+				Capabilities: []pgasn1.SMIMECapability{
+					{ID: pgasn1.OIDDESEDE3CBC},
+				},
+			},
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDExtendedKeyUsage,
+				Value: []byte{asn1.TagSequence | bit6, 12,
+					asn1.TagSequence | bit6, 10,
+					asn1.TagOID, 8, 0x2a, 0x86, 0x48, 0x86, 0xf7, 0x0d, 0x03, 0x07,
+				},
+			},
+			want: extensions.SMIMECapabilities{},
+			err:  errors.New("bad OID"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.SMIMECapabilities
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSMIMECapabilitiesAttributeValue(t *testing.T) {
+	t.Parallel()
+
+	var want = extensions.SMIMECapabilities{
+		Capabilities: []pgasn1.SMIMECapability{
+			{ID: pgasn1.OIDAES256GCM},
+			{ID: pgasn1.OIDAES128GCM},
+		},
+	}
+
+	der, err := want.MarshalAttributeValue()
+	if err != nil {
+		t.Fatalf("couldn't marshal attribute value: %v", err)
+	}
+
+	// The attribute value is identical to the extension value.
+	ext, err := want.Marshal()
+	if err != nil {
+		t.Fatalf("couldn't marshal extension: %v", err)
+	}
+
+	if !bytes.Equal(der, ext.Value) {
+		t.Errorf("got %x, want %x", der, ext.Value)
+	}
+
+	var got extensions.SMIMECapabilities
+	if err := got.UnmarshalAttributeValue(der); err != nil {
+		t.Fatalf("couldn't unmarshal attribute value: %v", err)
+	}
+
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("got %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,111 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	goasn1 "encoding/asn1"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/paulgriffiths/pki/asn1"
+)
+
+// SubjectDirectoryAttributes represents an X509 subject directory attributes
+// extension as defined in RFC 5280 section 4.2.1.8.
+type SubjectDirectoryAttributes struct {
+	Critical   bool
+	Attributes []asn1.Attribute
+}
+
+// Marshal returns a pkix.Extension.
+func (e SubjectDirectoryAttributes) Marshal() (pkix.Extension, error) {
+	der, err := asn1.SubjectDirectoryAttributes(e.Attributes).Marshal()
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       asn1.OIDSubjectDirectoryAttributes,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *SubjectDirectoryAttributes) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(asn1.OIDSubjectDirectoryAttributes) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var ae asn1.SubjectDirectoryAttributes
+	if err := ae.Unmarshal(ext.Value); err != nil {
+		return err
+	}
+
+	*e = SubjectDirectoryAttributes{
+		Critical:   ext.Critical,
+		Attributes: ae,
+	}
+
+	return nil
+}
+
+// Attribute returns the first attribute of the specified type, and false if
+// no such attribute is present.
+func (e SubjectDirectoryAttributes) Attribute(oid goasn1.ObjectIdentifier) (asn1.Attribute, bool) {
+	for _, attr := range e.Attributes {
+		if attr.Type.Equal(oid) {
+			return attr, true
+		}
+	}
+
+	return asn1.Attribute{}, false
+}
+
+// subjectDirectoryAttributesJSON is the JSON representation of a
+// SubjectDirectoryAttributes.
+type subjectDirectoryAttributesJSON struct {
+	Critical   bool             `json:"critical"`
+	Attributes []asn1.Attribute `json:"attributes"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "attributes" members. See asn1.Attribute.MarshalJSON.
+func (e SubjectDirectoryAttributes) MarshalJSON() ([]byte, error) {
+	return json.Marshal(subjectDirectoryAttributesJSON{
+		Critical:   e.Critical,
+		Attributes: e.Attributes,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *SubjectDirectoryAttributes) UnmarshalJSON(b []byte) error {
+	var tmp subjectDirectoryAttributesJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = SubjectDirectoryAttributes{
+		Critical:   tmp.Critical,
+		Attributes: tmp.Attributes,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e SubjectDirectoryAttributes) String() string {
+	var lines []string
+
+	for _, a := range e.Attributes {
+		var vals []string
+		for _, v := range a.Values {
+			vals = append(vals, valueText(v))
+		}
+
+		lines = append(lines, asn1.OIDLongName(a.Type)+": "+strings.Join(vals, ", "))
+	}
+
+	return text(asn1.OIDSubjectDirectoryAttributes, e.Critical, lines...)
+}
@@ -0,0 +1,143 @@
+package extensions_test
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestSubjectDirectoryAttributesMarshal(t *testing.T) {
+	t.Parallel()
+
+	gender, err := pgasn1.GenderAttribute(pgasn1.GenderMale)
+	if err != nil {
+		t.Fatalf("couldn't create attribute: %v", err)
+	}
+
+	var testcases = []struct {
+		name string
+		ext  extensions.SubjectDirectoryAttributes
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.SubjectDirectoryAttributes{
+				Attributes: []pgasn1.Attribute{gender},
+			},
+			want: pkix.Extension{
+				Id: pgasn1.OIDSubjectDirectoryAttributes,
+				Value: []byte{asn1.TagSequence | bit6, 17,
+					asn1.TagSequence | bit6, 15,
+					asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x09, 0x03,
+					asn1.TagSet | bit6, 3,
+					asn1.TagPrintableString, 1, 'M',
+				},
+			},
+		},
+		{
+			name: "Empty",
+			ext:  extensions.SubjectDirectoryAttributes{},
+			want: pkix.Extension{},
+			err:  errors.New("no attributes"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSubjectDirectoryAttributesUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want string
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDSubjectDirectoryAttributes,
+				Value: []byte{asn1.TagSequence | bit6, 17,
+					asn1.TagSequence | bit6, 15,
+					asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x09, 0x03,
+					asn1.TagSet | bit6, 3,
+					asn1.TagPrintableString, 1, 'M',
+				},
+			},
+			want: pgasn1.GenderMale,
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id: pgasn1.OIDSubjectAltName,
+				Value: []byte{asn1.TagSequence | bit6, 17,
+					asn1.TagSequence | bit6, 15,
+					asn1.TagOID, 8, 0x2b, 0x06, 0x01, 0x05, 0x05, 0x07, 0x09, 0x03,
+					asn1.TagSet | bit6, 3,
+					asn1.TagPrintableString, 1, 'M',
+				},
+			},
+			err: errors.New("bad OID"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.SubjectDirectoryAttributes
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if err != nil {
+				return
+			}
+
+			attr, ok := got.Attribute(pgasn1.OIDGender)
+			if !ok {
+				t.Fatalf("gender attribute not found")
+			}
+
+			gender, err := attr.Gender()
+			if err != nil {
+				t.Fatalf("couldn't get gender: %v", err)
+			}
+
+			if gender != tc.want {
+				t.Errorf("got %q, want %q", gender, tc.want)
+			}
+
+			if _, ok := got.Attribute(pgasn1.OIDDateOfBirth); ok {
+				t.Errorf("unexpectedly found date of birth attribute")
+			}
+		})
+	}
+}
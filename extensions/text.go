@@ -0,0 +1,533 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	goasn1 "encoding/asn1"
+	"encoding/hex"
+	"fmt"
+	"net"
+	"strings"
+	"time"
+
+	"github.com/paulgriffiths/pki/asn1"
+)
+
+// textIndent is the indentation of each line of an extension value in the
+// text representation of an extension.
+const textIndent = "    "
+
+// nestedIndent is the additional indentation of each nested level of an
+// extension value in the text representation of an extension.
+const nestedIndent = "  "
+
+// timeTextLayout is the layout of a time in the text representation of an
+// extension.
+const timeTextLayout = "Jan _2 15:04:05 2006 GMT"
+
+// rawNameTagRegisteredID is the tag of a registeredID general name.
+const rawNameTagRegisteredID = 8
+
+// rawNameTexts contains the OpenSSL names of the general name types which
+// are represented by raw values, indexed by tag.
+var rawNameTexts = map[int]string{
+	0: "othername",
+	3: "X400Name",
+	5: "EdiPartyName",
+	8: "Registered ID",
+}
+
+// reasonFlagTexts contains the OpenSSL names of the reason flags, indexed
+// by bit number.
+var reasonFlagTexts = []string{
+	"Unused",
+	"Key Compromise",
+	"CA Compromise",
+	"Affiliation Changed",
+	"Superseded",
+	"Cessation Of Operation",
+	"Certificate Hold",
+	"Privilege Withdrawn",
+	"AA Compromise",
+}
+
+// asn1TagNames contains the OpenSSL names of the universal ASN.1 tags.
+var asn1TagNames = map[int]string{
+	0:                         "EOC",
+	goasn1.TagBoolean:         "BOOLEAN",
+	goasn1.TagInteger:         "INTEGER",
+	goasn1.TagBitString:       "BIT STRING",
+	goasn1.TagOctetString:     "OCTET STRING",
+	goasn1.TagNull:            "NULL",
+	goasn1.TagOID:             "OBJECT",
+	7:                         "OBJECT DESCRIPTOR",
+	8:                         "EXTERNAL",
+	9:                         "REAL",
+	goasn1.TagEnum:            "ENUMERATED",
+	goasn1.TagUTF8String:      "UTF8STRING",
+	goasn1.TagSequence:        "SEQUENCE",
+	goasn1.TagSet:             "SET",
+	goasn1.TagNumericString:   "NUMERICSTRING",
+	goasn1.TagPrintableString: "PRINTABLESTRING",
+	goasn1.TagT61String:       "T61STRING",
+	21:                        "VIDEOTEXSTRING",
+	goasn1.TagIA5String:       "IA5STRING",
+	goasn1.TagUTCTime:         "UTCTIME",
+	goasn1.TagGeneralizedTime: "GENERALIZEDTIME",
+	25:                        "GRAPHICSTRING",
+	asn1.TagVisibleString:     "VISIBLESTRING",
+	goasn1.TagGeneralString:   "GENERALSTRING",
+	28:                        "UNIVERSALSTRING",
+	asn1.TagBMPString:         "BMPSTRING",
+}
+
+// Text returns the text representation of an extension in the style of the
+// OpenSSL x509 -text command. Extensions which do not implement fmt.Stringer,
+// such as registered types from other packages, are shown with their value
+// as an ASN.1 dump, as for a Raw.
+func Text(e Extension) string {
+	if s, ok := e.(fmt.Stringer); ok {
+		return s.String()
+	}
+
+	ext, err := e.Marshal()
+	if err != nil {
+		return fmt.Sprintf("<invalid extension: %v>", err)
+	}
+
+	return Raw{ID: ext.Id, Critical: ext.Critical, Value: ext.Value}.String()
+}
+
+// text returns the text representation of an extension, which is a line
+// containing the extension name and criticality, followed by the lines of
+// the extension value each indented by textIndent.
+func text(oid goasn1.ObjectIdentifier, critical bool, lines ...string) string {
+	var b strings.Builder
+
+	b.WriteString(asn1.OIDLongName(oid))
+	b.WriteString(":")
+
+	if critical {
+		b.WriteString(" critical")
+	}
+
+	for _, line := range lines {
+		b.WriteString("\n")
+		b.WriteString(textIndent)
+		b.WriteString(line)
+	}
+
+	return b.String()
+}
+
+// indent returns the lines of a nested value indented by nestedIndent.
+func indent(lines []string) []string {
+	var tmp []string
+
+	for _, line := range lines {
+		tmp = append(tmp, nestedIndent+line)
+	}
+
+	return tmp
+}
+
+// hexText returns colon-separated upper-case hex, such as "01:AB:FF".
+func hexText(b []byte) string {
+	var parts []string
+
+	for _, c := range b {
+		parts = append(parts, fmt.Sprintf("%02X", c))
+	}
+
+	return strings.Join(parts, ":")
+}
+
+// oidsText returns a comma-separated list of the long names of a list of
+// OIDs.
+func oidsText(oids []goasn1.ObjectIdentifier) string {
+	var names []string
+
+	for _, oid := range oids {
+		names = append(names, asn1.OIDLongName(oid))
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// timeText returns the text representation of a time.
+func timeText(t time.Time) string {
+	return t.UTC().Format(timeTextLayout)
+}
+
+// valueText returns the text representation of an ASN.1 value, which is
+// the string itself for string types, and the hex-encoded DER-encoding
+// otherwise.
+func valueText(v interface{}) string {
+	switch v := v.(type) {
+	case string:
+		return v
+
+	case goasn1.RawValue:
+		if len(v.FullBytes) != 0 {
+			if _, err := goasn1.Unmarshal(v.FullBytes, &v); err != nil {
+				return hexText(v.FullBytes)
+			}
+		}
+
+		if s, ok := rawString(v); ok {
+			return s
+		}
+	}
+
+	der, err := goasn1.Marshal(v)
+	if err != nil {
+		return fmt.Sprintf("%v", v)
+	}
+
+	return hexText(der)
+}
+
+// rawString returns the contents of a raw value of a universal string or
+// time type.
+func rawString(v goasn1.RawValue) (string, bool) {
+	if v.Class != goasn1.ClassUniversal || v.IsCompound {
+		return "", false
+	}
+
+	switch v.Tag {
+	case goasn1.TagUTF8String, goasn1.TagNumericString, goasn1.TagPrintableString,
+		goasn1.TagT61String, goasn1.TagIA5String, goasn1.TagUTCTime,
+		goasn1.TagGeneralizedTime, asn1.TagVisibleString:
+		return string(v.Bytes), true
+
+	case asn1.TagBMPString:
+		s, err := asn1.DecodeBMPString(v.Bytes)
+		if err != nil {
+			return "", false
+		}
+
+		return s, true
+	}
+
+	return "", false
+}
+
+// nameText returns the text representation of a distinguished name, such
+// as "CN = Test, O = Example".
+func nameText(name pkix.RDNSequence) string {
+	var rdns []string
+
+	for _, rdn := range name {
+		var atvs []string
+
+		for _, atv := range rdn {
+			atvs = append(atvs, asn1.OIDName(atv.Type)+" = "+valueText(atv.Value))
+		}
+
+		rdns = append(rdns, strings.Join(atvs, " + "))
+	}
+
+	return strings.Join(rdns, ", ")
+}
+
+// generalNameText returns the text representation of a general name, such
+// as "DNS:example.com".
+func generalNameText(name asn1.GeneralName) string {
+	switch {
+	case name.DNSName != "":
+		return "DNS:" + name.DNSName
+
+	case name.DirectoryName != nil:
+		return "DirName:" + nameText(name.DirectoryName)
+
+	case name.EmailAddress != "":
+		return "email:" + name.EmailAddress
+
+	case name.IPAddress != nil:
+		return "IP Address:" + name.IPAddress.String()
+
+	case name.URI != nil:
+		return "URI:" + name.URI.String()
+
+	case name.Raw.Class == goasn1.ClassContextSpecific && name.Raw.Tag == rawNameTagRegisteredID:
+		if oid, err := registeredIDText(name.Raw.Bytes); err == nil {
+			return "Registered ID:" + oid
+		}
+	}
+
+	if prefix, ok := rawNameTexts[name.Raw.Tag]; ok && name.Raw.Class == goasn1.ClassContextSpecific {
+		return prefix + ":<unsupported>"
+	}
+
+	return "<unsupported>"
+}
+
+// registeredIDText returns the text representation of the contents octets
+// of a registeredID general name.
+func registeredIDText(b []byte) (string, error) {
+	der, err := goasn1.Marshal(goasn1.RawValue{Tag: goasn1.TagOID, Bytes: b})
+	if err != nil {
+		return "", err
+	}
+
+	var oid goasn1.ObjectIdentifier
+	if rest, err := goasn1.Unmarshal(der, &oid); err != nil {
+		return "", err
+	} else if len(rest) != 0 {
+		return "", ErrTrailingBytes
+	}
+
+	return asn1.OIDName(oid), nil
+}
+
+// generalNamesText returns the text representations of a set of general
+// names.
+func generalNamesText(names asn1.GeneralNames) []string {
+	var lines []string
+
+	for _, name := range names {
+		lines = append(lines, generalNameText(name))
+	}
+
+	return lines
+}
+
+// reasonFlagsText returns a comma-separated list of the names of a set of
+// reason flags.
+func reasonFlagsText(flags asn1.ReasonFlags) string {
+	var names []string
+
+	for i, name := range reasonFlagTexts {
+		if flags&(1<<uint(i)) != 0 {
+			names = append(names, name)
+		}
+	}
+
+	return strings.Join(names, ", ")
+}
+
+// distributionPointNameText returns the text representation of a
+// distribution point name.
+func distributionPointNameText(name asn1.DistributionPointName) []string {
+	if len(name.RelativeName) != 0 {
+		return []string{
+			"Relative Name:",
+			nestedIndent + nameText(pkix.RDNSequence{name.RelativeName}),
+		}
+	}
+
+	if !name.FullName.IsEmpty() {
+		return append([]string{"Full Name:"}, indent(generalNamesText(name.FullName))...)
+	}
+
+	return nil
+}
+
+// distributionPointsText returns the text representation of a list of
+// distribution points.
+func distributionPointsText(dps []asn1.DistributionPoint) []string {
+	var lines []string
+
+	for _, dp := range dps {
+		lines = append(lines, distributionPointNameText(dp.Name)...)
+
+		if dp.Reasons != 0 {
+			lines = append(lines, "Reasons:", nestedIndent+reasonFlagsText(dp.Reasons))
+		}
+
+		if !dp.CRLIssuer.IsEmpty() {
+			lines = append(lines, "CRL Issuer:")
+			lines = append(lines, indent(generalNamesText(dp.CRLIssuer))...)
+		}
+	}
+
+	return lines
+}
+
+// policiesText returns the text representation of a list of certificate
+// policies.
+func policiesText(policies []asn1.PolicyInformation) []string {
+	var lines []string
+
+	for _, p := range policies {
+		lines = append(lines, "Policy: "+asn1.OIDLongName(p.Policy))
+
+		for _, q := range p.Qualifiers {
+			switch {
+			case q.ID.Equal(asn1.OIDPolicyQualifierCPS):
+				lines = append(lines, nestedIndent+"CPS: "+q.CPSURI)
+
+			case q.ID.Equal(asn1.OIDPolicyQualifierUserNotice) && len(q.Raw.FullBytes) != 0:
+				lines = append(lines, nestedIndent+"User Notice:")
+				lines = append(lines, indent(indent(dumpText(q.Raw.FullBytes)))...)
+
+			case q.ID.Equal(asn1.OIDPolicyQualifierUserNotice):
+				lines = append(lines, nestedIndent+"User Notice:")
+				lines = append(lines, indent(indent(userNoticeText(q.UserNotice)))...)
+
+			default:
+				lines = append(lines, nestedIndent+"Unknown Qualifier: "+asn1.OIDLongName(q.ID))
+			}
+		}
+	}
+
+	return lines
+}
+
+// userNoticeText returns the text representation of a user notice.
+func userNoticeText(notice asn1.UserNotice) []string {
+	var lines []string
+
+	if !notice.NoticeRef.Organization.IsZero() || len(notice.NoticeRef.NoticeNumbers) != 0 {
+		var nums []string
+		for _, n := range notice.NoticeRef.NoticeNumbers {
+			nums = append(nums, fmt.Sprintf("%d", n))
+		}
+
+		lines = append(lines,
+			"Organization: "+notice.NoticeRef.Organization.Text,
+			"Numbers: "+strings.Join(nums, ", "),
+		)
+	}
+
+	if !notice.ExplicitText.IsZero() {
+		lines = append(lines, "Explicit Text: "+notice.ExplicitText.Text)
+	}
+
+	return lines
+}
+
+// subtreesText returns the text representation of a list of general
+// subtrees. IP address ranges are shown as an address and mask.
+func subtreesText(subtrees []asn1.GeneralSubtree) []string {
+	var lines []string
+
+	for _, s := range subtrees {
+		switch {
+		case s.DNSName != "":
+			lines = append(lines, "DNS:"+s.DNSName)
+
+		case s.DirectoryName != nil:
+			lines = append(lines, "DirName:"+nameText(s.DirectoryName))
+
+		case s.EmailAddress != "":
+			lines = append(lines, "email:"+s.EmailAddress)
+
+		case s.IPRange != nil:
+			lines = append(lines, "IP:"+s.IPRange.IP.String()+"/"+net.IP(s.IPRange.Mask).String())
+
+		case s.URIDomain != "":
+			lines = append(lines, "URI:"+s.URIDomain)
+		}
+	}
+
+	return lines
+}
+
+// dumpText returns the text representation of a DER-encoded value, which is
+// an ASN.1 dump in the style of the OpenSSL asn1parse command, or a hex dump
+// if the value cannot be parsed.
+func dumpText(der []byte) []string {
+	lines, err := dumpASN1(der, 0, 0)
+	if err != nil || len(der) == 0 {
+		return strings.Split(strings.TrimRight(hex.Dump(der), "\n"), "\n")
+	}
+
+	return lines
+}
+
+// dumpASN1 returns an ASN.1 dump of a sequence of DER-encoded values, with
+// offsets relative to the specified offset.
+func dumpASN1(b []byte, offset, depth int) ([]string, error) {
+	var lines []string
+
+	for len(b) > 0 {
+		var val goasn1.RawValue
+
+		rest, err := goasn1.Unmarshal(b, &val)
+		if err != nil {
+			return nil, err
+		}
+
+		var hl = len(val.FullBytes) - len(val.Bytes)
+		var kind = "prim"
+		if val.IsCompound {
+			kind = "cons"
+		}
+
+		var line = fmt.Sprintf("%5d:d=%-2d hl=%d l=%4d %s: %s%-18s",
+			offset, depth, hl, len(val.Bytes), kind, strings.Repeat(" ", depth), tagText(val))
+
+		if val.IsCompound {
+			lines = append(lines, strings.TrimRight(line, " "))
+
+			nested, err := dumpASN1(val.Bytes, offset+hl, depth+1)
+			if err != nil {
+				return nil, err
+			}
+
+			lines = append(lines, nested...)
+		} else if s, ok := primitiveText(val); ok {
+			lines = append(lines, line+":"+s)
+		} else {
+			lines = append(lines, strings.TrimRight(line, " "))
+		}
+
+		offset += len(val.FullBytes)
+		b = rest
+	}
+
+	return lines, nil
+}
+
+// tagText returns the OpenSSL name of the tag of a raw value.
+func tagText(val goasn1.RawValue) string {
+	switch val.Class {
+	case goasn1.ClassContextSpecific:
+		return fmt.Sprintf("cont [ %d ]", val.Tag)
+
+	case goasn1.ClassApplication:
+		return fmt.Sprintf("appl [ %d ]", val.Tag)
+
+	case goasn1.ClassPrivate:
+		return fmt.Sprintf("priv [ %d ]", val.Tag)
+	}
+
+	if name, ok := asn1TagNames[val.Tag]; ok {
+		return name
+	}
+
+	return fmt.Sprintf("<ASN1 %d>", val.Tag)
+}
+
+// primitiveText returns the text representation of the contents of a
+// primitive universal value in an ASN.1 dump, if it has one.
+func primitiveText(val goasn1.RawValue) (string, bool) {
+	if val.Class != goasn1.ClassUniversal {
+		return "", false
+	}
+
+	if s, ok := rawString(val); ok {
+		return s, true
+	}
+
+	switch val.Tag {
+	case goasn1.TagBoolean:
+		if len(val.Bytes) == 1 {
+			return fmt.Sprintf("%d", val.Bytes[0]), true
+		}
+
+	case goasn1.TagInteger, goasn1.TagEnum:
+		return fmt.Sprintf("%X", val.Bytes), true
+
+	case goasn1.TagOctetString:
+		return fmt.Sprintf("[HEX DUMP]:%X", val.Bytes), true
+
+	case goasn1.TagOID:
+		var oid goasn1.ObjectIdentifier
+		if _, err := goasn1.Unmarshal(val.FullBytes, &oid); err == nil {
+			return asn1.OIDLongName(oid), true
+		}
+	}
+
+	return "", false
+}
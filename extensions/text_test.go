@@ -0,0 +1,325 @@
+package extensions_test
+
+import (
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"net"
+	"testing"
+	"time"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestText(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.Extension
+		want string
+	}{
+		{
+			name: "KeyUsage",
+			ext: &extensions.KeyUsage{
+				Critical: true,
+				Value:    x509.KeyUsageDigitalSignature | x509.KeyUsageCertSign,
+			},
+			want: "X509v3 Key Usage: critical\n" +
+				"    Digital Signature, Certificate Sign",
+		},
+		{
+			name: "BasicConstraints",
+			ext: &extensions.BasicConstraints{
+				Critical:   true,
+				IsCA:       true,
+				MaxPathLen: 0,
+			},
+			want: "X509v3 Basic Constraints: critical\n" +
+				"    CA:TRUE, pathlen:0",
+		},
+		{
+			name: "ExtendedKeyUsage",
+			ext: &extensions.ExtendedKeyUsage{
+				OIDs: []asn1.ObjectIdentifier{
+					pgasn1.OIDExtKeyUsageServerAuth,
+					{1, 2, 3, 4},
+				},
+			},
+			want: "X509v3 Extended Key Usage:\n" +
+				"    TLS Web Server Authentication, 1.2.3.4",
+		},
+		{
+			name: "AuthorityKeyIdentifier",
+			ext: &extensions.AuthorityKeyIdentifier{
+				ID:           []byte{1, 2, 3, 4},
+				Issuer:       pgasn1.GeneralNames{{DNSName: "ca.example.com"}},
+				SerialNumber: big.NewInt(258),
+			},
+			want: "X509v3 Authority Key Identifier:\n" +
+				"    keyid:01:02:03:04\n" +
+				"    DNS:ca.example.com\n" +
+				"    serial:01:02",
+		},
+		{
+			name: "SubjectAltName",
+			ext: &extensions.SubjectAltName{
+				GeneralNames: pgasn1.GeneralNames{
+					{DNSName: "example.com"},
+					{IPAddress: net.ParseIP("192.0.2.1").To4()},
+				},
+			},
+			want: "X509v3 Subject Alternative Name:\n" +
+				"    DNS:example.com, IP Address:192.0.2.1",
+		},
+		{
+			name: "CRLDistributionPoints",
+			ext: &extensions.CRLDistributionPoints{
+				DistributionPoints: []pgasn1.DistributionPoint{
+					{
+						Name: pgasn1.DistributionPointName{
+							FullName: pgasn1.GeneralNames{
+								{URI: mustParseURI(t, "http://example.com/ca.crl")},
+							},
+						},
+						CRLIssuer: pgasn1.GeneralNames{
+							{DirectoryName: pkix.RDNSequence{
+								{{Type: pgasn1.OIDCommonName, Value: "Test CA"}},
+								{{Type: pgasn1.OIDOrganizationName, Value: "Example"}},
+							}},
+						},
+					},
+				},
+			},
+			want: "X509v3 CRL Distribution Points:\n" +
+				"    Full Name:\n" +
+				"      URI:http://example.com/ca.crl\n" +
+				"    CRL Issuer:\n" +
+				"      DirName:CN = Test CA, O = Example",
+		},
+		{
+			name: "AuthorityInfoAccess",
+			ext: &extensions.AuthorityInfoAccess{
+				Descriptions: []pgasn1.AccessDescription{
+					{
+						Method:   pgasn1.OIDAccessMethodCAIssuers,
+						Location: pgasn1.GeneralName{URI: mustParseURI(t, "http://example.com/ca.crt")},
+					},
+				},
+			},
+			want: "Authority Information Access:\n" +
+				"    CA Issuers - URI:http://example.com/ca.crt",
+		},
+		{
+			name: "CertificatePolicies",
+			ext: &extensions.CertificatePolicies{
+				Policies: []pgasn1.PolicyInformation{
+					{
+						Policy: pgasn1.OIDAnyPolicy,
+						Qualifiers: []pgasn1.PolicyQualifierInfo{
+							{
+								ID:     pgasn1.OIDPolicyQualifierCPS,
+								CPSURI: "http://example.com/cps",
+							},
+							{
+								ID: pgasn1.OIDPolicyQualifierUserNotice,
+								UserNotice: pgasn1.UserNotice{
+									ExplicitText: pgasn1.DisplayText{Text: "Notice"},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: "X509v3 Certificate Policies:\n" +
+				"    Policy: X509v3 Any Policy\n" +
+				"      CPS: http://example.com/cps\n" +
+				"      User Notice:\n" +
+				"        Explicit Text: Notice",
+		},
+		{
+			name: "CertificatePoliciesRawUserNotice",
+			ext: &extensions.CertificatePolicies{
+				Policies: []pgasn1.PolicyInformation{
+					{
+						Policy: pgasn1.OIDAnyPolicy,
+						Qualifiers: []pgasn1.PolicyQualifierInfo{
+							{
+								ID: pgasn1.OIDPolicyQualifierUserNotice,
+								Raw: asn1.RawValue{
+									FullBytes: []byte{asn1.TagSequence | bit6, 2, asn1.TagUTF8String, 0},
+								},
+							},
+						},
+					},
+				},
+			},
+			want: "X509v3 Certificate Policies:\n" +
+				"    Policy: X509v3 Any Policy\n" +
+				"      User Notice:\n" +
+				"            0:d=0  hl=2 l=   2 cons: SEQUENCE\n" +
+				"            2:d=1  hl=2 l=   0 prim:  UTF8STRING        :",
+		},
+		{
+			name: "NameConstraints",
+			ext: &extensions.NameConstraints{
+				Critical: true,
+				Excluded: []pgasn1.GeneralSubtree{
+					{IPRange: mustParseCIDR(t, "10.0.0.0/8")},
+				},
+			},
+			want: "X509v3 Name Constraints: critical\n" +
+				"    Excluded:\n" +
+				"      IP:10.0.0.0/255.0.0.0",
+		},
+		{
+			name: "IPAddrBlocks",
+			ext: &extensions.IPAddrBlocks{
+				Critical: true,
+				Families: []pgasn1.IPAddressFamily{
+					{
+						AFI:       pgasn1.AFIIPv4,
+						SAFI:      1,
+						Addresses: []pgasn1.IPAddressOrRange{{Prefix: mustParseCIDR(t, "192.0.2.0/24")}},
+					},
+					{AFI: pgasn1.AFIIPv6, Inherit: true},
+				},
+			},
+			want: "sbgp-ipAddrBlock: critical\n" +
+				"    IPv4 (Unicast):\n" +
+				"      192.0.2.0/24\n" +
+				"    IPv6: inherit",
+		},
+		{
+			name: "ReasonCode",
+			ext:  &extensions.ReasonCode{Reason: extensions.CRLReasonCACompromise},
+			want: "X509v3 CRL Reason Code:\n" +
+				"    CA Compromise",
+		},
+		{
+			name: "InvalidityDate",
+			ext:  &extensions.InvalidityDate{Time: time.Date(2020, 1, 2, 3, 4, 5, 0, time.UTC)},
+			want: "Invalidity Date:\n" +
+				"    Jan  2 03:04:05 2020 GMT",
+		},
+		{
+			name: "OCSPNoCheck",
+			ext:  &extensions.OCSPNoCheck{},
+			want: "OCSP No Check:",
+		},
+		{
+			name: "SignedCertificateTimestampList",
+			ext: &extensions.SignedCertificateTimestampList{
+				SCTs: []extensions.SignedCertificateTimestamp{testSCT()},
+			},
+			want: "CT Precertificate SCTs:\n" +
+				"    Signed Certificate Timestamp:\n" +
+				"      Version   : v1 (0x0)\n" +
+				"      Log ID    : 11:11:11:11:11:11:11:11:11:11:11:11:11:11:11:11:" +
+				"11:11:11:11:11:11:11:11:11:11:11:11:11:11:11:11\n" +
+				"      Timestamp : Jan  2 03:04:05.678 2020 GMT\n" +
+				"      Extensions: AA\n" +
+				"      Signature : ecdsa-with-SHA256\n" +
+				"                  01:02:03:04",
+		},
+		{
+			name: "SignedCertificateTimestampListUnknownVersion",
+			ext: &extensions.SignedCertificateTimestampList{
+				SCTs: []extensions.SignedCertificateTimestamp{
+					{Version: 2, Raw: []byte{2, 0xab, 0xcd}},
+				},
+			},
+			want: "CT Precertificate SCTs:\n" +
+				"    Signed Certificate Timestamp:\n" +
+				"      Version   : unknown (0x2)\n" +
+				"      Data      : 02:AB:CD",
+		},
+		{
+			name: "Raw",
+			ext: &extensions.Raw{
+				ID:       asn1.ObjectIdentifier{1, 2, 3, 4},
+				Critical: true,
+				Value: []byte{
+					asn1.TagSequence | bit6, 8,
+					asn1.TagInteger, 1, 1,
+					asn1.TagUTF8String, 3, 'f', 'o', 'o',
+				},
+			},
+			want: "1.2.3.4: critical\n" +
+				"        0:d=0  hl=2 l=   8 cons: SEQUENCE\n" +
+				"        2:d=1  hl=2 l=   1 prim:  INTEGER           :01\n" +
+				"        5:d=1  hl=2 l=   3 prim:  UTF8STRING        :foo",
+		},
+		{
+			name: "RawStrings",
+			ext: &extensions.Raw{
+				ID: asn1.ObjectIdentifier{1, 2, 3, 4},
+				Value: []byte{
+					asn1.TagSequence | bit6, 14,
+					pgasn1.TagVisibleString, 2, 'a', 'b',
+					pgasn1.TagBMPString, 4, 0, 'h', 0, 'i',
+					pgasn1.TagBMPString, 2, 0xd8, 0x00,
+				},
+			},
+			want: "1.2.3.4:\n" +
+				"        0:d=0  hl=2 l=  14 cons: SEQUENCE\n" +
+				"        2:d=1  hl=2 l=   2 prim:  VISIBLESTRING     :ab\n" +
+				"        6:d=1  hl=2 l=   4 prim:  BMPSTRING         :hi\n" +
+				"       12:d=1  hl=2 l=   2 prim:  BMPSTRING",
+		},
+		{
+			name: "RawInvalid",
+			ext: &extensions.Raw{
+				ID:    asn1.ObjectIdentifier{1, 2, 3, 4},
+				Value: []byte{asn1.TagSequence | bit6, 8},
+			},
+			want: "1.2.3.4:\n" +
+				"    00000000  30 08                                             |0.|",
+		},
+		{
+			name: "Unregistered",
+			ext:  &testPrivateExtension{Value: 5},
+			want: "1.3.6.1.4.1.99999.1:\n" +
+				"        0:d=0  hl=2 l=   1 prim: INTEGER           :05",
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := extensions.Text(tc.ext); got != tc.want {
+				t.Errorf("got:\n%s\nwant:\n%s", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestSetString(t *testing.T) {
+	t.Parallel()
+
+	set, err := extensions.ParseAll([]pkix.Extension{
+		testSKIExtension,
+		testKeyUsageExtension,
+		testUnknownExtension,
+	})
+	if err != nil {
+		t.Fatalf("couldn't parse extensions: %v", err)
+	}
+
+	var want = "X509v3 Subject Key Identifier:\n" +
+		"    01:02:03:04\n" +
+		"X509v3 Key Usage: critical\n" +
+		"    Digital Signature\n" +
+		"1.2.3.4:\n" +
+		"        0:d=0  hl=2 l=   0 prim: NULL"
+
+	if got := set.String(); got != want {
+		t.Errorf("got:\n%s\nwant:\n%s", got, want)
+	}
+}
@@ -0,0 +1,154 @@
+package extensions
+
+import (
+	"crypto/x509/pkix"
+	"encoding/asn1"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"strings"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+)
+
+// TLSFeature represents an X509 TLS feature extension as defined in RFC 7633
+// section 4. Each feature is a TLS extension number, and a certificate with
+// the status_request feature is commonly referred to as "must-staple".
+type TLSFeature struct {
+	Critical bool
+	Features []int
+}
+
+// TLS extension number values. See RFC 6066 section 8 and RFC 6961 section
+// 2.2.
+const (
+	TLSFeatureStatusRequest   = 5
+	TLSFeatureStatusRequestV2 = 17
+)
+
+// maxTLSExtensionNumber is the largest TLS extension number.
+const maxTLSExtensionNumber = 1<<16 - 1
+
+// Marshal returns a pkix.Extension.
+func (e TLSFeature) Marshal() (pkix.Extension, error) {
+	if len(e.Features) == 0 {
+		return pkix.Extension{}, errors.New("no TLS features specified")
+	}
+
+	if err := checkTLSFeatures(e.Features); err != nil {
+		return pkix.Extension{}, err
+	}
+
+	der, err := asn1.Marshal(e.Features)
+	if err != nil {
+		return pkix.Extension{}, err
+	}
+
+	return pkix.Extension{
+		Id:       pgasn1.OIDTLSFeature,
+		Critical: e.Critical,
+		Value:    der,
+	}, nil
+}
+
+// Unmarshal parses a pkix.Extension and stores the result in the object.
+func (e *TLSFeature) Unmarshal(ext pkix.Extension) error {
+	if !ext.Id.Equal(pgasn1.OIDTLSFeature) {
+		return fmt.Errorf("unexpected OID: %v", ext.Id)
+	}
+
+	var features []int
+	if rest, err := asn1.Unmarshal(ext.Value, &features); err != nil {
+		return err
+	} else if len(rest) > 0 {
+		return ErrTrailingBytes
+	}
+
+	if err := checkTLSFeatures(features); err != nil {
+		return err
+	}
+
+	*e = TLSFeature{
+		Critical: ext.Critical,
+		Features: features,
+	}
+
+	return nil
+}
+
+// MustStaple returns true if the extension requires the TLS server to
+// provide a stapled OCSP response, via either the status_request or
+// status_request_v2 TLS extension.
+func (e TLSFeature) MustStaple() bool {
+	for _, f := range e.Features {
+		if f == TLSFeatureStatusRequest || f == TLSFeatureStatusRequestV2 {
+			return true
+		}
+	}
+
+	return false
+}
+
+// checkTLSFeatures returns an error if any feature is not a valid TLS
+// extension number.
+func checkTLSFeatures(features []int) error {
+	for _, f := range features {
+		if f < 0 || f > maxTLSExtensionNumber {
+			return fmt.Errorf("invalid TLS extension number: %d", f)
+		}
+	}
+
+	return nil
+}
+
+// tlsFeatureJSON is the JSON representation of a TLSFeature.
+type tlsFeatureJSON struct {
+	Critical bool  `json:"critical"`
+	Features []int `json:"features"`
+}
+
+// MarshalJSON returns the JSON encoding of the extension, which is an object
+// with "critical" and "features" members. The features are a list of TLS
+// extension numbers.
+func (e TLSFeature) MarshalJSON() ([]byte, error) {
+	return json.Marshal(tlsFeatureJSON{
+		Critical: e.Critical,
+		Features: e.Features,
+	})
+}
+
+// UnmarshalJSON parses a JSON-encoded extension and stores the result in the
+// object.
+func (e *TLSFeature) UnmarshalJSON(b []byte) error {
+	var tmp tlsFeatureJSON
+	if err := json.Unmarshal(b, &tmp); err != nil {
+		return err
+	}
+
+	*e = TLSFeature{
+		Critical: tmp.Critical,
+		Features: tmp.Features,
+	}
+
+	return nil
+}
+
+// String returns a text representation of the extension. See Text.
+func (e TLSFeature) String() string {
+	var names []string
+
+	for _, f := range e.Features {
+		switch f {
+		case TLSFeatureStatusRequest:
+			names = append(names, "status_request")
+
+		case TLSFeatureStatusRequestV2:
+			names = append(names, "status_request_v2")
+
+		default:
+			names = append(names, fmt.Sprintf("%d", f))
+		}
+	}
+
+	return text(pgasn1.OIDTLSFeature, e.Critical, strings.Join(names, ", "))
+}
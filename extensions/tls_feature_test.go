@@ -0,0 +1,180 @@
+package extensions_test
+
+import (
+	"crypto/x509/pkix"
+	"errors"
+	"reflect"
+	"testing"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestTLSFeatureMarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  extensions.TLSFeature
+		want pkix.Extension
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: extensions.TLSFeature{
+				Features: []int{
+					extensions.TLSFeatureStatusRequest,
+					extensions.TLSFeatureStatusRequestV2,
+				},
+			},
+			want: pkix.Extension{
+				Id: pgasn1.OIDTLSFeature,
+				Value: []byte{asn1.TagSequence | bit6, 6,
+					asn1.TagInteger, 1, 5,
+					asn1.TagInteger, 1, 17,
+				},
+			},
+		},
+		{
+			name: "Empty",
+			ext:  extensions.TLSFeature{},
+			want: pkix.Extension{},
+			err:  errors.New("no features"),
+		},
+		{
+			name: "OutOfRange",
+			ext:  extensions.TLSFeature{Features: []int{65536}},
+			want: pkix.Extension{},
+			err:  errors.New("out of range"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			got, err := tc.ext.Marshal()
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTLSFeatureUnmarshal(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name string
+		ext  pkix.Extension
+		want extensions.TLSFeature
+		err  error
+	}{
+		{
+			name: "OK",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDTLSFeature,
+				Value: []byte{asn1.TagSequence | bit6, 3, asn1.TagInteger, 1, 5},
+			},
+			want: extensions.TLSFeature{
+				Features: []int{extensions.TLSFeatureStatusRequest},
+			},
+		},
+		{
+			name: "Negative",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDTLSFeature,
+				Value: []byte{asn1.TagSequence | bit6, 3, asn1.TagInteger, 1, 0xff},
+			},
+			want: extensions.TLSFeature{},
+			err:  errors.New("negative"),
+		},
+		{
+			name: "BadOID",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDKeyUsage,
+				Value: []byte{asn1.TagSequence | bit6, 3, asn1.TagInteger, 1, 5},
+			},
+			want: extensions.TLSFeature{},
+			err:  errors.New("bad OID"),
+		},
+		{
+			name: "TrailingBytes",
+			ext: pkix.Extension{
+				Id:    pgasn1.OIDTLSFeature,
+				Value: []byte{asn1.TagSequence | bit6, 3, asn1.TagInteger, 1, 5, 0},
+			},
+			want: extensions.TLSFeature{},
+			err:  errors.New("trailing bytes"),
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got extensions.TLSFeature
+
+			err := got.Unmarshal(tc.ext)
+			if (err == nil) != (tc.err == nil) {
+				t.Fatalf("got error %v, want %v", err, tc.err)
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestTLSFeatureMustStaple(t *testing.T) {
+	t.Parallel()
+
+	var testcases = []struct {
+		name     string
+		features []int
+		want     bool
+	}{
+		{
+			name:     "StatusRequest",
+			features: []int{extensions.TLSFeatureStatusRequest},
+			want:     true,
+		},
+		{
+			name:     "StatusRequestV2",
+			features: []int{10, extensions.TLSFeatureStatusRequestV2},
+			want:     true,
+		},
+		{
+			name:     "Other",
+			features: []int{10},
+			want:     false,
+		},
+		{
+			name: "Empty",
+			want: false,
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			if got := (extensions.TLSFeature{Features: tc.features}).MustStaple(); got != tc.want {
+				t.Errorf("got %t, want %t", got, tc.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,319 @@
+package extensions
+
+import (
+	"bytes"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	goasn1 "encoding/asn1"
+	"fmt"
+
+	"github.com/paulgriffiths/pki/asn1"
+)
+
+// Severity indicates whether a finding violates a requirement or a
+// recommendation.
+type Severity int
+
+// Finding severity values.
+const (
+	// SeverityError indicates a violation of a requirement which RFC 5280
+	// expresses with MUST or MUST NOT.
+	SeverityError Severity = iota
+
+	// SeverityWarning indicates a violation of a recommendation which RFC
+	// 5280 expresses with SHOULD or SHOULD NOT.
+	SeverityWarning
+)
+
+// Rule identifies the rule which a finding violates.
+type Rule string
+
+// Validation rule values.
+const (
+	RuleInvalidExtension     Rule = "invalid-extension"
+	RuleDuplicateExtension   Rule = "duplicate-extension"
+	RuleCriticality          Rule = "criticality"
+	RuleEmptySubjectSAN      Rule = "empty-subject-san"
+	RuleKeyCertSignWithoutCA Rule = "key-cert-sign-without-ca"
+	RulePathLenWithoutCA     Rule = "path-len-without-ca"
+	RuleMissingSKI           Rule = "missing-ski"
+	RuleMissingAKI           Rule = "missing-aki"
+)
+
+// Context identifies the kind of object whose extensions are validated.
+type Context int
+
+// Validation context values.
+const (
+	CertificateContext Context = iota
+	RequestContext
+)
+
+// ValidateOptions contains options for validating a set of extensions.
+// EmptySubject indicates that the subject name is an empty sequence.
+// SelfSigned indicates that a certificate is self-signed, and so may omit
+// the authority key identifier extension. The subject key identifier and
+// authority key identifier presence rules are not applied in
+// RequestContext, since those extensions are normally added by the CA.
+type ValidateOptions struct {
+	Context      Context
+	EmptySubject bool
+	SelfSigned   bool
+}
+
+// Finding represents a violation of an RFC 5280 rule found when validating
+// a set of extensions. ID is the OID of the extension concerned, which may
+// be an extension which is missing.
+type Finding struct {
+	Rule     Rule
+	Severity Severity
+	ID       goasn1.ObjectIdentifier
+	Message  string
+}
+
+// criticalityRules lists the extensions whose criticality is fixed by RFC
+// 5280, other than those for which it depends on the other extensions.
+var criticalityRules = []struct {
+	oid      goasn1.ObjectIdentifier
+	name     string
+	critical bool
+	severity Severity
+}{
+	{asn1.OIDAuthorityKeyIdentifier, "authority key identifier", false, SeverityError},
+	{asn1.OIDSubjectKeyIdentifier, "subject key identifier", false, SeverityError},
+	{asn1.OIDKeyUsage, "key usage", true, SeverityWarning},
+	{asn1.OIDPolicyMappings, "policy mappings", true, SeverityWarning},
+	{asn1.OIDIssuerAltName, "issuer alternative name", false, SeverityWarning},
+	{asn1.OIDSubjectDirectoryAttributes, "subject directory attributes", false, SeverityError},
+	{asn1.OIDNameConstraints, "name constraints", true, SeverityError},
+	{asn1.OIDPolicyConstraints, "policy constraints", true, SeverityError},
+	{asn1.OIDCRLDistributionPoints, "CRL distribution points", false, SeverityWarning},
+	{asn1.OIDInhibitAnyPolicy, "inhibit anyPolicy", true, SeverityError},
+	{asn1.OIDFreshestCRL, "freshest CRL", false, SeverityError},
+	{asn1.OIDAuthorityInfoAccess, "authority information access", false, SeverityError},
+	{asn1.OIDSubjectInfoAccess, "subject information access", false, SeverityError},
+}
+
+// validator accumulates findings while validating a set of extensions.
+type validator struct {
+	opts     ValidateOptions
+	raw      map[string]pkix.Extension
+	set      Set
+	findings []Finding
+}
+
+// Validate checks a list of certificate or certificate request extensions
+// against the criticality and consistency rules of RFC 5280 section 4.2,
+// and returns any findings.
+func Validate(exts []pkix.Extension, opts ValidateOptions) []Finding {
+	set, dups := parseSet(exts)
+
+	var v = validator{
+		opts: opts,
+		raw:  make(map[string]pkix.Extension),
+		set:  set,
+	}
+
+	for _, ext := range exts {
+		if _, ok := v.raw[ext.Id.String()]; !ok {
+			v.raw[ext.Id.String()] = ext
+		}
+	}
+
+	for _, oid := range dups {
+		v.add(RuleDuplicateExtension, SeverityError, oid, "extension appears more than once")
+	}
+
+	for _, err := range set.errs {
+		v.add(RuleInvalidExtension, SeverityError, err.ID, "couldn't parse extension: %v", err.Err)
+	}
+
+	v.checkCriticality()
+	v.checkSubjectAltName()
+	v.checkBasicConstraints()
+	v.checkKeyIdentifiers()
+
+	return v.findings
+}
+
+// ValidateCertificate checks the extensions in a certificate. See Validate.
+func ValidateCertificate(cert *x509.Certificate) []Finding {
+	return Validate(cert.Extensions, ValidateOptions{
+		Context:      CertificateContext,
+		EmptySubject: isEmptyName(cert.RawSubject, cert.Subject),
+		SelfSigned:   isSelfSigned(cert),
+	})
+}
+
+// ValidateCertificateRequest checks the extensions in a certificate request.
+// See Validate.
+func ValidateCertificateRequest(csr *x509.CertificateRequest) []Finding {
+	return Validate(csr.Extensions, ValidateOptions{
+		Context:      RequestContext,
+		EmptySubject: isEmptyName(csr.RawSubject, csr.Subject),
+	})
+}
+
+// String returns a string representation of the severity.
+func (s Severity) String() string {
+	switch s {
+	case SeverityError:
+		return "error"
+
+	case SeverityWarning:
+		return "warning"
+	}
+
+	return fmt.Sprintf("Severity(%d)", int(s))
+}
+
+// String returns a string representation of the finding.
+func (f Finding) String() string {
+	return fmt.Sprintf("%v: %s: %v: %s", f.Severity, f.Rule, f.ID, f.Message)
+}
+
+// add adds a finding.
+func (v *validator) add(rule Rule, severity Severity, oid goasn1.ObjectIdentifier, format string, args ...interface{}) {
+	v.findings = append(v.findings, Finding{
+		Rule:     rule,
+		Severity: severity,
+		ID:       oid,
+		Message:  fmt.Sprintf(format, args...),
+	})
+}
+
+// checkCriticality checks the extensions whose criticality is fixed.
+func (v *validator) checkCriticality() {
+	for _, r := range criticalityRules {
+		ext, ok := v.raw[r.oid.String()]
+		if !ok || ext.Critical == r.critical {
+			continue
+		}
+
+		if r.critical {
+			v.add(RuleCriticality, r.severity, r.oid, "%s extension is not critical", r.name)
+		} else {
+			v.add(RuleCriticality, r.severity, r.oid, "%s extension is critical", r.name)
+		}
+	}
+}
+
+// checkSubjectAltName checks that a subject alternative name extension is
+// present and critical if the subject name is empty, and is otherwise not
+// critical. See RFC 5280 section 4.2.1.6.
+func (v *validator) checkSubjectAltName() {
+	ext, ok := v.raw[asn1.OIDSubjectAltName.String()]
+
+	switch {
+	case v.opts.EmptySubject && !ok:
+		v.add(RuleEmptySubjectSAN, SeverityError, asn1.OIDSubjectAltName,
+			"subject is empty and subject alternative name extension is missing")
+
+	case v.opts.EmptySubject && !ext.Critical:
+		v.add(RuleEmptySubjectSAN, SeverityError, asn1.OIDSubjectAltName,
+			"subject is empty and subject alternative name extension is not critical")
+
+	case !v.opts.EmptySubject && ok && ext.Critical:
+		v.add(RuleCriticality, SeverityWarning, asn1.OIDSubjectAltName,
+			"subject is not empty and subject alternative name extension is critical")
+	}
+}
+
+// checkBasicConstraints checks the consistency of the basic constraints and
+// key usage extensions. See RFC 5280 sections 4.2.1.3 and 4.2.1.9.
+func (v *validator) checkBasicConstraints() {
+	bc, hasBC := v.set.BasicConstraints()
+	ku, hasKU := v.set.KeyUsage()
+
+	var certSign = ku.Value&x509.KeyUsageCertSign != 0
+
+	if certSign && !bc.IsCA {
+		v.add(RuleKeyCertSignWithoutCA, SeverityError, asn1.OIDKeyUsage,
+			"keyCertSign is asserted but basic constraints cA is not")
+	}
+
+	if !hasBC {
+		return
+	}
+
+	if bc.IsCA && (!hasKU || certSign) && !bc.Critical {
+		v.add(RuleCriticality, SeverityError, asn1.OIDBasicConstraints,
+			"basic constraints extension is not critical in a CA certificate")
+	}
+
+	if bc.MaxPathLen != -1 {
+		if !bc.IsCA {
+			v.add(RulePathLenWithoutCA, SeverityError, asn1.OIDBasicConstraints,
+				"pathLenConstraint is present but cA is not asserted")
+		} else if hasKU && !certSign {
+			v.add(RulePathLenWithoutCA, SeverityError, asn1.OIDBasicConstraints,
+				"pathLenConstraint is present but keyCertSign is not asserted")
+		}
+	}
+}
+
+// checkKeyIdentifiers checks the presence of the subject key identifier and
+// authority key identifier extensions in a certificate. See RFC 5280
+// sections 4.2.1.1 and 4.2.1.2.
+func (v *validator) checkKeyIdentifiers() {
+	if v.opts.Context != CertificateContext {
+		return
+	}
+
+	bc, _ := v.set.BasicConstraints()
+
+	if _, ok := v.raw[asn1.OIDSubjectKeyIdentifier.String()]; !ok {
+		if bc.IsCA {
+			v.add(RuleMissingSKI, SeverityError, asn1.OIDSubjectKeyIdentifier,
+				"subject key identifier extension is missing from a CA certificate")
+		} else {
+			v.add(RuleMissingSKI, SeverityWarning, asn1.OIDSubjectKeyIdentifier,
+				"subject key identifier extension is missing")
+		}
+	}
+
+	if v.opts.SelfSigned {
+		return
+	}
+
+	if _, ok := v.raw[asn1.OIDAuthorityKeyIdentifier.String()]; !ok {
+		v.add(RuleMissingAKI, SeverityError, asn1.OIDAuthorityKeyIdentifier,
+			"authority key identifier extension is missing")
+	} else if aki, ok := v.set.AuthorityKeyIdentifier(); ok && len(aki.ID) == 0 {
+		v.add(RuleMissingAKI, SeverityError, asn1.OIDAuthorityKeyIdentifier,
+			"authority key identifier extension has no key identifier")
+	}
+}
+
+// isEmptyName returns true if a DER-encoded name is an empty sequence. If
+// the DER-encoding is not available, the name is checked instead.
+func isEmptyName(raw []byte, name pkix.Name) bool {
+	if len(raw) == 0 {
+		return len(name.ToRDNSequence()) == 0
+	}
+
+	var rdns pkix.RDNSequence
+	if rest, err := goasn1.Unmarshal(raw, &rdns); err != nil || len(rest) != 0 {
+		return false
+	}
+
+	return len(rdns) == 0
+}
+
+// isSelfSigned returns true if the issuer and subject names of a certificate
+// are the same and, when it has both an authority key identifier and a
+// subject key identifier, the two identify the same key. The signature is
+// deliberately not checked, since crypto/x509 refuses to verify signatures
+// using legacy algorithms such as MD5, which some long-lived root
+// certificates still use.
+func isSelfSigned(cert *x509.Certificate) bool {
+	if !bytes.Equal(cert.RawIssuer, cert.RawSubject) {
+		return false
+	}
+
+	if len(cert.AuthorityKeyId) != 0 && len(cert.SubjectKeyId) != 0 {
+		return bytes.Equal(cert.AuthorityKeyId, cert.SubjectKeyId)
+	}
+
+	return true
+}
@@ -0,0 +1,398 @@
+package extensions_test
+
+import (
+	"bytes"
+	"crypto/ecdsa"
+	"crypto/elliptic"
+	"crypto/rand"
+	"crypto/rsa"
+	"crypto/x509"
+	"crypto/x509/pkix"
+	"math/big"
+	"reflect"
+	"testing"
+	"time"
+
+	"encoding/asn1"
+
+	pgasn1 "github.com/paulgriffiths/pki/asn1"
+	"github.com/paulgriffiths/pki/extensions"
+)
+
+func TestValidate(t *testing.T) {
+	t.Parallel()
+
+	var (
+		ski = mustMarshal(t, &extensions.SubjectKeyIdentifier{ID: []byte{1, 2, 3, 4}})
+		aki = mustMarshal(t, &extensions.AuthorityKeyIdentifier{ID: []byte{5, 6, 7, 8}})
+		san = mustMarshal(t, &extensions.SubjectAltName{GeneralNames: pgasn1.GeneralNames{{DNSName: "example.com"}}})
+
+		caBC = mustMarshal(t, &extensions.BasicConstraints{Critical: true, IsCA: true, MaxPathLen: -1})
+		caKU = mustMarshal(t, &extensions.KeyUsage{
+			Critical: true,
+			Value:    x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		})
+		eeKU = mustMarshal(t, &extensions.KeyUsage{Critical: true, Value: x509.KeyUsageDigitalSignature})
+
+		criticalSAN = mustMarshal(t, &extensions.SubjectAltName{
+			Critical: true,
+			GeneralNames: pgasn1.GeneralNames{
+				{DNSName: "example.com"},
+			},
+		})
+	)
+
+	var testcases = []struct {
+		name string
+		exts []pkix.Extension
+		opts extensions.ValidateOptions
+		want []extensions.Finding
+	}{
+		{
+			name: "CA",
+			exts: []pkix.Extension{caBC, caKU, ski, aki},
+		},
+		{
+			name: "EndEntity",
+			exts: []pkix.Extension{eeKU, san, ski, aki},
+		},
+		{
+			name: "SelfSigned",
+			exts: []pkix.Extension{caBC, caKU, ski},
+			opts: extensions.ValidateOptions{SelfSigned: true},
+		},
+		{
+			name: "Request",
+			exts: []pkix.Extension{eeKU, san},
+			opts: extensions.ValidateOptions{Context: extensions.RequestContext},
+		},
+		{
+			name: "EmptySubject",
+			exts: []pkix.Extension{criticalSAN, ski, aki},
+			opts: extensions.ValidateOptions{EmptySubject: true},
+		},
+		{
+			name: "EmptySubjectNoSAN",
+			exts: []pkix.Extension{ski, aki},
+			opts: extensions.ValidateOptions{EmptySubject: true},
+			want: []extensions.Finding{
+				{
+					Rule:     extensions.RuleEmptySubjectSAN,
+					Severity: extensions.SeverityError,
+					ID:       pgasn1.OIDSubjectAltName,
+				},
+			},
+		},
+		{
+			name: "EmptySubjectNonCriticalSAN",
+			exts: []pkix.Extension{san, ski, aki},
+			opts: extensions.ValidateOptions{EmptySubject: true},
+			want: []extensions.Finding{
+				{
+					Rule:     extensions.RuleEmptySubjectSAN,
+					Severity: extensions.SeverityError,
+					ID:       pgasn1.OIDSubjectAltName,
+				},
+			},
+		},
+		{
+			name: "CriticalSAN",
+			exts: []pkix.Extension{criticalSAN, ski, aki},
+			want: []extensions.Finding{
+				{
+					Rule:     extensions.RuleCriticality,
+					Severity: extensions.SeverityWarning,
+					ID:       pgasn1.OIDSubjectAltName,
+				},
+			},
+		},
+		{
+			name: "NonCriticalBasicConstraints",
+			exts: []pkix.Extension{
+				mustMarshal(t, &extensions.BasicConstraints{IsCA: true, MaxPathLen: -1}),
+				caKU,
+				ski,
+				aki,
+			},
+			want: []extensions.Finding{
+				{
+					Rule:     extensions.RuleCriticality,
+					Severity: extensions.SeverityError,
+					ID:       pgasn1.OIDBasicConstraints,
+				},
+			},
+		},
+		{
+			name: "NonCriticalBasicConstraintsWithoutKeyCertSign",
+			exts: []pkix.Extension{
+				mustMarshal(t, &extensions.BasicConstraints{IsCA: true, MaxPathLen: -1}),
+				eeKU,
+				ski,
+				aki,
+			},
+		},
+		{
+			name: "KeyCertSignWithoutCA",
+			exts: []pkix.Extension{caKU, ski, aki},
+			want: []extensions.Finding{
+				{
+					Rule:     extensions.RuleKeyCertSignWithoutCA,
+					Severity: extensions.SeverityError,
+					ID:       pgasn1.OIDKeyUsage,
+				},
+			},
+		},
+		{
+			name: "PathLenWithoutCA",
+			exts: []pkix.Extension{
+				{
+					Id:       pgasn1.OIDBasicConstraints,
+					Critical: true,
+					Value:    []byte{asn1.TagSequence | bit6, 3, asn1.TagInteger, 1, 0},
+				},
+				ski,
+				aki,
+			},
+			want: []extensions.Finding{
+				{
+					Rule:     extensions.RulePathLenWithoutCA,
+					Severity: extensions.SeverityError,
+					ID:       pgasn1.OIDBasicConstraints,
+				},
+			},
+		},
+		{
+			name: "PathLenWithoutKeyCertSign",
+			exts: []pkix.Extension{
+				mustMarshal(t, &extensions.BasicConstraints{Critical: true, IsCA: true, MaxPathLen: 0}),
+				eeKU,
+				ski,
+				aki,
+			},
+			want: []extensions.Finding{
+				{
+					Rule:     extensions.RulePathLenWithoutCA,
+					Severity: extensions.SeverityError,
+					ID:       pgasn1.OIDBasicConstraints,
+				},
+			},
+		},
+		{
+			name: "FixedCriticality",
+			exts: []pkix.Extension{
+				{Id: aki.Id, Critical: true, Value: aki.Value},
+				ski,
+				mustMarshal(t, &extensions.KeyUsage{Value: x509.KeyUsageDigitalSignature}),
+				mustMarshal(t, &extensions.NameConstraints{
+					Permitted: []pgasn1.GeneralSubtree{{DNSName: "example.com"}},
+				}),
+			},
+			want: []extensions.Finding{
+				{
+					Rule:     extensions.RuleCriticality,
+					Severity: extensions.SeverityError,
+					ID:       pgasn1.OIDAuthorityKeyIdentifier,
+				},
+				{
+					Rule:     extensions.RuleCriticality,
+					Severity: extensions.SeverityWarning,
+					ID:       pgasn1.OIDKeyUsage,
+				},
+				{
+					Rule:     extensions.RuleCriticality,
+					Severity: extensions.SeverityError,
+					ID:       pgasn1.OIDNameConstraints,
+				},
+			},
+		},
+		{
+			name: "MissingKeyIdentifiers",
+			exts: []pkix.Extension{eeKU},
+			want: []extensions.Finding{
+				{
+					Rule:     extensions.RuleMissingSKI,
+					Severity: extensions.SeverityWarning,
+					ID:       pgasn1.OIDSubjectKeyIdentifier,
+				},
+				{
+					Rule:     extensions.RuleMissingAKI,
+					Severity: extensions.SeverityError,
+					ID:       pgasn1.OIDAuthorityKeyIdentifier,
+				},
+			},
+		},
+		{
+			name: "MissingSKIFromCA",
+			exts: []pkix.Extension{caBC, caKU, aki},
+			want: []extensions.Finding{
+				{
+					Rule:     extensions.RuleMissingSKI,
+					Severity: extensions.SeverityError,
+					ID:       pgasn1.OIDSubjectKeyIdentifier,
+				},
+			},
+		},
+		{
+			name: "AKIWithoutKeyID",
+			exts: []pkix.Extension{
+				eeKU,
+				ski,
+				{
+					Id:    pgasn1.OIDAuthorityKeyIdentifier,
+					Value: []byte{asn1.TagSequence | bit6, 0},
+				},
+			},
+			want: []extensions.Finding{
+				{
+					Rule:     extensions.RuleMissingAKI,
+					Severity: extensions.SeverityError,
+					ID:       pgasn1.OIDAuthorityKeyIdentifier,
+				},
+			},
+		},
+		{
+			name: "DuplicateAndInvalid",
+			exts: []pkix.Extension{
+				eeKU,
+				ski,
+				aki,
+				ski,
+				{Id: pgasn1.OIDExtendedKeyUsage, Value: []byte{0xff}},
+			},
+			want: []extensions.Finding{
+				{
+					Rule:     extensions.RuleDuplicateExtension,
+					Severity: extensions.SeverityError,
+					ID:       pgasn1.OIDSubjectKeyIdentifier,
+				},
+				{
+					Rule:     extensions.RuleInvalidExtension,
+					Severity: extensions.SeverityError,
+					ID:       pgasn1.OIDExtendedKeyUsage,
+				},
+			},
+		},
+	}
+
+	for _, tc := range testcases {
+		var tc = tc
+
+		t.Run(tc.name, func(t *testing.T) {
+			t.Parallel()
+
+			var got = extensions.Validate(tc.exts, tc.opts)
+			for i := range got {
+				if got[i].Message == "" {
+					t.Errorf("finding %d has no message", i)
+				}
+
+				got[i].Message = ""
+			}
+
+			if !reflect.DeepEqual(got, tc.want) {
+				t.Errorf("got %v, want %v", got, tc.want)
+			}
+		})
+	}
+}
+
+func TestValidateCertificate(t *testing.T) {
+	t.Parallel()
+
+	key, err := ecdsa.GenerateKey(elliptic.P256(), rand.Reader)
+	if err != nil {
+		t.Fatalf("couldn't generate key: %v", err)
+	}
+
+	var template = &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Test CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		SubjectKeyId:          []byte{1, 2, 3, 4},
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatalf("couldn't create certificate: %v", err)
+	}
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("couldn't parse certificate: %v", err)
+	}
+
+	if got := extensions.ValidateCertificate(cert); len(got) != 0 {
+		t.Errorf("got findings %v, want none", got)
+	}
+}
+
+func TestValidateCertificateLegacySignature(t *testing.T) {
+	t.Parallel()
+
+	key, err := rsa.GenerateKey(rand.Reader, 2048)
+	if err != nil {
+		t.Fatalf("couldn't generate key: %v", err)
+	}
+
+	var template = &x509.Certificate{
+		SerialNumber:          big.NewInt(1),
+		Subject:               pkix.Name{CommonName: "Legacy CA"},
+		NotBefore:             time.Now(),
+		NotAfter:              time.Now().Add(time.Hour),
+		KeyUsage:              x509.KeyUsageCertSign | x509.KeyUsageCRLSign,
+		BasicConstraintsValid: true,
+		IsCA:                  true,
+		SubjectKeyId:          []byte{1, 2, 3, 4},
+		SignatureAlgorithm:    x509.SHA256WithRSA,
+	}
+
+	der, err := x509.CreateCertificate(rand.Reader, template, template, key.Public(), key)
+	if err != nil {
+		t.Fatalf("couldn't create certificate: %v", err)
+	}
+
+	// crypto/x509 won't sign with MD5, so relabel the signature algorithm,
+	// which appears in both the certificate and the TBS certificate.
+	var sha256WithRSA = mustMarshalOID(t, asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 11})
+	var md5WithRSA = mustMarshalOID(t, asn1.ObjectIdentifier{1, 2, 840, 113549, 1, 1, 4})
+	der = bytes.ReplaceAll(der, sha256WithRSA, md5WithRSA)
+
+	cert, err := x509.ParseCertificate(der)
+	if err != nil {
+		t.Fatalf("couldn't parse certificate: %v", err)
+	}
+
+	if cert.SignatureAlgorithm != x509.MD5WithRSA {
+		t.Fatalf("got signature algorithm %v, want %v", cert.SignatureAlgorithm, x509.MD5WithRSA)
+	}
+
+	if got := extensions.ValidateCertificate(cert); len(got) != 0 {
+		t.Errorf("got findings %v, want none", got)
+	}
+}
+
+func mustMarshalOID(t *testing.T, oid asn1.ObjectIdentifier) []byte {
+	t.Helper()
+
+	der, err := asn1.Marshal(oid)
+	if err != nil {
+		t.Fatalf("couldn't marshal OID: %v", err)
+	}
+
+	return der
+}
+
+func mustMarshal(t *testing.T, e extensions.Extension) pkix.Extension {
+	t.Helper()
+
+	ext, err := e.Marshal()
+	if err != nil {
+		t.Fatalf("couldn't marshal extension: %v", err)
+	}
+
+	return ext
+}